@@ -39,8 +39,8 @@ func main() {
 		AllowOrigins:     "http://localhost:5173",
 		AllowCredentials: true,
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
-		ExposeHeaders:    "Content-Length",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
+		ExposeHeaders:    "Content-Length, Idempotent-Replayed",
 		MaxAge:           3600,
 	}))
 	app.Get("/swagger.yaml", func(c *fiber.Ctx) error {
@@ -30,6 +30,22 @@ func Migrate(db *gorm.DB, log *logrus.Logger) {
         log.Fatalf("failed to migrate Product entity: %v", err)
     }
 
+    if err := db.AutoMigrate(&entity.ExchangeRate{}); err != nil {
+        log.Fatalf("failed to migrate ExchangeRate entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Warehouse{}); err != nil {
+        log.Fatalf("failed to migrate Warehouse entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.WarehouseStock{}); err != nil {
+        log.Fatalf("failed to migrate WarehouseStock entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Checkout{}); err != nil {
+        log.Fatalf("failed to migrate Checkout entity: %v", err)
+    }
+
     if err := db.AutoMigrate(&entity.Order{}); err != nil {
         log.Fatalf("failed to migrate Order entity: %v", err)
     }
@@ -46,6 +62,132 @@ func Migrate(db *gorm.DB, log *logrus.Logger) {
         log.Fatalf("failed to migrate Shipping entity: %v", err)
     }
 
+    if err := db.AutoMigrate(&entity.InventoryMovement{}); err != nil {
+        log.Fatalf("failed to migrate InventoryMovement entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Notification{}); err != nil {
+        log.Fatalf("failed to migrate Notification entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.StockSubscription{}); err != nil {
+        log.Fatalf("failed to migrate StockSubscription entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Cart{}); err != nil {
+        log.Fatalf("failed to migrate Cart entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.CartItem{}); err != nil {
+        log.Fatalf("failed to migrate CartItem entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.IdempotencyKey{}); err != nil {
+        log.Fatalf("failed to migrate IdempotencyKey entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.OrderStatusHistory{}); err != nil {
+        log.Fatalf("failed to migrate OrderStatusHistory entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.ReturnRequest{}); err != nil {
+        log.Fatalf("failed to migrate ReturnRequest entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.ReturnItem{}); err != nil {
+        log.Fatalf("failed to migrate ReturnItem entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.ReturnPhoto{}); err != nil {
+        log.Fatalf("failed to migrate ReturnPhoto entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Refund{}); err != nil {
+        log.Fatalf("failed to migrate Refund entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.MessageThread{}); err != nil {
+        log.Fatalf("failed to migrate MessageThread entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Message{}); err != nil {
+        log.Fatalf("failed to migrate Message entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.MessageAttachment{}); err != nil {
+        log.Fatalf("failed to migrate MessageAttachment entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.MessageThreadRead{}); err != nil {
+        log.Fatalf("failed to migrate MessageThreadRead entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.OrderDocument{}); err != nil {
+        log.Fatalf("failed to migrate OrderDocument entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.OrderExport{}); err != nil {
+        log.Fatalf("failed to migrate OrderExport entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Voucher{}); err != nil {
+        log.Fatalf("failed to migrate Voucher entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.VoucherRedemption{}); err != nil {
+        log.Fatalf("failed to migrate VoucherRedemption entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Promotion{}); err != nil {
+        log.Fatalf("failed to migrate Promotion entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.PromotionTier{}); err != nil {
+        log.Fatalf("failed to migrate PromotionTier entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.OrderItemDiscount{}); err != nil {
+        log.Fatalf("failed to migrate OrderItemDiscount entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.ShippingRate{}); err != nil {
+        log.Fatalf("failed to migrate ShippingRate entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.TaxRate{}); err != nil {
+        log.Fatalf("failed to migrate TaxRate entity: %v", err)
+    }
+
+    if err := db.AutoMigrate(&entity.Address{}); err != nil {
+        log.Fatalf("failed to migrate Address entity: %v", err)
+    }
+
+    // Order items placed before items kept a product snapshot take it from
+    // the product as it is now; the unit price is derived from the item
+    // total, which did not change with the product.
+    if err := db.Exec(`UPDATE order_items
+        JOIN orders ON orders.id = order_items.order_id
+        JOIN products ON products.id = order_items.product_id
+        SET order_items.product_uuid = products.product_uuid,
+            order_items.product_name = products.product_name,
+            order_items.sku = products.sku,
+            order_items.image_url = products.image_url,
+            order_items.unit_price = ROUND((order_items.total_price + order_items.discount_amount - IF(orders.tax_inclusive, 0, order_items.tax_amount)) / order_items.quantity, 2)
+        WHERE order_items.product_uuid = ''`).Error; err != nil {
+        log.Fatalf("failed to backfill order item snapshots: %v", err)
+    }
+
+    // Orders placed before orders kept their store take it from the
+    // products of their items, deleted ones included.
+    if err := db.Exec(`UPDATE orders
+        JOIN (SELECT order_items.order_id, MIN(products.store_id) AS store_id
+            FROM order_items JOIN products ON products.id = order_items.product_id
+            GROUP BY order_items.order_id) items ON items.order_id = orders.id
+        SET orders.store_id = items.store_id
+        WHERE orders.store_id = 0`).Error; err != nil {
+        log.Fatalf("failed to backfill order stores: %v", err)
+    }
+
 	// Event
 	if err := db.AutoMigrate(&evententity.OrderEvent{}); err != nil {
 		log.Fatalf("failed to migrate OrderEvent entity: %v", err)
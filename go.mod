@@ -3,6 +3,7 @@ module github.com/abdisetiakawan/go-ecommerce
 go 1.23
 
 require (
+	github.com/go-sql-driver/mysql v1.7.0
 	github.com/google/uuid v1.6.0
 	golang.org/x/crypto v0.32.0
 	gorm.io/gorm v1.25.12
@@ -27,7 +28,6 @@ require (
 	github.com/go-openapi/swag v0.23.0 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
-	github.com/go-sql-driver/mysql v1.7.0 // indirect
 	github.com/gofiber/swagger v1.1.1 // indirect
 	github.com/gofiber/utils v0.0.10 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
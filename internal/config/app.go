@@ -10,6 +10,7 @@ import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	"github.com/abdisetiakawan/go-ecommerce/internal/repository"
 	eventrepository "github.com/abdisetiakawan/go-ecommerce/internal/repository/event_repository"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase"
 	eventuc "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc"
 	"github.com/go-playground/validator/v10"
@@ -41,13 +42,64 @@ func Bootstrap(config *BootstrapConfig) {
 	productRepository := repository.NewProductRepository(config.DB)
 	storeRepository := repository.NewStoreRepository(config.DB)
 	shippingRepository := repository.NewShippingRepository(config.DB)
+	inventoryRepository := repository.NewInventoryRepository(config.DB)
+	notificationRepository := repository.NewNotificationRepository(config.DB)
+	stockSubscriptionRepository := repository.NewStockSubscriptionRepository(config.DB)
+	warehouseRepository := repository.NewWarehouseRepository(config.DB)
+	exchangeRateRepository := repository.NewExchangeRateRepository(config.DB)
+	cartRepository := repository.NewCartRepository(config.DB)
+	checkoutRepository := repository.NewCheckoutRepository(config.DB)
+	idempotencyRepository := repository.NewIdempotencyRepository(config.DB)
+	orderStatusRepository := repository.NewOrderStatusRepository(config.DB)
+	returnRepository := repository.NewReturnRepository(config.DB)
+	refundRepository := repository.NewRefundRepository(config.DB)
+	messageRepository := repository.NewMessageRepository(config.DB)
+	orderDocumentRepository := repository.NewOrderDocumentRepository(config.DB)
+	exportRepository := repository.NewExportRepository(config.DB)
+	voucherRepository := repository.NewVoucherRepository(config.DB)
+	promotionRepository := repository.NewPromotionRepository(config.DB)
+	shippingRateRepository := repository.NewShippingRateRepository(config.DB)
+	taxRateRepository := repository.NewTaxRateRepository(config.DB)
+	addressRepository := repository.NewAddressRepository(config.DB)
 
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Validate, userRepository, config.UserUUID, config.Jwt)
 	profileUseCase := usecase.NewProfileUseCase(config.DB, config.Validate, profileRepository)
-	orderUseCase := usecase.NewOrderUseCase(config.DB, config.Validate, orderRepository, productRepository, storeRepository, config.UserUUID, orderEventUC)
-	productUseCase := usecase.NewProductUseCase(config.DB, config.Validate, productRepository, storeRepository, config.UserUUID)
+	notificationUseCase := usecase.NewNotificationUseCase(config.DB, config.Validate, notificationRepository, config.UserUUID)
+	inventoryUseCase := usecase.NewInventoryUseCase(config.DB, config.Validate, inventoryRepository, productRepository, warehouseRepository, stockSubscriptionRepository, notificationUseCase, config.UserUUID)
+	stockSubscriptionUseCase := usecase.NewStockSubscriptionUseCase(config.Validate, stockSubscriptionRepository, productRepository)
+	exchangeRateUseCase := usecase.NewExchangeRateUseCase(config.DB, config.Validate, exchangeRateRepository)
+	warehouseUseCase := usecase.NewWarehouseUseCase(config.DB, config.Validate, warehouseRepository, storeRepository, productRepository, config.UserUUID)
+	refundUseCase := usecase.NewRefundUseCase(refundRepository, config.UserUUID)
+	voucherUseCase := usecase.NewVoucherUseCase(config.DB, config.Validate, voucherRepository, storeRepository, productRepository, exchangeRateUseCase, config.UserUUID)
+	// courier integrations quoting their own rates are registered here next
+	// to the rate table
+	shippingRateUseCase := usecase.NewShippingRateUseCase(config.DB, config.Validate, shippingRateRepository, exchangeRateUseCase, config.UserUUID, []shipping.Provider{
+		shipping.NewRateTable(config.DB, shippingRateRepository),
+	})
+	taxRateUseCase := usecase.NewTaxRateUseCase(config.DB, config.Validate, taxRateRepository, config.UserUUID)
+	addressUseCase := usecase.NewAddressUseCase(config.DB, config.Validate, addressRepository, config.UserUUID)
+	promotionUseCase := usecase.NewPromotionUseCase(config.DB, config.Validate, promotionRepository, storeRepository, productRepository, exchangeRateUseCase, config.UserUUID)
+	orderUseCase := usecase.NewOrderUseCase(config.DB, config.Validate, orderRepository, productRepository, storeRepository, inventoryUseCase, warehouseUseCase, exchangeRateUseCase, orderStatusRepository, orderEventRepo, notificationUseCase, refundUseCase, voucherUseCase, promotionUseCase, shippingRateUseCase, taxRateUseCase, addressUseCase, config.UserUUID, orderEventUC)
+	checkoutUseCase := usecase.NewCheckoutUseCase(config.DB, config.Validate, checkoutRepository, productRepository, orderUseCase, voucherUseCase, addressUseCase, orderEventUC, config.UserUUID)
+	cartUseCase := usecase.NewCartUseCase(config.DB, config.Validate, cartRepository, productRepository, checkoutUseCase, promotionUseCase, config.UserUUID)
+	userUseCase := usecase.NewUserUseCase(config.DB, config.Validate, userRepository, config.UserUUID, config.Jwt, cartUseCase)
+	productUseCase := usecase.NewProductUseCase(config.DB, config.Validate, productRepository, storeRepository, warehouseRepository, inventoryUseCase, exchangeRateUseCase, config.UserUUID)
 	storeUseCase := usecase.NewStoreUseCase(config.DB, config.Validate, storeRepository, config.UserUUID)
-	shippingUseCase := usecase.NewShippingUseCase(config.DB, config.Validate, shippingRepository, storeRepository, orderRepository, config.UserUUID, orderEventUC)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(config.DB, config.Validate, idempotencyRepository, config.UserUUID, config.Config.GetDuration("IDEMPOTENCY_TTL"), config.Config.GetDuration("IDEMPOTENCY_LEASE"))
+	orderPolicyUseCase := usecase.NewOrderPolicyUseCase(config.DB, orderRepository, orderStatusRepository, orderUseCase, notificationUseCase, orderEventUC, usecase.OrderPolicyConfig{
+		PaymentWindow:      config.Config.GetDuration("ORDER_PAYMENT_WINDOW"),
+		PaymentReminder:    config.Config.GetDuration("ORDER_PAYMENT_REMINDER"),
+		CompletionDelay:    time.Duration(config.Config.GetInt("ORDER_AUTO_COMPLETE_DAYS")) * 24 * time.Hour,
+		CompletionReminder: config.Config.GetDuration("ORDER_COMPLETE_REMINDER"),
+	})
+	returnUseCase := usecase.NewReturnUseCase(config.DB, config.Validate, returnRepository, orderRepository, storeRepository, orderStatusRepository, inventoryUseCase, refundUseCase, notificationUseCase, orderEventUC, config.UserUUID, time.Duration(config.Config.GetInt("RETURN_WINDOW_DAYS"))*24*time.Hour)
+	messageUseCase := usecase.NewMessageUseCase(config.DB, config.Validate, messageRepository, orderRepository, productRepository, storeRepository, userRepository, notificationUseCase, config.UserUUID)
+	orderDocumentUseCase := usecase.NewOrderDocumentUseCase(config.DB, config.Validate, orderDocumentRepository, orderRepository, storeRepository, userRepository, profileRepository, orderStatusRepository, config.UserUUID)
+	exportUseCase := usecase.NewExportUseCase(config.DB, config.Validate, exportRepository, orderRepository, storeRepository, notificationUseCase, config.UserUUID, usecase.ExportConfig{
+		SyncLimit: config.Config.GetInt("EXPORT_SYNC_LIMIT"),
+		TTL:       config.Config.GetDuration("EXPORT_TTL"),
+		Dir:       config.Config.GetString("EXPORT_DIR"),
+	})
+	shippingUseCase := usecase.NewShippingUseCase(config.DB, config.Validate, shippingRepository, storeRepository, orderRepository, orderStatusRepository, config.UserUUID, orderEventUC)
 
 	userController := http.NewUserController(userUseCase)
 	profileController := http.NewProfileController(profileUseCase)
@@ -55,6 +107,22 @@ func Bootstrap(config *BootstrapConfig) {
 	productController := http.NewProductController(productUseCase)
 	storeController := http.NewStoreController(storeUseCase)
 	shippingController := http.NewShippingController(shippingUseCase)
+	inventoryController := http.NewInventoryController(inventoryUseCase)
+	notificationController := http.NewNotificationController(notificationUseCase)
+	stockSubscriptionController := http.NewStockSubscriptionController(stockSubscriptionUseCase)
+	warehouseController := http.NewWarehouseController(warehouseUseCase)
+	exchangeRateController := http.NewExchangeRateController(exchangeRateUseCase)
+	cartController := http.NewCartController(cartUseCase)
+	checkoutController := http.NewCheckoutController(checkoutUseCase)
+	returnController := http.NewReturnController(returnUseCase)
+	messageController := http.NewMessageController(messageUseCase)
+	orderDocumentController := http.NewOrderDocumentController(orderDocumentUseCase)
+	exportController := http.NewExportController(exportUseCase)
+	voucherController := http.NewVoucherController(voucherUseCase)
+	promotionController := http.NewPromotionController(promotionUseCase)
+	shippingRateController := http.NewShippingRateController(shippingRateUseCase)
+	taxRateController := http.NewTaxRateController(taxRateUseCase)
+	addressController := http.NewAddressController(addressUseCase)
 
 	go func() {
 		ticker := time.NewTicker(5 * time.Minute)
@@ -65,7 +133,35 @@ func Bootstrap(config *BootstrapConfig) {
 		}
 	}()
 
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		for range ticker.C {
+			if err := idempotencyUseCase.PurgeExpired(context.Background()); err != nil {
+				logrus.WithError(err).Error("Failed to purge idempotency keys")
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		for range ticker.C {
+			if err := orderPolicyUseCase.Run(context.Background()); err != nil {
+				logrus.WithError(err).Error("Failed to run order policies")
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		for range ticker.C {
+			if err := exportUseCase.RunPendingExports(context.Background()); err != nil {
+				logrus.WithError(err).Error("Failed to run order exports")
+			}
+		}
+	}()
+
 	AuthMiddleware := middleware.NewAuth(config.Config)
+	IdempotencyMiddleware := middleware.NewIdempotency(idempotencyUseCase)
 	routeConfig := &route.RouteConfig{
 		App:                config.App,
 		ProfileController:  profileController,
@@ -74,7 +170,24 @@ func Bootstrap(config *BootstrapConfig) {
 		ProductController:  productController,
 		StoreController:    storeController,
 		ShippingController: shippingController,
+		InventoryController: inventoryController,
+		NotificationController: notificationController,
+		StockSubscriptionController: stockSubscriptionController,
+		WarehouseController: warehouseController,
+		ExchangeRateController: exchangeRateController,
+		CartController: cartController,
+		CheckoutController: checkoutController,
+		ReturnController: returnController,
+		MessageController: messageController,
+		OrderDocumentController: orderDocumentController,
+		ExportController: exportController,
+		VoucherController: voucherController,
+		PromotionController: promotionController,
+		ShippingRateController: shippingRateController,
+		TaxRateController: taxRateController,
+		AddressController: addressController,
 		AuthMiddleware:     AuthMiddleware,
+		IdempotencyMiddleware: IdempotencyMiddleware,
 	}
 	routeConfig.Setup()
 }
@@ -28,6 +28,10 @@ func BootstrapConsumers(cfg *ConsumerBootstrapConfig) ([]*helper.KafkaConsumer,
 	if err != nil {
 		return nil, err
 	}
+	refundPaymentConsumer, err := helper.NewKafkaConsumer(cfg.Config, "payment-refund-consumer")
+	if err != nil {
+		return nil, err
+	}
 	createShippingConsumer, err := helper.NewKafkaConsumer(cfg.Config, "shipping-create-consumer")
 	if err != nil {
 		return nil, err
@@ -41,12 +45,15 @@ func BootstrapConsumers(cfg *ConsumerBootstrapConfig) ([]*helper.KafkaConsumer,
 		return nil, err
 	}
 
-	createPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, createPaymentConsumer)
-	cancelPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, cancelPaymentConsumer)
-	checkoutPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, checkoutPaymentConsumer)
-	createShippingRepo := repository.NewShippingConsumerHandler(cfg.DB, createShippingConsumer)
-	cancelShippingRepo := repository.NewShippingConsumerHandler(cfg.DB, cancelShippingConsumer)
-	orderStatusRepo := repository.NewOrderConsumerHandler(cfg.DB, orderStatusConsumer)
+	orderStatusRepository := repository.NewOrderStatusRepository(cfg.DB)
+
+	createPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, createPaymentConsumer, orderStatusRepository)
+	cancelPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, cancelPaymentConsumer, orderStatusRepository)
+	checkoutPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, checkoutPaymentConsumer, orderStatusRepository)
+	refundPaymentRepo := repository.NewPaymentConsumerHandler(cfg.DB, refundPaymentConsumer, orderStatusRepository)
+	createShippingRepo := repository.NewShippingConsumerHandler(cfg.DB, createShippingConsumer, orderStatusRepository)
+	cancelShippingRepo := repository.NewShippingConsumerHandler(cfg.DB, cancelShippingConsumer, orderStatusRepository)
+	orderStatusRepo := repository.NewOrderConsumerHandler(cfg.DB, orderStatusConsumer, orderStatusRepository)
 
 	go func() {
 		ctx := context.Background()
@@ -69,6 +76,13 @@ func BootstrapConsumers(cfg *ConsumerBootstrapConfig) ([]*helper.KafkaConsumer,
 		}
 	}()
 
+	go func() {
+		ctx := context.Background()
+		if err := refundPaymentRepo.RefundPayment(ctx); err != nil {
+			logrus.Error(err)
+		}
+	}()
+
 	go func() {
 		ctx := context.Background()
 		if err := createShippingRepo.CreateShipping(ctx); err != nil {
@@ -94,6 +108,7 @@ func BootstrapConsumers(cfg *ConsumerBootstrapConfig) ([]*helper.KafkaConsumer,
 		createPaymentConsumer,
 		cancelPaymentConsumer,
 		checkoutPaymentConsumer,
+		refundPaymentConsumer,
 		createShippingConsumer,
 		cancelShippingConsumer,
 		orderStatusConsumer,
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AddressController struct {
+	uc interfaces.AddressUseCase
+}
+
+func NewAddressController(usecase interfaces.AddressUseCase) *AddressController {
+	return &AddressController{
+		uc: usecase,
+	}
+}
+
+// CreateAddress handles POST /addresses endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.CreateAddressRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.AddressResponse if the address is added successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if creation fails.
+func (c *AddressController) CreateAddress(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.CreateAddressRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	response, err := c.uc.CreateAddress(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created address", fiber.StatusCreated, nil, nil))
+}
+
+// GetAddresses handles GET /addresses endpoint for buyer.
+//
+// Returns:
+//
+//   - 200 OK: list of model.AddressResponse, the default address first.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *AddressController) GetAddresses(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	response, err := c.uc.GetAddresses(ctx.UserContext(), auth.ID)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get addresses", fiber.StatusOK, nil, nil))
+}
+
+// GetAddress handles GET /addresses/{address_uuid} endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the address UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.AddressResponse of the address.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *AddressController) GetAddress(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetAddressRequest{
+		UserID:      auth.ID,
+		AddressUUID: ctx.Params("address_uuid"),
+	}
+	response, err := c.uc.GetAddress(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get address", fiber.StatusOK, nil, nil))
+}
+
+// UpdateAddress handles PUT /addresses/{address_uuid} endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the address UUID path parameter and request body model.UpdateAddressRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.AddressResponse if the address is updated successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *AddressController) UpdateAddress(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.UpdateAddressRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	request.AddressUUID = ctx.Params("address_uuid")
+	response, err := c.uc.UpdateAddress(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated address", fiber.StatusOK, nil, nil))
+}
+
+// DeleteAddress handles DELETE /addresses/{address_uuid} endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the address UUID path parameter.
+//
+// Returns:
+//
+//   - 204 No Content: if the address is deleted successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if deletion fails.
+func (c *AddressController) DeleteAddress(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetAddressRequest{
+		UserID:      auth.ID,
+		AddressUUID: ctx.Params("address_uuid"),
+	}
+	if err := c.uc.DeleteAddress(ctx.UserContext(), request); err != nil {
+		return err
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CartTokenCookie holds the token of an anonymous visitor's cart.
+const CartTokenCookie = "cart_token"
+
+type CartController struct {
+	uc interfaces.CartUseCase
+}
+
+func NewCartController(usecase interfaces.CartUseCase) *CartController {
+	return &CartController{
+		uc: usecase,
+	}
+}
+
+// cartOwner resolves the cart a request works on: the logged in buyer's cart
+// on buyer routes, or the guest cart named by the cart_token cookie.
+func cartOwner(ctx *fiber.Ctx) model.CartOwner {
+	if auth, ok := ctx.Locals("auth").(*model.Auth); ok && auth != nil {
+		return model.CartOwner{UserID: auth.ID}
+	}
+	return model.CartOwner{GuestToken: cartToken(ctx)}
+}
+
+// cartToken returns the token of the cart_token cookie, or an empty token
+// when the cookie is missing or not a token this API issued, so the visitor
+// starts a new guest cart instead.
+func cartToken(ctx *fiber.Ctx) string {
+	token, err := uuid.Parse(ctx.Cookies(CartTokenCookie))
+	if err != nil {
+		return ""
+	}
+	return token.String()
+}
+
+// GetCart handles GET /cart endpoint for buyers and guests.
+//
+// Returns:
+//
+//   - 200 OK: model.CartResponse with live prices, stock and warnings.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *CartController) GetCart(ctx *fiber.Ctx) error {
+	response, err := c.uc.GetCart(ctx.UserContext(), &model.GetCartRequest{CartOwner: cartOwner(ctx)})
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get cart", fiber.StatusOK, nil, nil))
+}
+
+// AddItem handles POST /cart/items endpoint for buyers and guests.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.AddCartItemRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.CartResponse after the product is added. A guest without a
+//     cart receives a cart_token cookie.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the product cannot be added.
+func (c *CartController) AddItem(ctx *fiber.Ctx) error {
+	request := new(model.AddCartItemRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.CartOwner = cartOwner(ctx)
+	response, err := c.uc.AddItem(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	if request.UserID == 0 && response.GuestToken != "" {
+		ctx.Cookie(&fiber.Cookie{
+			Name:     CartTokenCookie,
+			Value:    response.GuestToken,
+			HTTPOnly: true,
+			Secure:   true,
+			SameSite: "Strict",
+			Expires:  time.Now().Add(30 * 24 * time.Hour),
+			Path:     "/",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully added item to cart", fiber.StatusOK, nil, nil))
+}
+
+// UpdateItem handles PATCH /cart/items/{product_uuid} endpoint for buyers and guests.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the product UUID path parameter and request body model.UpdateCartItemRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.CartResponse after the quantity is updated.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the item cannot be updated.
+func (c *CartController) UpdateItem(ctx *fiber.Ctx) error {
+	request := new(model.UpdateCartItemRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.CartOwner = cartOwner(ctx)
+	request.ProductUUID = ctx.Params("product_uuid")
+	response, err := c.uc.UpdateItem(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated cart item", fiber.StatusOK, nil, nil))
+}
+
+// RemoveItem handles DELETE /cart/items/{product_uuid} endpoint for buyers and guests.
+//
+// Returns:
+//
+//   - 200 OK: model.CartResponse after the item is removed.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the item cannot be removed.
+func (c *CartController) RemoveItem(ctx *fiber.Ctx) error {
+	request := &model.RemoveCartItemRequest{
+		CartOwner:   cartOwner(ctx),
+		ProductUUID: ctx.Params("product_uuid"),
+	}
+	response, err := c.uc.RemoveItem(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully removed cart item", fiber.StatusOK, nil, nil))
+}
+
+// Checkout handles POST /cart/checkout endpoint for buyers.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.CheckoutCartRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.CheckoutResponse with one order per store in the cart.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if checkout fails.
+func (c *CartController) Checkout(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.CheckoutCartRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	response, err := c.uc.Checkout(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully checked out cart", fiber.StatusCreated, nil, nil))
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CheckoutController struct {
+	uc interfaces.CheckoutUseCase
+}
+
+func NewCheckoutController(usecase interfaces.CheckoutUseCase) *CheckoutController {
+	return &CheckoutController{
+		uc: usecase,
+	}
+}
+
+// CreateCheckout handles POST /checkouts endpoint for buyers. Items from
+// several stores are split into one order per store.
+//
+// Parameters:
+//
+//   - request body: model.CreateCheckout
+//
+// Returns:
+//
+//   - 201 Created: model.CheckoutResponse with the orders created for each store.
+//
+// Errors:
+//
+//   - 400 Bad Request: If checkout items are empty
+//   - Propagates error from use case layer if any order cannot be placed.
+func (c *CheckoutController) CreateCheckout(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.CreateCheckout)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	if len(request.Items) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Checkout items cannot be empty")
+	}
+	response, err := c.uc.CreateCheckout(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created checkout", fiber.StatusCreated, nil, nil))
+}
+
+// GetCheckout handles GET /checkouts/{checkout_uuid} endpoint for buyers.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the checkout UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.CheckoutResponse with its orders.
+//
+// Errors:
+//
+//   - 404 Not Found: If the checkout does not exist.
+func (c *CheckoutController) GetCheckout(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetCheckoutRequest{
+		UserID:       auth.ID,
+		CheckoutUUID: ctx.Params("checkout_uuid"),
+	}
+	response, err := c.uc.GetCheckout(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully retrieved checkout", fiber.StatusOK, nil, nil))
+}
+
+// PayCheckout handles PATCH /checkouts/{checkout_uuid}/pay endpoint for
+// buyers. It pays every pending order of the checkout at once.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the checkout UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.CheckoutResponse after payment.
+//
+// Errors:
+//
+//   - 404 Not Found: If the checkout does not exist.
+//   - 409 Conflict: If the checkout is already paid or has no pending orders.
+func (c *CheckoutController) PayCheckout(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.PayCheckoutRequest{
+		UserID:       auth.ID,
+		CheckoutUUID: ctx.Params("checkout_uuid"),
+	}
+	response, err := c.uc.PayCheckout(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully paid checkout", fiber.StatusOK, nil, nil))
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExchangeRateController struct {
+	uc interfaces.ExchangeRateUseCase
+}
+
+func NewExchangeRateController(usecase interfaces.ExchangeRateUseCase) *ExchangeRateController {
+	return &ExchangeRateController{
+		uc: usecase,
+	}
+}
+
+// ImportRates handles POST /exchange-rates endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.ImportExchangeRatesRequest.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ExchangeRateResponse if the rates are stored successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the import fails.
+func (c *ExchangeRateController) ImportRates(ctx *fiber.Ctx) error {
+	request := new(model.ImportExchangeRatesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	response, err := c.uc.ImportRates(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully imported exchange rates", fiber.StatusOK, nil, nil))
+}
+
+// GetRates handles GET /exchange-rates endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the optional base query parameter.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ExchangeRateResponse.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ExchangeRateController) GetRates(ctx *fiber.Ctx) error {
+	request := &model.GetExchangeRatesRequest{
+		BaseCurrency: ctx.Query("base", ""),
+	}
+	response, err := c.uc.GetRates(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get exchange rates", fiber.StatusOK, nil, nil))
+}
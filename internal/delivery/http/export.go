@@ -0,0 +1,159 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ExportController struct {
+	uc interfaces.ExportUseCase
+}
+
+func NewExportController(usecase interfaces.ExportUseCase) *ExportController {
+	return &ExportController{
+		uc: usecase,
+	}
+}
+
+// ExportOrders handles GET /orders/export endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the format query parameter and the filters of the seller order list.
+//
+// Returns:
+//
+//   - 200 OK: the CSV or XLSX file as an attachment, for small exports.
+//   - 202 Accepted: model.ExportResponse of the background export, for large exports.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the export fails.
+func (c *ExportController) ExportOrders(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	filter, err := orderFilter(ctx)
+	if err != nil {
+		return err
+	}
+	request := &model.ExportOrdersRequest{
+		UserID:      auth.ID,
+		Format:      ctx.Query("format", "csv"),
+		Status:      ctx.Query("status", ""),
+		SortDate:    ctx.Query("sort_date", ""),
+		OrderFilter: filter,
+	}
+	file, export, err := c.uc.ExportOrders(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	if export != nil {
+		return ctx.Status(fiber.StatusAccepted).JSON(model.NewWebResponse(export, "Export is being prepared", fiber.StatusAccepted, nil, nil))
+	}
+	return sendExportFile(ctx, file)
+}
+
+// GetExports handles GET /exports endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the paging query parameters.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ExportResponse with paging metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ExportController) GetExports(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchExportRequest{
+		UserID: auth.ID,
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetExports(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get exports", fiber.StatusOK, paging, nil))
+}
+
+// GetExport handles GET /exports/{export_uuid} endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the export UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.ExportResponse with the status of the export.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ExportController) GetExport(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetExportRequest{
+		UserID:     auth.ID,
+		ExportUUID: ctx.Params("export_uuid"),
+	}
+	response, err := c.uc.GetExport(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get export", fiber.StatusOK, nil, nil))
+}
+
+// DownloadExport handles GET /exports/{export_uuid}/download endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the export UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: the CSV or XLSX file as an attachment.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the export is not ready or has expired.
+func (c *ExportController) DownloadExport(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetExportRequest{
+		UserID:     auth.ID,
+		ExportUUID: ctx.Params("export_uuid"),
+	}
+	file, err := c.uc.DownloadExport(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return sendExportFile(ctx, file)
+}
+
+// sendExportFile streams the file to the client while it is written. An
+// error past the headers can only be logged, the client gets a truncated
+// file.
+func sendExportFile(ctx *fiber.Ctx, file *model.ExportFile) error {
+	ctx.Set(fiber.HeaderContentType, file.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.FileName))
+	ctx.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := file.Write(w); err != nil {
+			logrus.WithError(err).WithField("file_name", file.FileName).Error("Failed to stream export")
+		}
+	})
+	return nil
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InventoryController struct {
+	uc interfaces.InventoryUseCase
+}
+
+func NewInventoryController(usecase interfaces.InventoryUseCase) *InventoryController {
+	return &InventoryController{
+		uc: usecase,
+	}
+}
+
+// AdjustStock handles POST /products/{product_uuid}/stock endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the product UUID path parameter and request body model.AdjustStockRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.InventoryMovementResponse if the stock movement is recorded successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if adjustment fails.
+func (c *InventoryController) AdjustStock(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.AdjustStockRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	request.ProductUUID = ctx.Params("product_uuid")
+	response, err := c.uc.AdjustStock(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully adjusted stock", fiber.StatusCreated, nil, nil))
+}
+
+// GetStockHistory handles GET /products/{product_uuid}/stock/history endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the product UUID path parameter and query parameters for filtering by reason, page, and limit.
+//
+// Returns:
+//
+//   - 200 OK: list of model.InventoryMovementResponse with pagination metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *InventoryController) GetStockHistory(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetStockHistoryRequest{
+		UserID:      auth.ID,
+		ProductUUID: ctx.Params("product_uuid"),
+		Reason:      ctx.Query("reason", ""),
+		Page:        ctx.QueryInt("page", 1),
+		Limit:       ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetStockHistory(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get stock history", fiber.StatusOK, paging, nil))
+}
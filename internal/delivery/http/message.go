@@ -0,0 +1,199 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MessageController struct {
+	uc interfaces.MessageUseCase
+}
+
+func NewMessageController(usecase interfaces.MessageUseCase) *MessageController {
+	return &MessageController{
+		uc: usecase,
+	}
+}
+
+// StartThread handles POST /threads endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.StartThreadRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.ThreadResponse with the sent message.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request body cannot be parsed.
+//   - Propagates error from use case layer if the message cannot be sent.
+func (c *MessageController) StartThread(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.StartThreadRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return model.ErrBadRequest
+	}
+	request.UserID = auth.ID
+	request.Role = auth.Role
+	response, err := c.uc.StartThread(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully sent message", fiber.StatusCreated, nil, nil))
+}
+
+// GetThreads handles GET /threads endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including query parameters unread, page, and limit.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ThreadResponse with pagination metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *MessageController) GetThreads(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchThreadRequest{
+		UserID: auth.ID,
+		Role:   auth.Role,
+		Unread: ctx.QueryBool("unread", false),
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetThreads(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get threads", fiber.StatusOK, messagePaging(request.Page, request.Limit, total), nil))
+}
+
+// GetUnreadCount handles GET /threads/unread-count endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request.
+//
+// Returns:
+//
+//   - 200 OK: model.UnreadCountResponse.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *MessageController) GetUnreadCount(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchThreadRequest{
+		UserID: auth.ID,
+		Role:   auth.Role,
+	}
+	response, err := c.uc.GetUnreadCount(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get unread count", fiber.StatusOK, nil, nil))
+}
+
+// GetThread handles GET /threads/{thread_uuid} endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the thread UUID path parameter and query parameters page and limit for the messages.
+//
+// Returns:
+//
+//   - 200 OK: model.ThreadResponse with a page of its messages, newest first, and pagination metadata of the messages.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *MessageController) GetThread(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetThreadRequest{
+		UserID:     auth.ID,
+		Role:       auth.Role,
+		ThreadUUID: ctx.Params("thread_uuid"),
+		Page:       ctx.QueryInt("page", 1),
+		Limit:      ctx.QueryInt("limit", 20),
+	}
+	response, total, err := c.uc.GetThread(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get thread", fiber.StatusOK, messagePaging(request.Page, request.Limit, total), nil))
+}
+
+// SendMessage handles POST /threads/{thread_uuid}/messages endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the thread UUID path parameter and request body model.SendMessageRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.MessageResponse if the message is sent successfully.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request body cannot be parsed.
+//   - Propagates error from use case layer if the message cannot be sent.
+func (c *MessageController) SendMessage(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.SendMessageRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return model.ErrBadRequest
+	}
+	request.UserID = auth.ID
+	request.Role = auth.Role
+	request.ThreadUUID = ctx.Params("thread_uuid")
+	response, err := c.uc.SendMessage(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully sent message", fiber.StatusCreated, nil, nil))
+}
+
+// MarkThreadRead handles PATCH /threads/{thread_uuid}/read endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the thread UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.ThreadResponse once every message of the thread is marked as read.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *MessageController) MarkThreadRead(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetThreadRequest{
+		UserID:     auth.ID,
+		Role:       auth.Role,
+		ThreadUUID: ctx.Params("thread_uuid"),
+	}
+	response, err := c.uc.MarkThreadRead(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully read thread", fiber.StatusOK, nil, nil))
+}
+
+func messagePaging(page, limit int, total int64) *model.PageMetadata {
+	return &model.PageMetadata{
+		Page:      page,
+		Size:      limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(limit))),
+	}
+}
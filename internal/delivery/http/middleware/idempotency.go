@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// NewIdempotency makes mutating requests that carry an Idempotency-Key header
+// safe to retry. The first request with a key runs normally and its response
+// is stored; a retry with the same key and the same method, URL and body gets
+// the stored response back without running the handler again. Reusing a key
+// for a different request returns 409 Conflict, and so does a retry while
+// the first request is still running, unless it outlived its processing
+// lease, see IdempotencyUseCase.Begin. Failed requests (errors and
+// 5xx responses) are not stored, so they can be retried with the same key.
+// Must run after the auth middleware, keys are scoped to the user.
+func NewIdempotency(uc interfaces.IdempotencyUseCase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || !isMutating(c.Method()) {
+			return c.Next()
+		}
+
+		auth := GetUser(c)
+		request := &model.IdempotencyRequest{
+			UserID:      auth.ID,
+			Key:         key,
+			RequestHash: requestHash(c),
+		}
+		stored, err := uc.Begin(c.UserContext(), request)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			c.Set(IdempotentReplayedHeader, "true")
+			if stored.ContentType != "" {
+				c.Set(fiber.HeaderContentType, stored.ContentType)
+			}
+			return c.Status(stored.StatusCode).Send(stored.Body)
+		}
+
+		if err := c.Next(); err != nil {
+			releaseKey(c, uc, request)
+			return err
+		}
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			releaseKey(c, uc, request)
+			return nil
+		}
+		response := &model.IdempotentResponse{
+			StatusCode:  status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		if err := uc.Complete(c.UserContext(), request, response); err != nil {
+			if err == model.ErrConflict {
+				logrus.WithField("user_id", request.UserID).Warn("Idempotency key was taken over, response not stored")
+				return nil
+			}
+			logrus.WithError(err).Error("Failed to store idempotent response")
+			releaseKey(c, uc, request)
+		}
+		return nil
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestHash fingerprints the method, URL and body of the request.
+func requestHash(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.OriginalURL()))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func releaseKey(c *fiber.Ctx, uc interfaces.IdempotencyUseCase, request *model.IdempotencyRequest) {
+	if err := uc.Release(c.UserContext(), request); err != nil {
+		if err == model.ErrConflict {
+			logrus.WithField("user_id", request.UserID).Warn("Idempotency key was taken over, not released")
+			return
+		}
+		logrus.WithError(err).Error("Failed to release idempotency key")
+	}
+}
@@ -14,6 +14,7 @@ const (
     Seller RateLimiterType = "seller"
     Buyer  RateLimiterType = "buyer"
     User   RateLimiterType = "user"
+    Admin  RateLimiterType = "admin"
 )
 
 type RateLimiterConfig struct {
@@ -79,4 +80,13 @@ func NewUserRateLimiter(maxRequests int, expiration time.Duration) fiber.Handler
         Expiration: expiration,
     }
     return limiter.New(createLimiterConfig(&config))
+}
+
+func NewAdminRateLimiter(maxRequests int, expiration time.Duration) fiber.Handler {
+    config := RateLimiterConfig{
+        Type:       Admin,
+        Max:        maxRequests,
+        Expiration: expiration,
+    }
+    return limiter.New(createLimiterConfig(&config))
 }
\ No newline at end of file
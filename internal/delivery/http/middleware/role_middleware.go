@@ -25,4 +25,15 @@ func BuyerOnly() fiber.Handler {
         }
         return ctx.Next()
     }
-}
\ No newline at end of file
+}
+
+// Middleware to verify if the user is an admin
+func AdminOnly() fiber.Handler {
+    return func(ctx *fiber.Ctx) error {
+        auth := ctx.Locals("auth").(*model.Auth)
+        if auth.Role != "admin" {
+            return model.ErrForbidden
+        }
+        return ctx.Next()
+    }
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationController struct {
+	uc interfaces.NotificationUseCase
+}
+
+func NewNotificationController(usecase interfaces.NotificationUseCase) *NotificationController {
+	return &NotificationController{
+		uc: usecase,
+	}
+}
+
+// GetNotifications handles GET /notifications endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including query parameters unread, page, and limit.
+//
+// Returns:
+//
+//   - 200 OK: list of model.NotificationResponse with pagination metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *NotificationController) GetNotifications(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetNotificationsRequest{
+		UserID: auth.ID,
+		Unread: ctx.QueryBool("unread", false),
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetNotifications(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get notifications", fiber.StatusOK, paging, nil))
+}
+
+// MarkAsRead handles PATCH /notifications/{notification_uuid}/read endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the notification UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.NotificationResponse if the notification is marked as read.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *NotificationController) MarkAsRead(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.ReadNotificationRequest{
+		UserID:           auth.ID,
+		NotificationUUID: ctx.Params("notification_uuid"),
+	}
+	response, err := c.uc.MarkAsRead(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully read notification", fiber.StatusOK, nil, nil))
+}
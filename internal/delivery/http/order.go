@@ -1,10 +1,12 @@
 package http
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/gofiber/fiber/v2"
 )
@@ -50,11 +52,40 @@ func (c *OrderController) CreateOrder(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created order", fiber.StatusCreated, nil, nil))
 }
 
+// GetShippingOptions handles POST /orders/shipping-options endpoint for buyer.
+// It quotes the shipping options of the items per store without placing an
+// order.
+//
+// Parameters:
+//
+//	* request body: model.ShippingOptionsRequest
+//
+// Returns:
+//
+//	* 200 OK: model.ShippingOptionsResponse
+//
+// Errors:
+//
+//	* Propagates error from use case layer if the items cannot be quoted.
+func (c *OrderController) GetShippingOptions(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.ShippingOptionsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	response, err := c.uc.GetShippingOptions(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get shipping options", fiber.StatusOK, nil, nil))
+}
+
 // GetOrdersByBuyer handles GET /orders endpoint for buyer.
 //
 // Parameters:
 //
-//	* ctx: fiber.Ctx - Context for the request, including query parameters for filtering orders by status, page, and limit.
+//	* ctx: fiber.Ctx - Context for the request, including query parameters for filtering orders by status, start_date, end_date, search, min_total, max_total, currency and payment_method, page, and limit.
 //
 // Returns:
 //
@@ -62,14 +93,20 @@ func (c *OrderController) CreateOrder(ctx *fiber.Ctx) error {
 //
 // Errors:
 //
+//	* 400 Bad Request: if min_total or max_total is not a decimal amount.
 //	* Propagates error from use case layer if retrieval fails.
 func (c *OrderController) GetOrdersByBuyer(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
+	filter, err := orderFilter(ctx)
+	if err != nil {
+		return err
+	}
 	request := &model.SearchOrderRequest{
-		UserID: auth.ID,
-		Status: ctx.Query("status", ""),
-		Page:   ctx.QueryInt("page", 1),
-		Limit:  ctx.QueryInt("limit", 10),
+		UserID:      auth.ID,
+		Status:      ctx.Query("status", ""),
+		OrderFilter: filter,
+		Page:        ctx.QueryInt("page", 1),
+		Limit:       ctx.QueryInt("limit", 10),
 	}
 	response, total, err := c.uc.GetOrdersByBuyer(ctx.UserContext(), request)
 	if err != nil {
@@ -137,6 +174,126 @@ func (c *OrderController) CancelOrder(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully cancel order", fiber.StatusOK, nil, nil))
 }
 
+// CancelOrderItemByBuyer handles PATCH /orders/{order_uuid}/items/{order_item_uuid}/cancel endpoint for buyer.
+//
+// Parameters:
+//
+//	* ctx: fiber.Ctx - Context for the request, including the order and order item UUID path parameters and an optional reason in the body.
+//
+// Returns:
+//
+//	* 200 OK: model.OrderResponse with the new total if the item is cancelled successfully.
+//
+// Errors:
+//
+//	* 400 Bad Request: if the request body cannot be parsed.
+//	* Propagates error from use case layer if the item cannot be cancelled.
+func (c *OrderController) CancelOrderItemByBuyer(ctx *fiber.Ctx) error {
+	request, err := cancelOrderItemRequest(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := c.uc.CancelOrderItemByBuyer(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully cancel order item", fiber.StatusOK, nil, nil))
+}
+
+// CancelOrderItemBySeller handles PATCH /orders/{order_uuid}/items/{order_item_uuid}/cancel endpoint for seller.
+//
+// Parameters:
+//
+//	* ctx: fiber.Ctx - Context for the request, including the order and order item UUID path parameters and an optional reason in the body.
+//
+// Returns:
+//
+//	* 200 OK: model.OrderResponse with the new total if the item is cancelled successfully.
+//
+// Errors:
+//
+//	* 400 Bad Request: if the request body cannot be parsed.
+//	* Propagates error from use case layer if the item cannot be cancelled.
+func (c *OrderController) CancelOrderItemBySeller(ctx *fiber.Ctx) error {
+	request, err := cancelOrderItemRequest(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := c.uc.CancelOrderItemBySeller(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully cancel order item", fiber.StatusOK, nil, nil))
+}
+
+func cancelOrderItemRequest(ctx *fiber.Ctx) (*model.CancelOrderItemRequest, error) {
+	request := new(model.CancelOrderItemRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return nil, model.ErrBadRequest
+		}
+	}
+	request.UserID = middleware.GetUser(ctx).ID
+	request.OrderUUID = ctx.Params("order_uuid")
+	request.OrderItemUUID = ctx.Params("order_item_uuid")
+	return request, nil
+}
+
+// ConfirmOrderReceipt handles PATCH /orders/{order_uuid}/confirm endpoint for buyer.
+//
+// Parameters:
+//
+//	* ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//	* 200 OK: model.OrderResponse if the order is completed successfully.
+//
+// Errors:
+//
+//	* Propagates error from use case layer if the order cannot be completed.
+func (c *OrderController) ConfirmOrderReceipt(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.ConfirmOrderRequest{
+		UserID: auth.ID,
+		OrderUUID: ctx.Params("order_uuid"),
+	}
+	response, err := c.uc.ConfirmOrderReceipt(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully confirm order receipt", fiber.StatusOK, nil, nil))
+}
+
+// ReportOrderProblem handles PATCH /orders/{order_uuid}/problem endpoint for buyer.
+//
+// Parameters:
+//
+//	* ctx: fiber.Ctx - Context for the request, including the order UUID path parameter and the reason in the body.
+//
+// Returns:
+//
+//	* 200 OK: model.OrderResponse if the problem is reported successfully.
+//
+// Errors:
+//
+//	* 400 Bad Request: if the request body cannot be parsed.
+//	* Propagates error from use case layer if the problem cannot be reported.
+func (c *OrderController) ReportOrderProblem(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.ReportOrderProblemRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return model.ErrBadRequest
+	}
+	request.UserID = auth.ID
+	request.OrderUUID = ctx.Params("order_uuid")
+	response, err := c.uc.ReportOrderProblem(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully report order problem", fiber.StatusOK, nil, nil))
+}
+
 // CheckoutOrder handles PATCH /orders/{order_uuid}/checkout endpoint for buyer.
 //
 // Parameters:
@@ -193,7 +350,7 @@ func (c *OrderController) GetOrderByIdSeller(ctx *fiber.Ctx) error {
 //
 // Parameters:
 //
-//	* ctx: fiber.Ctx - Context for the request, including query parameters for filtering orders by status, page, and limit.
+//	* ctx: fiber.Ctx - Context for the request, including query parameters for filtering orders by status, start_date, end_date, search, min_total, max_total, currency and payment_method, sort_date, page, and limit.
 //
 // Returns:
 //
@@ -201,15 +358,21 @@ func (c *OrderController) GetOrderByIdSeller(ctx *fiber.Ctx) error {
 //
 // Errors:
 //
+//	* 400 Bad Request: if min_total or max_total is not a decimal amount.
 //	* Propagates error from use case layer if retrieval fails.
 func (c *OrderController) GetOrdersBySeller(ctx *fiber.Ctx) error {
 	authID := middleware.GetUser(ctx)
+	filter, err := orderFilter(ctx)
+	if err != nil {
+		return err
+	}
 	request := &model.SearchOrderRequestBySeller{
-		UserID:   authID.ID,
-		Status:   ctx.Query("status", ""),
-		SortDate: ctx.Query("sort_date", ""),
-		Page:     ctx.QueryInt("page", 1),
-		Limit:    ctx.QueryInt("limit", 10),
+		UserID:      authID.ID,
+		Status:      ctx.Query("status", ""),
+		SortDate:    ctx.Query("sort_date", ""),
+		OrderFilter: filter,
+		Page:        ctx.QueryInt("page", 1),
+		Limit:       ctx.QueryInt("limit", 10),
 	}
 	response, total, err := c.uc.GetOrdersBySeller(ctx.UserContext(), request)
 	if err != nil {
@@ -223,3 +386,82 @@ func (c *OrderController) GetOrdersBySeller(ctx *fiber.Ctx) error {
 	}
 	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get orders", fiber.StatusOK, paging, nil))
 }
+
+// GetOrderTimelineByBuyer handles GET /orders/{order_uuid}/timeline endpoint for buyer.
+//
+// Parameters:
+//
+//	* ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//	* 200 OK: model.OrderTimelineResponse with the lifecycle events of the order.
+//
+// Errors:
+//
+//	* Propagates error from use case layer if retrieval fails.
+func (c *OrderController) GetOrderTimelineByBuyer(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetOrderDetails{
+		UserID:    auth.ID,
+		OrderUUID: ctx.Params("order_uuid"),
+	}
+	response, err := c.uc.GetOrderTimelineByBuyer(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get order timeline", fiber.StatusOK, nil, nil))
+}
+
+// GetOrderTimelineBySeller handles GET /orders/{order_uuid}/timeline endpoint for seller.
+//
+// Parameters:
+//
+//	* ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//	* 200 OK: model.OrderTimelineResponse with the lifecycle events of the order.
+//
+// Errors:
+//
+//	* Propagates error from use case layer if retrieval fails.
+func (c *OrderController) GetOrderTimelineBySeller(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetOrderDetails{
+		UserID:    auth.ID,
+		OrderUUID: ctx.Params("order_uuid"),
+	}
+	response, err := c.uc.GetOrderTimelineBySeller(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get order timeline", fiber.StatusOK, nil, nil))
+}
+
+// orderFilter reads the filters shared by the buyer and seller order lists
+// from the query string.
+func orderFilter(ctx *fiber.Ctx) (model.OrderFilter, error) {
+	filter := model.OrderFilter{
+		StartDate:     ctx.Query("start_date", ""),
+		EndDate:       ctx.Query("end_date", ""),
+		Search:        ctx.Query("search", ""),
+		PaymentMethod: ctx.Query("payment_method", ""),
+		Currency:      ctx.Query("currency", ""),
+	}
+	for name, target := range map[string]**money.Amount{
+		"min_total": &filter.MinTotal,
+		"max_total": &filter.MaxTotal,
+	} {
+		value := ctx.Query(name, "")
+		if value == "" {
+			continue
+		}
+		amount, err := money.Parse(value)
+		if err != nil {
+			return filter, model.NewApiError(fiber.StatusBadRequest, fmt.Sprintf("%s must be a decimal amount", name), nil)
+		}
+		*target = &amount
+	}
+	return filter, nil
+}
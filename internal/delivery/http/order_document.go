@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderDocumentController struct {
+	uc interfaces.OrderDocumentUseCase
+}
+
+func NewOrderDocumentController(usecase interfaces.OrderDocumentUseCase) *OrderDocumentController {
+	return &OrderDocumentController{
+		uc: usecase,
+	}
+}
+
+// GetInvoiceByBuyer handles GET /orders/{order_uuid}/invoice endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: the invoice PDF as an attachment.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the invoice cannot be issued.
+func (c *OrderDocumentController) GetInvoiceByBuyer(ctx *fiber.Ctx) error {
+	return c.sendDocument(ctx, c.uc.GetDocumentByBuyer, "invoice")
+}
+
+// GetReceiptByBuyer handles GET /orders/{order_uuid}/receipt endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: the receipt PDF as an attachment.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the receipt cannot be issued.
+func (c *OrderDocumentController) GetReceiptByBuyer(ctx *fiber.Ctx) error {
+	return c.sendDocument(ctx, c.uc.GetDocumentByBuyer, "receipt")
+}
+
+// GetInvoiceBySeller handles GET /orders/{order_uuid}/invoice endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: the invoice PDF as an attachment.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the invoice cannot be issued.
+func (c *OrderDocumentController) GetInvoiceBySeller(ctx *fiber.Ctx) error {
+	return c.sendDocument(ctx, c.uc.GetDocumentBySeller, "invoice")
+}
+
+// GetReceiptBySeller handles GET /orders/{order_uuid}/receipt endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the order UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: the receipt PDF as an attachment.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the receipt cannot be issued.
+func (c *OrderDocumentController) GetReceiptBySeller(ctx *fiber.Ctx) error {
+	return c.sendDocument(ctx, c.uc.GetDocumentBySeller, "receipt")
+}
+
+func (c *OrderDocumentController) sendDocument(ctx *fiber.Ctx, get func(ctx context.Context, request *model.GetOrderDocumentRequest) (*model.OrderDocumentFile, error), documentType string) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetOrderDocumentRequest{
+		UserID:    auth.ID,
+		OrderUUID: ctx.Params("order_uuid"),
+		Type:      documentType,
+	}
+	document, err := get(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", document.FileName))
+	ctx.Set(fiber.HeaderETag, fmt.Sprintf("%q", document.Checksum))
+	return ctx.Status(fiber.StatusOK).Send(document.Content)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/gofiber/fiber/v2"
 )
@@ -35,13 +36,23 @@ func NewProductController(usecase interfaces.ProductUseCase) *ProductController
 //	* Propagates error from use case layer if retrieval fails.
 func (c *ProductController) GetProducts(ctx *fiber.Ctx) error {
 	authID := middleware.GetUser(ctx)
+	priceMin, err := money.Parse(ctx.Query("price_min"))
+	if err != nil {
+		return model.NewApiError(fiber.StatusBadRequest, "Invalid price_min", nil)
+	}
+	priceMax, err := money.Parse(ctx.Query("price_max"))
+	if err != nil {
+		return model.NewApiError(fiber.StatusBadRequest, "Invalid price_max", nil)
+	}
 	request := &model.GetProductsRequest{
 		Role: authID.Role,
 		UserID: authID.ID,
 		Search: ctx.Query("search", ""),
 		Category: ctx.Query("category", ""),
-		PriceMin: ctx.QueryFloat("price_min"),
-		PriceMax: ctx.QueryFloat("price_max"),
+		PriceMin: priceMin,
+		PriceMax: priceMax,
+		PriceCurrency: ctx.Query("price_currency", ""),
+		Currency: ctx.Query("currency", ""),
 		Page:   ctx.QueryInt("page", 1),
 		Limit:  ctx.QueryInt("limit", 10),
 	}
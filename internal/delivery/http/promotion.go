@@ -0,0 +1,162 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PromotionController struct {
+	uc interfaces.PromotionUseCase
+}
+
+func NewPromotionController(usecase interfaces.PromotionUseCase) *PromotionController {
+	return &PromotionController{
+		uc: usecase,
+	}
+}
+
+// CreatePromotion handles POST /seller/promotions endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.CreatePromotionRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.PromotionResponse if the promotion is created successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if creation fails.
+func (c *PromotionController) CreatePromotion(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.CreatePromotionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	response, err := c.uc.CreatePromotion(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created promotion", fiber.StatusCreated, nil, nil))
+}
+
+// GetPromotions handles GET /seller/promotions endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the paging query parameters.
+//
+// Returns:
+//
+//   - 200 OK: list of model.PromotionResponse with paging metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *PromotionController) GetPromotions(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchPromotionRequest{
+		UserID: auth.ID,
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetPromotions(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get promotions", fiber.StatusOK, paging, nil))
+}
+
+// GetPromotion handles GET /seller/promotions/{promotion_uuid} endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the promotion UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.PromotionResponse.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *PromotionController) GetPromotion(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetPromotionRequest{
+		UserID:        auth.ID,
+		PromotionUUID: ctx.Params("promotion_uuid"),
+	}
+	response, err := c.uc.GetPromotion(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get promotion", fiber.StatusOK, nil, nil))
+}
+
+// UpdatePromotion handles PUT /seller/promotions/{promotion_uuid} endpoint.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the promotion UUID path parameter and request body model.UpdatePromotionRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.PromotionResponse if the promotion is updated successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *PromotionController) UpdatePromotion(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.UpdatePromotionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	request.PromotionUUID = ctx.Params("promotion_uuid")
+	response, err := c.uc.UpdatePromotion(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated promotion", fiber.StatusOK, nil, nil))
+}
+
+// PreviewOrder handles POST /buyer/orders/preview endpoint. It prices the
+// items per store after promotions without placing an order.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.PreviewOrderRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.PreviewOrderResponse with the prices after promotions.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if the preview fails.
+func (c *PromotionController) PreviewOrder(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.PreviewOrderRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	response, err := c.uc.PreviewOrder(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully previewed order", fiber.StatusOK, nil, nil))
+}
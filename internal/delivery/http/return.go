@@ -0,0 +1,257 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReturnController struct {
+	uc interfaces.ReturnUseCase
+}
+
+func NewReturnController(usecase interfaces.ReturnUseCase) *ReturnController {
+	return &ReturnController{
+		uc: usecase,
+	}
+}
+
+// RequestReturn handles POST /orders/{order_uuid}/returns endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the order UUID path parameter and request body model.CreateReturnRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.ReturnResponse if the return is requested successfully.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request body cannot be parsed.
+//   - Propagates error from use case layer if the return cannot be requested.
+func (c *ReturnController) RequestReturn(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.CreateReturnRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return model.ErrBadRequest
+	}
+	request.UserID = auth.ID
+	request.OrderUUID = ctx.Params("order_uuid")
+	response, err := c.uc.RequestReturn(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully requested return", fiber.StatusCreated, nil, nil))
+}
+
+// GetReturnsByBuyer handles GET /returns endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including query parameters status, page, and limit.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ReturnResponse with pagination metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ReturnController) GetReturnsByBuyer(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchReturnRequest{
+		UserID: auth.ID,
+		Status: ctx.Query("status", ""),
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetReturnsByBuyer(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get returns", fiber.StatusOK, returnPaging(request, total), nil))
+}
+
+// GetReturnByBuyer handles GET /returns/{return_uuid} endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the return UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.ReturnResponse if the return is retrieved successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ReturnController) GetReturnByBuyer(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetReturnRequest{
+		UserID:     auth.ID,
+		ReturnUUID: ctx.Params("return_uuid"),
+	}
+	response, err := c.uc.GetReturnByBuyer(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get return", fiber.StatusOK, nil, nil))
+}
+
+// GetReturnsBySeller handles GET /returns endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including query parameters status, page, and limit.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ReturnResponse with pagination metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ReturnController) GetReturnsBySeller(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchReturnRequest{
+		UserID: auth.ID,
+		Status: ctx.Query("status", ""),
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetReturnsBySeller(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get returns", fiber.StatusOK, returnPaging(request, total), nil))
+}
+
+// GetReturnBySeller handles GET /returns/{return_uuid} endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the return UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.ReturnResponse if the return is retrieved successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ReturnController) GetReturnBySeller(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetReturnRequest{
+		UserID:     auth.ID,
+		ReturnUUID: ctx.Params("return_uuid"),
+	}
+	response, err := c.uc.GetReturnBySeller(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get return", fiber.StatusOK, nil, nil))
+}
+
+// ApproveReturn handles PATCH /returns/{return_uuid}/approve endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the return UUID path parameter and request body model.ApproveReturnRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.ReturnResponse if the return is approved successfully.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request body cannot be parsed.
+//   - Propagates error from use case layer if the return cannot be approved.
+func (c *ReturnController) ApproveReturn(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.ApproveReturnRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return model.ErrBadRequest
+		}
+	}
+	request.UserID = auth.ID
+	request.ReturnUUID = ctx.Params("return_uuid")
+	response, err := c.uc.ApproveReturn(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully approved return", fiber.StatusOK, nil, nil))
+}
+
+// RejectReturn handles PATCH /returns/{return_uuid}/reject endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the return UUID path parameter and request body model.RejectReturnRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.ReturnResponse if the return is rejected successfully.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request body cannot be parsed.
+//   - Propagates error from use case layer if the return cannot be rejected.
+func (c *ReturnController) RejectReturn(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.RejectReturnRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return model.ErrBadRequest
+	}
+	request.UserID = auth.ID
+	request.ReturnUUID = ctx.Params("return_uuid")
+	response, err := c.uc.RejectReturn(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully rejected return", fiber.StatusOK, nil, nil))
+}
+
+// InspectReturn handles PATCH /returns/{return_uuid}/inspect endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the return UUID path parameter and request body model.InspectReturnRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.ReturnResponse if the return is inspected and the refund issued successfully.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request body cannot be parsed.
+//   - Propagates error from use case layer if the return cannot be inspected.
+func (c *ReturnController) InspectReturn(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.InspectReturnRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return model.ErrBadRequest
+		}
+	}
+	request.UserID = auth.ID
+	request.ReturnUUID = ctx.Params("return_uuid")
+	response, err := c.uc.InspectReturn(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully inspected return", fiber.StatusOK, nil, nil))
+}
+
+func returnPaging(request *model.SearchReturnRequest, total int64) *model.PageMetadata {
+	return &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ShippingRateController struct {
+	uc interfaces.ShippingRateUseCase
+}
+
+func NewShippingRateController(usecase interfaces.ShippingRateUseCase) *ShippingRateController {
+	return &ShippingRateController{
+		uc: usecase,
+	}
+}
+
+// CreateRates handles POST /shipping-rates endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.CreateShippingRatesRequest.
+//
+// Returns:
+//
+//   - 201 Created: list of model.ShippingRateResponse if the rates are stored successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if creation fails.
+func (c *ShippingRateController) CreateRates(ctx *fiber.Ctx) error {
+	request := new(model.CreateShippingRatesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	response, err := c.uc.CreateRates(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created shipping rates", fiber.StatusCreated, nil, nil))
+}
+
+// GetRates handles GET /shipping-rates endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the service, origin, destination and paging query parameters.
+//
+// Returns:
+//
+//   - 200 OK: list of model.ShippingRateResponse with paging metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *ShippingRateController) GetRates(ctx *fiber.Ctx) error {
+	request := &model.SearchShippingRateRequest{
+		Service:             ctx.Query("service", ""),
+		OriginProvince:      ctx.Query("origin", ""),
+		DestinationProvince: ctx.Query("destination", ""),
+		Page:                ctx.QueryInt("page", 1),
+		Limit:               ctx.QueryInt("limit", 50),
+	}
+	response, total, err := c.uc.GetRates(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get shipping rates", fiber.StatusOK, paging, nil))
+}
+
+// UpdateRate handles PUT /shipping-rates/{rate_uuid} endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the rate UUID path parameter and request body model.ShippingRateRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.ShippingRateResponse if the rate is updated successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *ShippingRateController) UpdateRate(ctx *fiber.Ctx) error {
+	request := new(model.UpdateShippingRateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.RateUUID = ctx.Params("rate_uuid")
+	response, err := c.uc.UpdateRate(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated shipping rate", fiber.StatusOK, nil, nil))
+}
+
+// DeleteRate handles DELETE /shipping-rates/{rate_uuid} endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the rate UUID path parameter.
+//
+// Returns:
+//
+//   - 204 No Content: if the rate is deleted successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if deletion fails.
+func (c *ShippingRateController) DeleteRate(ctx *fiber.Ctx) error {
+	request := &model.DeleteShippingRateRequest{
+		RateUUID: ctx.Params("rate_uuid"),
+	}
+	if err := c.uc.DeleteRate(ctx.UserContext(), request); err != nil {
+		return err
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockSubscriptionController struct {
+	uc interfaces.StockSubscriptionUseCase
+}
+
+func NewStockSubscriptionController(usecase interfaces.StockSubscriptionUseCase) *StockSubscriptionController {
+	return &StockSubscriptionController{
+		uc: usecase,
+	}
+}
+
+// Subscribe handles POST /products/{product_uuid}/subscription endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the product UUID path parameter.
+//
+// Returns:
+//
+//   - 201 Created: if the buyer is subscribed to the back-in-stock notification.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if subscription fails.
+func (c *StockSubscriptionController) Subscribe(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.StockSubscriptionRequest{
+		UserID:      auth.ID,
+		ProductUUID: ctx.Params("product_uuid"),
+	}
+	if err := c.uc.Subscribe(ctx.UserContext(), request); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(true, "Successfully subscribed to product", fiber.StatusCreated, nil, nil))
+}
+
+// Unsubscribe handles DELETE /products/{product_uuid}/subscription endpoint for buyer.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the product UUID path parameter.
+//
+// Returns:
+//
+//   - 204 No Content: if the subscription is removed.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if removal fails.
+func (c *StockSubscriptionController) Unsubscribe(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.StockSubscriptionRequest{
+		UserID:      auth.ID,
+		ProductUUID: ctx.Params("product_uuid"),
+	}
+	if err := c.uc.Unsubscribe(ctx.UserContext(), request); err != nil {
+		return err
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
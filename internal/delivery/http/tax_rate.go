@@ -0,0 +1,102 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaxRateController struct {
+	uc interfaces.TaxRateUseCase
+}
+
+func NewTaxRateController(usecase interfaces.TaxRateUseCase) *TaxRateController {
+	return &TaxRateController{
+		uc: usecase,
+	}
+}
+
+// CreateRate handles POST /tax-rates endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.CreateTaxRateRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.TaxRateResponse if the rate is stored successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if creation fails.
+func (c *TaxRateController) CreateRate(ctx *fiber.Ctx) error {
+	request := new(model.CreateTaxRateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	response, err := c.uc.CreateRate(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created tax rate", fiber.StatusCreated, nil, nil))
+}
+
+// GetRates handles GET /tax-rates endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the category and paging query parameters.
+//
+// Returns:
+//
+//   - 200 OK: list of model.TaxRateResponse with paging metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *TaxRateController) GetRates(ctx *fiber.Ctx) error {
+	request := &model.SearchTaxRateRequest{
+		Category: ctx.Query("category", ""),
+		Page:     ctx.QueryInt("page", 1),
+		Limit:    ctx.QueryInt("limit", 50),
+	}
+	response, total, err := c.uc.GetRates(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get tax rates", fiber.StatusOK, paging, nil))
+}
+
+// UpdateRate handles PUT /tax-rates/{tax_rate_uuid} endpoint for admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the tax rate UUID path parameter and request body model.UpdateTaxRateRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.TaxRateResponse if the rate is updated successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *TaxRateController) UpdateRate(ctx *fiber.Ctx) error {
+	request := new(model.UpdateTaxRateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.TaxRateUUID = ctx.Params("tax_rate_uuid")
+	response, err := c.uc.UpdateRate(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated tax rate", fiber.StatusOK, nil, nil))
+}
@@ -59,6 +59,7 @@ func (c *UserController) Register(ctx *fiber.Ctx) error {
 }
 
 // Login handles POST /users/login endpoint.
+// A buyer's guest cart (cart_token cookie) is merged into their cart and the cookie is cleared.
 //
 // Parameters:
 //
@@ -76,10 +77,14 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(request); err != nil {
 		return err
 	}
+	request.CartToken = cartToken(ctx)
 	authRes, err := c.uc.Login(ctx.UserContext(), request)
 	if err != nil {
 		return err
 	}
+	if request.CartToken != "" && authRes.Role == "buyer" {
+		ctx.ClearCookie(CartTokenCookie)
+	}
 	ctx.Cookie(&fiber.Cookie{
         Name:     "jwt",
         Value:    authRes.AccessToken,
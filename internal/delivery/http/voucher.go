@@ -0,0 +1,139 @@
+package http
+
+import (
+	"math"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type VoucherController struct {
+	uc interfaces.VoucherUseCase
+}
+
+func NewVoucherController(usecase interfaces.VoucherUseCase) *VoucherController {
+	return &VoucherController{
+		uc: usecase,
+	}
+}
+
+// CreateVoucher handles POST /vouchers endpoint for seller and admin. Sellers
+// create vouchers of their store, admins platform vouchers.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.CreateVoucherRequest.
+//
+// Returns:
+//
+//   - 201 Created: model.VoucherResponse if the voucher is created successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if creation fails.
+func (c *VoucherController) CreateVoucher(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.CreateVoucherRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	request.Role = auth.Role
+	response, err := c.uc.CreateVoucher(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created voucher", fiber.StatusCreated, nil, nil))
+}
+
+// GetVouchers handles GET /vouchers endpoint for seller and admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the paging query parameters.
+//
+// Returns:
+//
+//   - 200 OK: list of model.VoucherResponse with paging metadata.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *VoucherController) GetVouchers(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.SearchVoucherRequest{
+		UserID: auth.ID,
+		Role:   auth.Role,
+		Page:   ctx.QueryInt("page", 1),
+		Limit:  ctx.QueryInt("limit", 10),
+	}
+	response, total, err := c.uc.GetVouchers(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Limit,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Limit))),
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get vouchers", fiber.StatusOK, paging, nil))
+}
+
+// GetVoucher handles GET /vouchers/{voucher_uuid} endpoint for seller and admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the voucher UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.VoucherResponse with the usage of the voucher.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *VoucherController) GetVoucher(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetVoucherRequest{
+		UserID:      auth.ID,
+		Role:        auth.Role,
+		VoucherUUID: ctx.Params("voucher_uuid"),
+	}
+	response, err := c.uc.GetVoucher(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get voucher", fiber.StatusOK, nil, nil))
+}
+
+// UpdateVoucher handles PUT /vouchers/{voucher_uuid} endpoint for seller and admin.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the voucher UUID path parameter and request body model.UpdateVoucherRequest.
+//
+// Returns:
+//
+//   - 200 OK: model.VoucherResponse if the voucher is updated successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *VoucherController) UpdateVoucher(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.UpdateVoucherRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	request.Role = auth.Role
+	request.VoucherUUID = ctx.Params("voucher_uuid")
+	response, err := c.uc.UpdateVoucher(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated voucher", fiber.StatusOK, nil, nil))
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/delivery/http/middleware"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WarehouseController struct {
+	uc interfaces.WarehouseUseCase
+}
+
+func NewWarehouseController(usecase interfaces.WarehouseUseCase) *WarehouseController {
+	return &WarehouseController{
+		uc: usecase,
+	}
+}
+
+// CreateWarehouse handles POST /warehouses endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including request body model.RegisterWarehouse.
+//
+// Returns:
+//
+//   - 201 Created: model.WarehouseResponse if the warehouse is created successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if creation fails.
+func (c *WarehouseController) CreateWarehouse(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.RegisterWarehouse)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	response, err := c.uc.CreateWarehouse(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewWebResponse(response, "Successfully created warehouse", fiber.StatusCreated, nil, nil))
+}
+
+// GetWarehouses handles GET /warehouses endpoint for seller.
+//
+// Returns:
+//
+//   - 200 OK: list of model.WarehouseResponse of the seller's store.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *WarehouseController) GetWarehouses(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	response, err := c.uc.GetWarehouses(ctx.UserContext(), auth.ID)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get warehouses", fiber.StatusOK, nil, nil))
+}
+
+// UpdateWarehouse handles PUT /warehouses/{warehouse_uuid} endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the warehouse UUID path parameter and request body model.UpdateWarehouse.
+//
+// Returns:
+//
+//   - 200 OK: model.WarehouseResponse if the warehouse is updated successfully.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if update fails.
+func (c *WarehouseController) UpdateWarehouse(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := new(model.UpdateWarehouse)
+	if err := ctx.BodyParser(request); err != nil {
+		return err
+	}
+	request.UserID = auth.ID
+	request.WarehouseUUID = ctx.Params("warehouse_uuid")
+	response, err := c.uc.UpdateWarehouse(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully updated warehouse", fiber.StatusOK, nil, nil))
+}
+
+// GetProductStock handles GET /products/{product_uuid}/stock endpoint for seller.
+//
+// Parameters:
+//
+//   - ctx: fiber.Ctx - Context for the request, including the product UUID path parameter.
+//
+// Returns:
+//
+//   - 200 OK: model.ProductStockResponse with the stock per warehouse.
+//
+// Errors:
+//
+//   - Propagates error from use case layer if retrieval fails.
+func (c *WarehouseController) GetProductStock(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	request := &model.GetProductRequest{
+		UserID:      auth.ID,
+		ProductUUID: ctx.Params("product_uuid"),
+	}
+	response, err := c.uc.GetProductStock(ctx.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(model.NewWebResponse(response, "Successfully get product stock", fiber.StatusOK, nil, nil))
+}
@@ -16,14 +16,33 @@ type RouteConfig struct {
 	StoreController   *http.StoreController
 	ProductController *http.ProductController
 	ShippingController *http.ShippingController
+	InventoryController *http.InventoryController
+	NotificationController *http.NotificationController
+	StockSubscriptionController *http.StockSubscriptionController
+	WarehouseController *http.WarehouseController
+	ExchangeRateController *http.ExchangeRateController
+	CartController *http.CartController
+	CheckoutController *http.CheckoutController
+	ReturnController *http.ReturnController
+	MessageController *http.MessageController
+	OrderDocumentController *http.OrderDocumentController
+	ExportController *http.ExportController
+	VoucherController *http.VoucherController
+	PromotionController *http.PromotionController
+	ShippingRateController *http.ShippingRateController
+	TaxRateController *http.TaxRateController
+	AddressController *http.AddressController
 	AuthMiddleware    fiber.Handler
+	IdempotencyMiddleware fiber.Handler
 }
 
 func (rc *RouteConfig) Setup() {
 	rc.setupAuthRoutes()
 	rc.setupUserRoutes()
+	rc.setupGuestRoutes()
 	rc.setupBuyerRoutes()
 	rc.setupSellerRoutes()
+	rc.setupAdminRoutes()
 }
 
 func (rc *RouteConfig) setupAuthRoutes() {
@@ -44,6 +63,14 @@ func (rc *RouteConfig) setupUserRoutes() {
 		userGroup.Get("/profile", rc.ProfileController.GetProfile)
 		userGroup.Put("/profile", rc.ProfileController.UpdateProfile)
 		userGroup.Patch("/password", rc.UserController.ChangePassword)
+		userGroup.Get("/notifications", rc.NotificationController.GetNotifications)
+		userGroup.Patch("/notifications/:notification_uuid/read", rc.NotificationController.MarkAsRead)
+		userGroup.Post("/threads", rc.MessageController.StartThread)
+		userGroup.Get("/threads", rc.MessageController.GetThreads)
+		userGroup.Get("/threads/unread-count", rc.MessageController.GetUnreadCount)
+		userGroup.Get("/threads/:thread_uuid", rc.MessageController.GetThread)
+		userGroup.Post("/threads/:thread_uuid/messages", rc.MessageController.SendMessage)
+		userGroup.Patch("/threads/:thread_uuid/read", rc.MessageController.MarkThreadRead)
 	}
 	productGroup := rc.App.Group("/api/product", rc.AuthMiddleware, userRateLimiter)
 	{
@@ -51,25 +78,87 @@ func (rc *RouteConfig) setupUserRoutes() {
 	}
 }
 
+func (rc *RouteConfig) setupGuestRoutes() {
+	guestRateLimiter := middleware.NewDynamicRateLimiter(30, time.Minute)
+	cartGroup := rc.App.Group("/api/guest/cart", guestRateLimiter)
+	{
+		cartGroup.Get("", rc.CartController.GetCart)
+		cartGroup.Post("/items", rc.CartController.AddItem)
+		cartGroup.Patch("/items/:product_uuid", rc.CartController.UpdateItem)
+		cartGroup.Delete("/items/:product_uuid", rc.CartController.RemoveItem)
+	}
+}
+
 func (rc *RouteConfig) setupBuyerRoutes() {
 	buyerRateLimiter := middleware.NewBuyerRateLimiter(30, time.Minute)
-	buyerGroup := rc.App.Group("/api/buyer", rc.AuthMiddleware, middleware.BuyerOnly(), buyerRateLimiter)
+	buyerGroup := rc.App.Group("/api/buyer", rc.AuthMiddleware, middleware.BuyerOnly(), buyerRateLimiter, rc.IdempotencyMiddleware)
 	{
 		// Order Routes
 		orderGroup := buyerGroup.Group("/orders")
 		{
 			orderGroup.Get("", rc.OrderController.GetOrdersByBuyer)
+			orderGroup.Post("/preview", rc.PromotionController.PreviewOrder)
+			orderGroup.Post("/shipping-options", rc.OrderController.GetShippingOptions)
 			orderGroup.Get("/:order_uuid", rc.OrderController.GetOrderByIdByBuyer)
+			orderGroup.Get("/:order_uuid/timeline", rc.OrderController.GetOrderTimelineByBuyer)
+			orderGroup.Get("/:order_uuid/invoice", rc.OrderDocumentController.GetInvoiceByBuyer)
+			orderGroup.Get("/:order_uuid/receipt", rc.OrderDocumentController.GetReceiptByBuyer)
 			orderGroup.Post("", rc.OrderController.CreateOrder)
 			orderGroup.Patch("/:order_uuid/cancel", rc.OrderController.CancelOrder)
+			orderGroup.Patch("/:order_uuid/items/:order_item_uuid/cancel", rc.OrderController.CancelOrderItemByBuyer)
 			orderGroup.Patch("/:order_uuid/checkout", rc.OrderController.CheckoutOrder)
+			orderGroup.Patch("/:order_uuid/confirm", rc.OrderController.ConfirmOrderReceipt)
+			orderGroup.Patch("/:order_uuid/problem", rc.OrderController.ReportOrderProblem)
+			orderGroup.Post("/:order_uuid/returns", rc.ReturnController.RequestReturn)
+		}
+
+		// Product Routes
+		productGroup := buyerGroup.Group("/products")
+		{
+			productGroup.Post("/:product_uuid/subscription", rc.StockSubscriptionController.Subscribe)
+			productGroup.Delete("/:product_uuid/subscription", rc.StockSubscriptionController.Unsubscribe)
+		}
+
+		// Cart Routes
+		cartGroup := buyerGroup.Group("/cart")
+		{
+			cartGroup.Get("", rc.CartController.GetCart)
+			cartGroup.Post("/items", rc.CartController.AddItem)
+			cartGroup.Patch("/items/:product_uuid", rc.CartController.UpdateItem)
+			cartGroup.Delete("/items/:product_uuid", rc.CartController.RemoveItem)
+			cartGroup.Post("/checkout", rc.CartController.Checkout)
+		}
+
+		// Checkout Routes
+		checkoutGroup := buyerGroup.Group("/checkouts")
+		{
+			checkoutGroup.Post("", rc.CheckoutController.CreateCheckout)
+			checkoutGroup.Get("/:checkout_uuid", rc.CheckoutController.GetCheckout)
+			checkoutGroup.Patch("/:checkout_uuid/pay", rc.CheckoutController.PayCheckout)
+		}
+
+		// Return Routes
+		returnGroup := buyerGroup.Group("/returns")
+		{
+			returnGroup.Get("", rc.ReturnController.GetReturnsByBuyer)
+			returnGroup.Get("/:return_uuid", rc.ReturnController.GetReturnByBuyer)
+		}
+
+		// Address Book Routes
+		addressGroup := buyerGroup.Group("/addresses")
+		{
+			addressGroup.Get("", rc.AddressController.GetAddresses)
+			addressGroup.Post("", rc.AddressController.CreateAddress)
+			addressGroup.Get("/:address_uuid", rc.AddressController.GetAddress)
+			addressGroup.Put("/:address_uuid", rc.AddressController.UpdateAddress)
+			addressGroup.Delete("/:address_uuid", rc.AddressController.DeleteAddress)
 		}
 	}
 }
 
 func (rc *RouteConfig) setupSellerRoutes() {
 	sellerRateLimiter := middleware.NewSellerRateLimiter(50, time.Minute)
-	sellerGroup := rc.App.Group("/api/seller", rc.AuthMiddleware, middleware.SellerOnly(), sellerRateLimiter)
+	sellerGroup := rc.App.Group("/api/seller", rc.AuthMiddleware, middleware.SellerOnly(), sellerRateLimiter, rc.IdempotencyMiddleware)
 	{
 		// Store Routes
 		storeGroup := sellerGroup.Group("/store")
@@ -79,6 +168,14 @@ func (rc *RouteConfig) setupSellerRoutes() {
 			storeGroup.Put("", rc.StoreController.UpdateStore)
 		}
 
+		// Warehouse Routes
+		warehouseGroup := sellerGroup.Group("/warehouses")
+		{
+			warehouseGroup.Post("", rc.WarehouseController.CreateWarehouse)
+			warehouseGroup.Get("", rc.WarehouseController.GetWarehouses)
+			warehouseGroup.Put("/:warehouse_uuid", rc.WarehouseController.UpdateWarehouse)
+		}
+
 		// Product Routes
 		productGroup := sellerGroup.Group("/products")
 		{
@@ -86,14 +183,97 @@ func (rc *RouteConfig) setupSellerRoutes() {
 			productGroup.Get("/:product_uuid", rc.ProductController.GetProductById)
 			productGroup.Put("/:product_uuid", rc.ProductController.UpdateProduct)
 			productGroup.Delete("/:product_uuid", rc.ProductController.DeleteProduct)
+			productGroup.Get("/:product_uuid/stock", rc.WarehouseController.GetProductStock)
+			productGroup.Post("/:product_uuid/stock", rc.InventoryController.AdjustStock)
+			productGroup.Get("/:product_uuid/stock/history", rc.InventoryController.GetStockHistory)
 		}
 
 		// Order Routes
 		orderGroup := sellerGroup.Group("/orders")
 		{
 			orderGroup.Get("", rc.OrderController.GetOrdersBySeller)
+			orderGroup.Get("/export", rc.ExportController.ExportOrders)
 			orderGroup.Get("/:order_uuid", rc.OrderController.GetOrderByIdSeller)
+			orderGroup.Get("/:order_uuid/timeline", rc.OrderController.GetOrderTimelineBySeller)
+			orderGroup.Get("/:order_uuid/invoice", rc.OrderDocumentController.GetInvoiceBySeller)
+			orderGroup.Get("/:order_uuid/receipt", rc.OrderDocumentController.GetReceiptBySeller)
 			orderGroup.Patch("/:order_uuid/shipping", rc.ShippingController.UpdateShippingStatus)
+			orderGroup.Patch("/:order_uuid/items/:order_item_uuid/cancel", rc.OrderController.CancelOrderItemBySeller)
+		}
+
+		// Return Routes
+		returnGroup := sellerGroup.Group("/returns")
+		{
+			returnGroup.Get("", rc.ReturnController.GetReturnsBySeller)
+			returnGroup.Get("/:return_uuid", rc.ReturnController.GetReturnBySeller)
+			returnGroup.Patch("/:return_uuid/approve", rc.ReturnController.ApproveReturn)
+			returnGroup.Patch("/:return_uuid/reject", rc.ReturnController.RejectReturn)
+			returnGroup.Patch("/:return_uuid/inspect", rc.ReturnController.InspectReturn)
+		}
+
+		// Export Routes
+		exportGroup := sellerGroup.Group("/exports")
+		{
+			exportGroup.Get("", rc.ExportController.GetExports)
+			exportGroup.Get("/:export_uuid", rc.ExportController.GetExport)
+			exportGroup.Get("/:export_uuid/download", rc.ExportController.DownloadExport)
+		}
+
+		// Voucher Routes
+		voucherGroup := sellerGroup.Group("/vouchers")
+		{
+			voucherGroup.Get("", rc.VoucherController.GetVouchers)
+			voucherGroup.Post("", rc.VoucherController.CreateVoucher)
+			voucherGroup.Get("/:voucher_uuid", rc.VoucherController.GetVoucher)
+			voucherGroup.Put("/:voucher_uuid", rc.VoucherController.UpdateVoucher)
+		}
+
+		// Promotion Routes
+		promotionGroup := sellerGroup.Group("/promotions")
+		{
+			promotionGroup.Get("", rc.PromotionController.GetPromotions)
+			promotionGroup.Post("", rc.PromotionController.CreatePromotion)
+			promotionGroup.Get("/:promotion_uuid", rc.PromotionController.GetPromotion)
+			promotionGroup.Put("/:promotion_uuid", rc.PromotionController.UpdatePromotion)
 		}
 	}
 }
+
+func (rc *RouteConfig) setupAdminRoutes() {
+	adminRateLimiter := middleware.NewAdminRateLimiter(30, time.Minute)
+	adminGroup := rc.App.Group("/api/admin", rc.AuthMiddleware, middleware.AdminOnly(), adminRateLimiter)
+	{
+		// Exchange Rate Routes
+		exchangeRateGroup := adminGroup.Group("/exchange-rates")
+		{
+			exchangeRateGroup.Get("", rc.ExchangeRateController.GetRates)
+			exchangeRateGroup.Post("", rc.ExchangeRateController.ImportRates)
+		}
+
+		// Shipping Rate Routes
+		shippingRateGroup := adminGroup.Group("/shipping-rates")
+		{
+			shippingRateGroup.Get("", rc.ShippingRateController.GetRates)
+			shippingRateGroup.Post("", rc.ShippingRateController.CreateRates)
+			shippingRateGroup.Put("/:rate_uuid", rc.ShippingRateController.UpdateRate)
+			shippingRateGroup.Delete("/:rate_uuid", rc.ShippingRateController.DeleteRate)
+		}
+
+		// Tax Rate Routes
+		taxRateGroup := adminGroup.Group("/tax-rates")
+		{
+			taxRateGroup.Get("", rc.TaxRateController.GetRates)
+			taxRateGroup.Post("", rc.TaxRateController.CreateRate)
+			taxRateGroup.Put("/:tax_rate_uuid", rc.TaxRateController.UpdateRate)
+		}
+
+		// Voucher Routes
+		voucherGroup := adminGroup.Group("/vouchers")
+		{
+			voucherGroup.Get("", rc.VoucherController.GetVouchers)
+			voucherGroup.Post("", rc.VoucherController.CreateVoucher)
+			voucherGroup.Get("/:voucher_uuid", rc.VoucherController.GetVoucher)
+			voucherGroup.Put("/:voucher_uuid", rc.VoucherController.UpdateVoucher)
+		}
+	}
+}
\ No newline at end of file
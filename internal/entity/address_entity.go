@@ -0,0 +1,23 @@
+package entity
+
+import "gorm.io/gorm"
+
+// Address is an entry of a buyer's address book. Orders copy the address onto
+// their shipping, so editing or deleting it does not change placed orders.
+type Address struct {
+	gorm.Model
+	AddressUUID   string `gorm:"type:char(36);uniqueIndex;not null"`
+	UserID        uint   `gorm:"not null;index"`
+	User          User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Label         string `gorm:"size:50;not null"`
+	RecipientName string `gorm:"size:255;not null"`
+	Phone         string `gorm:"size:20;not null"`
+	Address       string `gorm:"size:255;not null"`
+	Province      string `gorm:"size:255;not null"`
+	City          string `gorm:"size:255;not null"`
+	District      string `gorm:"size:255"`
+	PostalCode    string `gorm:"size:10;not null"`
+	// IsDefault marks the address orders ship to when they name none; a
+	// buyer has at most one default address.
+	IsDefault bool `gorm:"not null;default:false"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// Cart belongs either to a buyer (UserID) or to an anonymous visitor
+// identified by the cart_token cookie (GuestToken).
+type Cart struct {
+	gorm.Model
+	CartUUID   string  `gorm:"type:char(36);uniqueIndex;not null"`
+	UserID     *uint   `gorm:"uniqueIndex"`
+	User       *User   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	GuestToken *string `gorm:"type:char(36);uniqueIndex"`
+
+	Items []CartItem `gorm:"foreignKey:CartID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// CartItem remembers the price the product had when it was put in the cart,
+// so the cart can warn the buyer when it changes.
+type CartItem struct {
+	gorm.Model
+	CartID     uint         `gorm:"not null;uniqueIndex:idx_cart_item_product"`
+	Cart       Cart         `gorm:"foreignKey:CartID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ProductID  uint         `gorm:"not null;uniqueIndex:idx_cart_item_product"`
+	Product    Product      `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Quantity   int          `gorm:"not null"`
+	PriceAtAdd money.Amount `gorm:"type:decimal(20,2);not null"`
+	Currency   string       `gorm:"type:char(3);not null;default:'IDR'"`
+}
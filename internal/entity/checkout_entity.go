@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// Checkout groups the per-store orders created from one purchase so they can
+// be paid in a single payment. Shipping and cancellation stay per order.
+type Checkout struct {
+	gorm.Model
+	CheckoutUUID  string       `gorm:"type:char(36);uniqueIndex;not null"`
+	UserID        uint         `gorm:"not null;index"`
+	User          User         `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Status        string       `gorm:"type:enum('pending', 'paid');default:'pending';not null"`
+	PaymentMethod string       `gorm:"type:enum('cash', 'transfer');not null"`
+	Currency      string       `gorm:"type:char(3);not null"`
+	PaidAmount    money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	PaidAt        *time.Time
+
+	Orders []Order `gorm:"foreignKey:CheckoutID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
@@ -12,7 +12,7 @@ type OrderEvent struct {
 	EventUUID string `gorm:"type:char(36);uniqueIndex;not null"`
 	OrderID   uint   `gorm:"not null"`
 	Order	entity.Order `gorm:"foreignKey:OrderID"`
-	EventType string `gorm:"type:enum('order_created', 'payment_processed', 'shipping_processed', 'order_processed', 'order_cancelled', 'order_delivered');not null"`
+	EventType string `gorm:"type:enum('order_created', 'payment_processed', 'shipping_processed', 'order_processed', 'order_cancelled', 'order_delivered', 'refund_issued', 'order_updated');not null"`
 	Status       string         `gorm:"type:enum('pending','completed','failed');not null"`
 	PaymentData json.RawMessage `gorm:"type:json"`
 	ShippingData json.RawMessage `gorm:"type:json"`
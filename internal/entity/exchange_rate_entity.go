@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// ExchangeRate is the latest known rate for a currency pair: one unit of
+// BaseCurrency buys Rate units of QuoteCurrency.
+type ExchangeRate struct {
+	gorm.Model
+	BaseCurrency  string     `gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rate_pair"`
+	QuoteCurrency string     `gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rate_pair"`
+	Rate          money.Rate `gorm:"type:decimal(20,8);not null"`
+}
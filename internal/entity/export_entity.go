@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// OrderExport is a background export of a store's orders. The filters of the
+// seller order list are kept on the job. The finished file is written to the
+// export directory at FilePath and kept until ExpiresAt.
+type OrderExport struct {
+	gorm.Model
+	ExportUUID    string        `gorm:"type:char(36);uniqueIndex;not null"`
+	StoreID       uint          `gorm:"not null;index"`
+	Store         Store         `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID        uint          `gorm:"not null"`
+	Format        string        `gorm:"type:enum('csv', 'xlsx');not null"`
+	Status        string        `gorm:"type:enum('pending', 'running', 'completed', 'failed', 'expired');default:'pending';not null;index"`
+	OrderStatus   string        `gorm:"size:20"`
+	SortDate      string        `gorm:"size:4"`
+	StartDate     string        `gorm:"size:10"`
+	EndDate       string        `gorm:"size:10"`
+	Search        string        `gorm:"size:100"`
+	MinTotal      *money.Amount `gorm:"type:decimal(20,2)"`
+	MaxTotal      *money.Amount `gorm:"type:decimal(20,2)"`
+	Currency      string        `gorm:"type:char(3)"`
+	PaymentMethod string        `gorm:"size:20"`
+	RowCount      int           `gorm:"not null;default:0"`
+	FileName      string        `gorm:"size:255"`
+	FilePath      string        `gorm:"size:1024"`
+	Error         string        `gorm:"size:255"`
+	StartedAt     *time.Time
+	CompletedAt   *time.Time
+	ExpiresAt     *time.Time `gorm:"index"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// IdempotencyKey stores the outcome of a mutating request sent with an
+// Idempotency-Key header, so a retry with the same key replays the stored
+// response instead of running the request again. Keys are scoped to the user
+// and are hard deleted once they expire.
+type IdempotencyKey struct {
+	ID          uint   `gorm:"primarykey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_idempotency_user_key"`
+	User        User   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Key         string `gorm:"column:idempotency_key;type:varchar(255);not null;uniqueIndex:idx_idempotency_user_key"`
+	RequestHash string `gorm:"type:char(64);not null"`
+	Status      string `gorm:"type:enum('processing', 'completed');default:'processing';not null"`
+	// LockedAt is when the request now processing under the key claimed
+	// it. A claim older than the processing lease is taken to belong to a
+	// request that died, and a retry may take the key over.
+	LockedAt *time.Time
+	// ClaimToken identifies the claim of the request now processing under
+	// the key. Storing or releasing the outcome requires it, so a request
+	// whose key was taken over cannot overwrite the new holder's claim.
+	ClaimToken     string    `gorm:"type:char(36);not null;default:''"`
+	ResponseStatus int       `gorm:"not null;default:0"`
+	ContentType    string    `gorm:"type:varchar(255)"`
+	ResponseBody   []byte    `gorm:"type:mediumblob"`
+	ExpiresAt      time.Time `gorm:"not null;index"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "gorm.io/gorm"
+
+// InventoryMovement is an append-only ledger row. Quantity is the signed change
+// applied to Product.Stock and StockAfter is the stock level right after it.
+type InventoryMovement struct {
+	gorm.Model
+	MovementUUID  string  `gorm:"type:char(36);uniqueIndex;not null"`
+	ProductID     uint    `gorm:"not null;index"`
+	Product       Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	WarehouseID   *uint
+	Warehouse     *Warehouse `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Quantity      int        `gorm:"not null"`
+	StockAfter    int        `gorm:"not null"`
+	Reason        string     `gorm:"type:enum('sale', 'cancel', 'adjust', 'return', 'import');not null"`
+	ReferenceType string     `gorm:"size:50"`
+	ReferenceID   string     `gorm:"size:64"`
+	UserID        *uint
+	Note          string `gorm:"type:text"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MessageThread is a conversation between a buyer and a store, tied either to
+// an order or, before a purchase, to a product. An order has at most one
+// thread and a buyer has at most one pre-sale thread per product.
+type MessageThread struct {
+	gorm.Model
+	ThreadUUID    string    `gorm:"type:char(36);uniqueIndex;not null"`
+	OrderID       *uint     `gorm:"uniqueIndex"`
+	Order         *Order    `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ProductID     *uint     `gorm:"uniqueIndex:idx_thread_product_buyer"`
+	Product       *Product  `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	BuyerID       uint      `gorm:"not null;index;uniqueIndex:idx_thread_product_buyer"`
+	Buyer         User      `gorm:"foreignKey:BuyerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	StoreID       uint      `gorm:"not null;index"`
+	Store         Store     `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	LastMessageAt time.Time `gorm:"not null;index"`
+
+	Messages []Message `gorm:"foreignKey:ThreadID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type Message struct {
+	ID          uint   `gorm:"primarykey"`
+	MessageUUID string `gorm:"type:char(36);uniqueIndex;not null"`
+	ThreadID    uint   `gorm:"not null;index"`
+	SenderID    uint   `gorm:"not null"`
+	Sender      User   `gorm:"foreignKey:SenderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	SenderRole  string `gorm:"type:enum('buyer', 'seller', 'admin');not null"`
+	Body        string `gorm:"type:text;not null"`
+	CreatedAt   time.Time
+
+	Attachments []MessageAttachment `gorm:"foreignKey:MessageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// MessageAttachment is a file attached to a message, referenced by the URL it
+// was uploaded to.
+type MessageAttachment struct {
+	ID          uint   `gorm:"primarykey"`
+	MessageID   uint   `gorm:"not null;index"`
+	URL         string `gorm:"size:2048;not null"`
+	FileName    string `gorm:"size:255"`
+	ContentType string `gorm:"size:100"`
+}
+
+// MessageThreadRead is the read receipt of a participant: every message of
+// the thread up to LastReadMessageID has been read by the user.
+type MessageThreadRead struct {
+	ID                uint      `gorm:"primarykey"`
+	ThreadID          uint      `gorm:"not null;uniqueIndex:idx_thread_read_user"`
+	UserID            uint      `gorm:"not null;uniqueIndex:idx_thread_read_user"`
+	LastReadMessageID uint      `gorm:"not null;default:0"`
+	ReadAt            time.Time `gorm:"not null"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Notification struct {
+	gorm.Model
+	NotificationUUID string `gorm:"type:char(36);uniqueIndex;not null"`
+	UserID           uint   `gorm:"not null;index"`
+	User             User   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type             string `gorm:"type:enum('low_stock', 'back_in_stock', 'payment_reminder', 'order_expired', 'completion_reminder', 'order_completed', 'order_disputed', 'return_requested', 'return_updated', 'order_updated', 'new_message', 'export_ready');not null"`
+	Title            string `gorm:"size:255;not null"`
+	Message          string `gorm:"type:text;not null"`
+	ReferenceType    string `gorm:"size:50"`
+	ReferenceID      string `gorm:"size:64"`
+	ReadAt           *time.Time
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// OrderDocument is an invoice or a receipt issued for an order. The PDF is
+// rendered once, when the document is first requested, and is never changed
+// afterwards. Sequence numbers the documents of a type per store without
+// gaps; Number is the printed form.
+type OrderDocument struct {
+	ID           uint      `gorm:"primarykey"`
+	DocumentUUID string    `gorm:"type:char(36);uniqueIndex;not null"`
+	OrderID      uint      `gorm:"not null;uniqueIndex:idx_document_order_type"`
+	Order        Order     `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	StoreID      uint      `gorm:"not null;uniqueIndex:idx_document_store_sequence"`
+	Store        Store     `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type         string    `gorm:"type:enum('invoice', 'receipt');not null;uniqueIndex:idx_document_order_type;uniqueIndex:idx_document_store_sequence"`
+	Sequence     int       `gorm:"not null;uniqueIndex:idx_document_store_sequence"`
+	Number       string    `gorm:"size:50;not null"`
+	Content      []byte    `gorm:"type:mediumblob;not null"`
+	Checksum     string    `gorm:"type:char(64);not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
@@ -1,14 +1,44 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
 
 type Order struct {
 	gorm.Model
 	OrderUUID string `gorm:"type:char(36);uniqueIndex;not null"`
-	UserID 	  uint 	 `gorm:"not null"`
+	UserID 	  uint 	 `gorm:"not null;index:idx_orders_user_total,priority:1;index:idx_orders_user_created,priority:1"`
 	User 	  User 	 `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Status    string `gorm:"type:enum('pending', 'processed', 'shipped', 'completed', 'cancelled');default:'pending';not null"`
-	TotalPrice float64 `gorm:"not null"`
+	// StoreID is the store the order was placed with, kept on the order so
+	// it does not depend on its products, which may be deleted later.
+	StoreID   uint   `gorm:"not null;default:0;index:idx_orders_store_created,priority:1"`
+	Status    string `gorm:"type:enum('pending', 'processed', 'shipped', 'delivered', 'disputed', 'completed', 'cancelled');default:'pending';not null"`
+	TotalPrice money.Amount `gorm:"type:decimal(20,2);not null;index:idx_orders_user_total,priority:2"`
+	// DiscountAmount is the discount of promotions and VoucherCode, TotalPrice
+	// is net of it.
+	DiscountAmount money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	VoucherCode string `gorm:"size:32"`
+	// ShippingCost is the cost of the chosen shipping option, included in
+	// TotalPrice.
+	ShippingCost money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	// TaxAmount is the tax of the items, included in TotalPrice whether the
+	// store's prices include tax (TaxInclusive) or tax was added on top.
+	TaxAmount money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	TaxInclusive bool `gorm:"not null;default:false"`
+	Currency   string `gorm:"type:char(3);not null;default:'IDR'"`
+	DisplayCurrency string `gorm:"type:char(3);not null;default:'IDR'"`
+	ExchangeRate money.Rate `gorm:"type:decimal(20,8);not null;default:1"`
+	WarehouseID *uint
+	Warehouse  *Warehouse `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CheckoutID *uint `gorm:"index"`
+	PaymentReminderSentAt *time.Time
+	CompletionReminderSentAt *time.Time
+	// CreatedAt shadows the one of gorm.Model to index it for the date
+	// filters of the order lists.
+	CreatedAt  time.Time `gorm:"index:idx_orders_user_created,priority:2;index:idx_orders_store_created,priority:2;index:idx_orders_created_at"`
 
 	Items []OrderItem `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Payment *Payment `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
@@ -1,6 +1,11 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
 
 type  OrderItem struct {
 	gorm.Model
@@ -10,6 +15,26 @@ type  OrderItem struct {
 	ProductID     uint   `gorm:"not null"`
 	Product       Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Quantity      int    `gorm:"not null"`
-	TotalPrice    float64 `gorm:"not null"`
-	ProductName   string  `gorm:"-" json:"product_name"`
+	// ProductUUID, ProductName, SKU, VariantAttributes, ImageURL and
+	// UnitPrice are a snapshot of the product when the order was placed, so
+	// editing or deleting the product does not change the order. UnitPrice is
+	// in the order currency, before discounts and before any tax added on top
+	// of it.
+	ProductUUID   string `gorm:"type:char(36);not null;default:''"`
+	ProductName   string  `gorm:"size:255;not null;default:''" json:"product_name"`
+	SKU           string  `gorm:"size:64"`
+	VariantAttributes map[string]string `gorm:"type:json;serializer:json"`
+	ImageURL      string  `gorm:"size:2048"`
+	UnitPrice     money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	// TotalPrice is what the buyer pays for the item: net of DiscountAmount,
+	// the sum of the item's discount lines in Discounts, and including
+	// TaxAmount, charged at TaxBasisPoints.
+	TotalPrice    money.Amount `gorm:"type:decimal(20,2);not null"`
+	DiscountAmount money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	TaxAmount     money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	TaxBasisPoints int `gorm:"not null;default:0"`
+	Status        string  `gorm:"type:enum('active', 'cancelled');default:'active';not null"`
+	CancelledAt   *time.Time
+
+	Discounts []OrderItemDiscount `gorm:"foreignKey:OrderItemID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// OrderStatusHistory records one status change of an order, its payment or
+// its shipping. A record with an empty FromStatus marks the creation.
+type OrderStatusHistory struct {
+	ID         uint   `gorm:"primarykey"`
+	OrderID    uint   `gorm:"not null;index"`
+	Order      Order  `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Machine    string `gorm:"type:enum('order', 'payment', 'shipping');not null"`
+	FromStatus string `gorm:"type:varchar(32);not null;default:''"`
+	ToStatus   string `gorm:"type:varchar(32);not null"`
+	ActorType  string `gorm:"type:enum('buyer', 'seller', 'admin', 'system');not null"`
+	ActorID    *uint
+	Reason     string    `gorm:"size:255"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
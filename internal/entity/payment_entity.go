@@ -1,13 +1,20 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
 
 type Payment struct {
 	gorm.Model
 	PaymentUUID string  `gorm:"type:char(36);uniqueIndex;not null"`
-	OrderID     uint    `gorm:"not null"`
+	OrderID     uint    `gorm:"not null;index:idx_payments_order_method,priority:1"`
 	Order       Order   `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Amount  	float64 `gorm:"not null"`
-	Status      string  `gorm:"type:enum('pending', 'paid', 'cancelled');default:'pending';not null"`
-	Method      string  `gorm:"type:enum('cash', 'transfer');not null"`
+	Amount  	money.Amount `gorm:"type:decimal(20,2);not null"`
+	Currency    string  `gorm:"type:char(3);not null;default:'IDR'"`
+	DisplayCurrency string `gorm:"type:char(3);not null;default:'IDR'"`
+	ExchangeRate money.Rate `gorm:"type:decimal(20,8);not null;default:1"`
+	Status      string  `gorm:"type:enum('pending', 'paid', 'partially_refunded', 'refunded', 'cancelled');default:'pending';not null"`
+	Method      string  `gorm:"type:enum('cash', 'transfer');not null;index:idx_payments_order_method,priority:2"`
+	RefundedAmount money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
 }
\ No newline at end of file
@@ -1,6 +1,9 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
 
 type Product struct {
 	gorm.Model
@@ -8,10 +11,25 @@ type Product struct {
 	StoreID     uint    `gorm:"not null"`
 	Store       Store   `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	ProductName string  `gorm:"size:255;not null"`
+	SKU         string  `gorm:"size:64"`
+	ImageURL    string  `gorm:"size:2048"`
+	// Attributes describe the variant sold, such as its size or colour.
+	Attributes  map[string]string `gorm:"type:json;serializer:json"`
 	Description string  `gorm:"type:text"`
-	Price       float64 `gorm:"not null"`
+	Price       money.Amount `gorm:"type:decimal(20,2);not null"`
+	Currency    string  `gorm:"type:char(3);not null;default:'IDR'"`
 	Stock       int     `gorm:"not null"`
+	LowStockThreshold int `gorm:"not null;default:0"`
 	Category    string  `gorm:"type:enum('clothes', 'electronics', 'accessories');not null"`
+	// TaxCategory selects the TaxRate charged on the product; a category
+	// without a rate, such as "exempt", is not taxed.
+	TaxCategory string  `gorm:"size:50;not null;default:'standard'"`
+	// Weight is in grams and the dimensions of the packed product in
+	// centimetres, they price its shipping.
+	Weight      int     `gorm:"not null;default:0"`
+	Length      int     `gorm:"not null;default:0"`
+	Width       int     `gorm:"not null;default:0"`
+	Height      int     `gorm:"not null;default:0"`
 
 	OrderItems []OrderItem `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
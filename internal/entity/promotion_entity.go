@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// Promotion is an automatic discount of a store, applied to every order line
+// it covers without a code. A promotion without products and categories
+// covers every product of the store. Percentage promotions take Percent off,
+// buy X get Y promotions make GetQuantity of every BuyQuantity + GetQuantity
+// units of a line free, tiered promotions take the percent of the highest
+// tier the line quantity reaches off, and bundle promotions take Percent off
+// every complete set of their products bought together, one unit of each.
+type Promotion struct {
+	gorm.Model
+	PromotionUUID string `gorm:"type:char(36);uniqueIndex;not null"`
+	StoreID       uint   `gorm:"not null;index"`
+	Store         Store  `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name          string `gorm:"size:100;not null"`
+	Type          string `gorm:"type:enum('percentage', 'buy_x_get_y', 'tiered', 'bundle');not null"`
+	Percent       int    `gorm:"not null;default:0"`
+	BuyQuantity   int    `gorm:"not null;default:0"`
+	GetQuantity   int    `gorm:"not null;default:0"`
+	Categories    string `gorm:"type:set('clothes', 'electronics', 'accessories');not null;default:''"`
+	StartsAt      *time.Time
+	EndsAt        *time.Time
+	IsActive      bool `gorm:"not null;default:true"`
+
+	Tiers    []PromotionTier `gorm:"foreignKey:PromotionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Products []Product       `gorm:"many2many:promotion_products;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type PromotionTier struct {
+	ID          uint `gorm:"primarykey"`
+	PromotionID uint `gorm:"not null;index"`
+	MinQuantity int  `gorm:"not null"`
+	Percent     int  `gorm:"not null"`
+}
+
+// OrderItemDiscount is a discount line of an order item: the promotion or
+// the voucher that produced it and the amount it took off the item.
+type OrderItemDiscount struct {
+	ID          uint         `gorm:"primarykey"`
+	OrderItemID uint         `gorm:"not null;index"`
+	PromotionID *uint        `gorm:"index"`
+	Promotion   *Promotion   `gorm:"foreignKey:PromotionID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	VoucherID   *uint        `gorm:"index"`
+	Voucher     *Voucher     `gorm:"foreignKey:VoucherID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Label       string       `gorm:"size:100;not null"`
+	Amount      money.Amount `gorm:"type:decimal(20,2);not null"`
+	CreatedAt   time.Time
+}
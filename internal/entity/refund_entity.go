@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// Refund is money paid back on the payment of an order. It is created
+// "pending" together with a "refund_issued" order event and becomes
+// "completed" once the payment consumer has booked it on the payment.
+// ReferenceType and ReferenceID name what caused it, such as a return.
+type Refund struct {
+	gorm.Model
+	RefundUUID    string       `gorm:"type:char(36);uniqueIndex;not null"`
+	OrderID       uint         `gorm:"not null;index"`
+	Order         Order        `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Amount        money.Amount `gorm:"type:decimal(20,2);not null"`
+	Currency      string       `gorm:"type:char(3);not null;default:'IDR'"`
+	Reason        string       `gorm:"size:255"`
+	ReferenceType string       `gorm:"size:50"`
+	ReferenceID   string       `gorm:"size:64"`
+	Status        string       `gorm:"type:enum('pending', 'completed');default:'pending';not null"`
+	CompletedAt   *time.Time
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// ReturnRequest is a buyer's request to send items of an order back. Its
+// status follows statemachine.Return; RefundAmount is set by the seller when
+// the returned items have been inspected.
+type ReturnRequest struct {
+	gorm.Model
+	ReturnUUID   string       `gorm:"type:char(36);uniqueIndex;not null"`
+	OrderID      uint         `gorm:"not null;index"`
+	Order        Order        `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID       uint         `gorm:"not null;index"`
+	User         User         `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	StoreID      uint         `gorm:"not null;index"`
+	Store        Store        `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Status       string       `gorm:"type:enum('requested', 'approved', 'rejected', 'inspected', 'refunded');default:'requested';not null"`
+	Reason       string       `gorm:"type:text;not null"`
+	SellerNote   string       `gorm:"type:text"`
+	RefundAmount money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	Currency     string       `gorm:"type:char(3);not null;default:'IDR'"`
+	RefundedAt   *time.Time
+
+	Items  []ReturnItem  `gorm:"foreignKey:ReturnRequestID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Photos []ReturnPhoto `gorm:"foreignKey:ReturnRequestID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// ReturnItem is a quantity of one order item sent back. Amount is the share
+// of the order item's total price for that quantity.
+type ReturnItem struct {
+	ID              uint         `gorm:"primarykey"`
+	ReturnRequestID uint         `gorm:"not null;index"`
+	OrderItemID     uint         `gorm:"not null;index"`
+	OrderItem       OrderItem    `gorm:"foreignKey:OrderItemID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Quantity        int          `gorm:"not null"`
+	Amount          money.Amount `gorm:"type:decimal(20,2);not null"`
+}
+
+type ReturnPhoto struct {
+	ID              uint   `gorm:"primarykey"`
+	ReturnRequestID uint   `gorm:"not null;index"`
+	URL             string `gorm:"size:2048;not null"`
+}
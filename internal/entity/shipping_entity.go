@@ -1,15 +1,30 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
 
 type Shipping struct {
 	gorm.Model
 	ShippingUUID string `gorm:"type:char(36);uniqueIndex;not null"`
 	OrderID      uint   `gorm:"not null"`
 	Order        Order  `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	// The address is copied from the order, inline or from the buyer's
+	// address book, so later edits of the address book do not move it.
+	RecipientName string `gorm:"size:255"`
+	Phone        string `gorm:"size:20"`
 	Address      string `gorm:"size:255;not null"`
 	City         string `gorm:"size:255;not null"`
+	District     string `gorm:"size:255"`
 	Province     string `gorm:"size:255;not null"`
 	PostalCode   string `gorm:"size:10;not null"`
 	Status       string `gorm:"type:enum('pending', 'shipped', 'delivered', 'cancelled');default:'pending';not null"`
+	// Provider and Service name the chosen shipping option, Cost is what it
+	// costs in the order currency, for a parcel of Weight grams.
+	Provider      string       `gorm:"size:50"`
+	Service       string       `gorm:"size:50"`
+	Cost          money.Amount `gorm:"type:decimal(20,2);not null;default:0"`
+	Weight        int          `gorm:"not null;default:0"`
+	EstimatedDays int          `gorm:"not null;default:0"`
 }
\ No newline at end of file
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// ShippingRate is a row of the shipping rate table: what a Service costs for
+// a parcel from OriginProvince to DestinationProvince weighing from MinWeight
+// up to, but not including, MaxWeight grams. An empty province matches every
+// province and a MaxWeight of 0 has no upper bound.
+type ShippingRate struct {
+	gorm.Model
+	RateUUID            string       `gorm:"type:char(36);uniqueIndex;not null"`
+	Service             string       `gorm:"size:50;not null;index"`
+	OriginProvince      string       `gorm:"size:255;not null;default:''"`
+	DestinationProvince string       `gorm:"size:255;not null;default:''"`
+	MinWeight           int          `gorm:"not null;default:0"`
+	MaxWeight           int          `gorm:"not null;default:0"`
+	Cost                money.Amount `gorm:"type:decimal(20,2);not null"`
+	Currency            string       `gorm:"type:char(3);not null;default:'IDR'"`
+	EstimatedDays       int          `gorm:"not null;default:0"`
+}
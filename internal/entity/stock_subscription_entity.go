@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockSubscription struct {
+	gorm.Model
+	UserID     uint    `gorm:"not null;uniqueIndex:idx_stock_subscriptions_user_product"`
+	User       User    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ProductID  uint    `gorm:"not null;uniqueIndex:idx_stock_subscriptions_user_product"`
+	Product    Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	NotifiedAt *time.Time
+}
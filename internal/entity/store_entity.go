@@ -8,5 +8,14 @@ type Store struct {
 	User        User   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	StoreName   string `gorm:"size:255;not null"`
 	Description string `gorm:"type:text"`
+	Currency    string `gorm:"type:char(3);not null;default:'IDR'"`
+	// TaxMode tells whether product prices already include tax or tax is
+	// added on top of them.
+	TaxMode     string `gorm:"type:enum('exclusive', 'inclusive');not null;default:'exclusive'"`
+	// OriginCity, OriginProvince and OriginPostalCode are where orders
+	// ship from when the store runs no warehouses.
+	OriginCity       string `gorm:"size:255"`
+	OriginProvince   string `gorm:"size:255"`
+	OriginPostalCode string `gorm:"size:10"`
 	Products []Product `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
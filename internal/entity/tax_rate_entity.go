@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaxRate is the tax charged on products of a tax Category from EffectiveFrom
+// until EffectiveTo, in basis points: 1100 is 11%. A rate in effect is never
+// changed; a new rate gets its own row with a later EffectiveFrom.
+type TaxRate struct {
+	gorm.Model
+	TaxRateUUID   string    `gorm:"type:char(36);uniqueIndex;not null"`
+	Category      string    `gorm:"size:50;not null;index"`
+	Name          string    `gorm:"size:100;not null"`
+	BasisPoints   int       `gorm:"not null"`
+	EffectiveFrom time.Time `gorm:"not null"`
+	EffectiveTo   *time.Time
+}
@@ -10,7 +10,7 @@ type User struct {
 	Username  string 	`gorm:"size:100;not null;uniqueIndex"`
 	Name      string    `gorm:"size:100;not null"`
 	Email     string    `gorm:"size:100;uniqueIndex;not null"`
-	Role      string    `gorm:"type:enum('seller','buyer');not null"`
+	Role      string    `gorm:"type:enum('seller','buyer','admin');not null"`
 	Password  string	`gorm:"size:255;not null"`
 	ConfirmPassword string `gorm:"-"`
 	
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+// Voucher is a discount code. Vouchers without a store are platform vouchers
+// and apply to orders of any store; store vouchers only apply to orders of
+// their store. A voucher without products and categories covers every item of
+// an order, otherwise only the items of the listed products or categories.
+// Amounts are in Currency and converted to the store currency of an order.
+type Voucher struct {
+	gorm.Model
+	VoucherUUID  string        `gorm:"type:char(36);uniqueIndex;not null"`
+	Code         string        `gorm:"size:32;uniqueIndex;not null"`
+	StoreID      *uint         `gorm:"index"`
+	Store        *Store        `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type         string        `gorm:"type:enum('percentage', 'fixed');not null"`
+	Percent      int           `gorm:"not null;default:0"`
+	Amount       money.Amount  `gorm:"type:decimal(20,2);not null;default:0"`
+	MaxDiscount  *money.Amount `gorm:"type:decimal(20,2)"`
+	MinSpend     money.Amount  `gorm:"type:decimal(20,2);not null;default:0"`
+	Currency     string        `gorm:"type:char(3);not null;default:'IDR'"`
+	Categories   string        `gorm:"type:set('clothes', 'electronics', 'accessories');not null;default:''"`
+	UsageLimit   int           `gorm:"not null;default:0"`
+	PerUserLimit int           `gorm:"not null;default:0"`
+	UsedCount    int           `gorm:"not null;default:0"`
+	StartsAt     *time.Time
+	EndsAt       *time.Time
+	IsActive     bool `gorm:"not null;default:true"`
+
+	Products []Product `gorm:"many2many:voucher_products;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// VoucherRedemption is the use of a voucher by an order. It is deleted again
+// when the order is cancelled, which gives the use back.
+type VoucherRedemption struct {
+	ID        uint         `gorm:"primarykey"`
+	VoucherID uint         `gorm:"not null;index:idx_redemption_voucher_user,priority:1"`
+	Voucher   Voucher      `gorm:"foreignKey:VoucherID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID    uint         `gorm:"not null;index:idx_redemption_voucher_user,priority:2"`
+	OrderID   uint         `gorm:"not null;uniqueIndex"`
+	Order     Order        `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Discount  money.Amount `gorm:"type:decimal(20,2);not null"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Warehouse struct {
+	gorm.Model
+	WarehouseUUID string `gorm:"type:char(36);uniqueIndex;not null"`
+	StoreID       uint   `gorm:"not null;index"`
+	Store         Store  `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name          string `gorm:"size:255;not null"`
+	Address       string `gorm:"size:255;not null"`
+	City          string `gorm:"size:255;not null"`
+	Province      string `gorm:"size:255;not null"`
+	PostalCode    string `gorm:"size:10;not null"`
+	Priority      int    `gorm:"not null;default:0"`
+	IsActive      bool   `gorm:"not null;default:true"`
+}
+
+// WarehouseStock is the per-location part of Product.Stock. Product.Stock stays
+// the total sellable stock and always moves together with these rows.
+type WarehouseStock struct {
+	gorm.Model
+	WarehouseID uint      `gorm:"not null;uniqueIndex:idx_warehouse_stocks_warehouse_product"`
+	Warehouse   Warehouse `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ProductID   uint      `gorm:"not null;uniqueIndex:idx_warehouse_stocks_warehouse_product"`
+	Product     Product   `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Stock       int       `gorm:"not null;default:0"`
+}
@@ -0,0 +1,51 @@
+package model
+
+type CreateAddressRequest struct {
+	UserID        uint   `json:"-"`
+	Label         string `json:"label" validate:"required,max=50"`
+	RecipientName string `json:"recipient_name" validate:"required,max=255"`
+	Phone         string `json:"phone" validate:"required,e164"`
+	Address       string `json:"address" validate:"required,max=255"`
+	Province      string `json:"province" validate:"required,max=255"`
+	City          string `json:"city" validate:"required,max=255"`
+	District      string `json:"district" validate:"max=255"`
+	PostalCode    string `json:"postal_code" validate:"required,len=5,numeric"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+// UpdateAddressRequest changes the given fields of an address. Setting
+// is_default makes it the default address; the default cannot be unset
+// directly, only by making another address the default.
+type UpdateAddressRequest struct {
+	UserID        uint    `json:"-"`
+	AddressUUID   string  `json:"-" validate:"required,uuid"`
+	Label         string  `json:"label" validate:"omitempty,max=50"`
+	RecipientName string  `json:"recipient_name" validate:"omitempty,max=255"`
+	Phone         string  `json:"phone" validate:"omitempty,e164"`
+	Address       string  `json:"address" validate:"omitempty,max=255"`
+	Province      string  `json:"province" validate:"omitempty,max=255"`
+	City          string  `json:"city" validate:"omitempty,max=255"`
+	District      *string `json:"district" validate:"omitempty,max=255"`
+	PostalCode    string  `json:"postal_code" validate:"omitempty,len=5,numeric"`
+	IsDefault     bool    `json:"is_default"`
+}
+
+type GetAddressRequest struct {
+	UserID      uint   `json:"-"`
+	AddressUUID string `json:"-" validate:"required,uuid"`
+}
+
+type AddressResponse struct {
+	AddressUUID   string `json:"address_uuid"`
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Address       string `json:"address"`
+	Province      string `json:"province"`
+	City          string `json:"city"`
+	District      string `json:"district,omitempty"`
+	PostalCode    string `json:"postal_code"`
+	IsDefault     bool   `json:"is_default"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
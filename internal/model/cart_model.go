@@ -0,0 +1,64 @@
+package model
+
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
+// CartOwner identifies whose cart a request works on: a logged in buyer, or a
+// guest holding a cart token.
+type CartOwner struct {
+	UserID     uint   `json:"-"`
+	GuestToken string `json:"-"`
+}
+
+type GetCartRequest struct {
+	CartOwner
+}
+
+type AddCartItemRequest struct {
+	CartOwner
+	ProductUUID string `json:"product_uuid" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,gte=1"`
+}
+
+type UpdateCartItemRequest struct {
+	CartOwner
+	ProductUUID string `json:"-" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,gte=1"`
+}
+
+type RemoveCartItemRequest struct {
+	CartOwner
+	ProductUUID string `json:"-" validate:"required,uuid"`
+}
+
+type CheckoutCartRequest struct {
+	UserID          uint                   `json:"-" validate:"required"`
+	AddressID       string                 `json:"address_id" validate:"omitempty,uuid"`
+	ShippingAddress ShippingAddressRequest `json:"shipping_address" validate:"required"`
+	Payments        PaymentRequest         `json:"payments" validate:"required"`
+	Currency        string                 `json:"currency" validate:"omitempty,iso4217"`
+	VoucherCode     string                 `json:"voucher_code" validate:"omitempty,alphanum,max=32"`
+	ShippingOption  string                 `json:"shipping_option" validate:"max=101"`
+}
+
+type CartResponse struct {
+	CartUUID   string                  `json:"cart_uuid,omitempty"`
+	Items      []CartItemResponse      `json:"items"`
+	Totals     map[string]money.Amount `json:"totals"`
+	HasWarning bool                    `json:"has_warning"`
+	GuestToken string                  `json:"-"`
+}
+
+type CartItemResponse struct {
+	ProductUUID string                    `json:"product_uuid"`
+	ProductName string                    `json:"product_name"`
+	Store       string                    `json:"store"`
+	Quantity    int                       `json:"quantity"`
+	UnitPrice   money.Amount              `json:"unit_price"`
+	PriceAtAdd  money.Amount              `json:"price_at_add"`
+	Currency    string                    `json:"currency"`
+	Subtotal    money.Amount              `json:"subtotal"`
+	Discount    money.Amount              `json:"discount,omitempty"`
+	Promotion   *AppliedPromotionResponse `json:"promotion,omitempty"`
+	Stock       int                       `json:"stock"`
+	Warnings    []string                  `json:"warnings,omitempty"`
+}
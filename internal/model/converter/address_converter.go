@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func AddressToResponse(address *entity.Address) *model.AddressResponse {
+	return &model.AddressResponse{
+		AddressUUID:   address.AddressUUID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Address:       address.Address,
+		Province:      address.Province,
+		City:          address.City,
+		District:      address.District,
+		PostalCode:    address.PostalCode,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     address.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     address.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+// CartToResponse builds the cart view from live product data. Every item is
+// checked against the product's current price and stock, and the differences
+// are reported as warnings instead of being silently applied.
+func CartToResponse(cart *entity.Cart) *model.CartResponse {
+	response := &model.CartResponse{
+		Items:  make([]model.CartItemResponse, 0, len(cart.Items)),
+		Totals: make(map[string]money.Amount),
+	}
+	if cart.ID == 0 {
+		return response
+	}
+	response.CartUUID = cart.CartUUID
+	if cart.GuestToken != nil {
+		response.GuestToken = *cart.GuestToken
+	}
+
+	for _, item := range cart.Items {
+		if item.Product.ID == 0 {
+			response.HasWarning = true
+			response.Items = append(response.Items, model.CartItemResponse{
+				Quantity:   item.Quantity,
+				PriceAtAdd: item.PriceAtAdd,
+				Currency:   item.Currency,
+				Warnings:   []string{"Product is no longer available"},
+			})
+			continue
+		}
+		itemResponse := model.CartItemResponse{
+			ProductUUID: item.Product.ProductUUID,
+			ProductName: item.Product.ProductName,
+			Store:       item.Product.Store.StoreName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Product.Price,
+			PriceAtAdd:  item.PriceAtAdd,
+			Currency:    item.Product.Currency,
+			Subtotal:    item.Product.Price.Mul(item.Quantity),
+			Stock:       item.Product.Stock,
+		}
+		if item.Product.Price != item.PriceAtAdd || item.Product.Currency != item.Currency {
+			itemResponse.Warnings = append(itemResponse.Warnings,
+				fmt.Sprintf("Price changed from %s %s to %s %s", item.Currency, item.PriceAtAdd, item.Product.Currency, item.Product.Price))
+		}
+		if item.Product.Stock <= 0 {
+			itemResponse.Warnings = append(itemResponse.Warnings, "Out of stock")
+		} else if item.Product.Stock < item.Quantity {
+			itemResponse.Warnings = append(itemResponse.Warnings, fmt.Sprintf("Only %d left in stock", item.Product.Stock))
+		}
+		if len(itemResponse.Warnings) > 0 {
+			response.HasWarning = true
+		}
+		response.Totals[itemResponse.Currency] = response.Totals[itemResponse.Currency].Add(itemResponse.Subtotal)
+		response.Items = append(response.Items, itemResponse)
+	}
+	return response
+}
@@ -0,0 +1,51 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+// CheckoutToResponse builds the checkout response around the given order
+// responses. The total sums every order that was not cancelled, converted to
+// the checkout currency at the rate locked on each order.
+func CheckoutToResponse(checkout *entity.Checkout, orders []model.OrderResponse) *model.CheckoutResponse {
+	var total money.Amount
+	for _, order := range checkout.Orders {
+		if order.Status == "cancelled" {
+			continue
+		}
+		total = total.Add(order.TotalPrice.Convert(order.ExchangeRate))
+	}
+	response := &model.CheckoutResponse{
+		CheckoutUUID:  checkout.CheckoutUUID,
+		Status:        checkout.Status,
+		PaymentMethod: checkout.PaymentMethod,
+		Currency:      checkout.Currency,
+		TotalAmount:   total,
+		PaidAmount:    checkout.PaidAmount,
+		Orders:        orders,
+		CreatedAt:     checkout.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if checkout.PaidAt != nil {
+		response.PaidAt = checkout.PaidAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
+
+// CheckoutOrdersToResponse converts the orders of a checkout. Payment and
+// shipping rows are created asynchronously, so an order may not have them yet.
+func CheckoutOrdersToResponse(orders []entity.Order) []model.OrderResponse {
+	responses := make([]model.OrderResponse, len(orders))
+	for i := range orders {
+		order := orders[i]
+		if order.Payment == nil {
+			order.Payment = &entity.Payment{}
+		}
+		if order.Shipping == nil {
+			order.Shipping = &entity.Shipping{}
+		}
+		responses[i] = *OrderToResponse(&order)
+	}
+	return responses
+}
@@ -0,0 +1,15 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func ExchangeRateToResponse(rate *entity.ExchangeRate) *model.ExchangeRateResponse {
+	return &model.ExchangeRateResponse{
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          rate.Rate,
+		UpdatedAt:     rate.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
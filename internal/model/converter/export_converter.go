@@ -0,0 +1,28 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func ExportToResponse(export *entity.OrderExport) *model.ExportResponse {
+	response := &model.ExportResponse{
+		ExportUUID: export.ExportUUID,
+		Format:     export.Format,
+		Status:     export.Status,
+		RowCount:   export.RowCount,
+		FileName:   export.FileName,
+		Error:      export.Error,
+		CreatedAt:  export.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if export.Status == "completed" {
+		response.DownloadURL = "/api/seller/exports/" + export.ExportUUID + "/download"
+	}
+	if export.CompletedAt != nil {
+		response.CompletedAt = export.CompletedAt.Format("2006-01-02 15:04:05")
+	}
+	if export.ExpiresAt != nil {
+		response.ExpiresAt = export.ExpiresAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func InventoryMovementToResponse(movement *entity.InventoryMovement) *model.InventoryMovementResponse {
+	response := &model.InventoryMovementResponse{
+		MovementUUID:  movement.MovementUUID,
+		Quantity:      movement.Quantity,
+		StockAfter:    movement.StockAfter,
+		Reason:        movement.Reason,
+		ReferenceType: movement.ReferenceType,
+		ReferenceID:   movement.ReferenceID,
+		Note:          movement.Note,
+		CreatedAt:     movement.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if movement.Warehouse != nil {
+		response.WarehouseUUID = movement.Warehouse.WarehouseUUID
+	}
+	return response
+}
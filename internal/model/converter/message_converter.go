@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func ThreadToResponse(thread *entity.MessageThread, unreadCount int64) *model.ThreadResponse {
+	response := &model.ThreadResponse{
+		ThreadUUID:    thread.ThreadUUID,
+		Type:          "order",
+		StoreName:     thread.Store.StoreName,
+		BuyerName:     thread.Buyer.Name,
+		UnreadCount:   unreadCount,
+		LastMessageAt: thread.LastMessageAt.Format("2006-01-02 15:04:05"),
+		CreatedAt:     thread.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if thread.Order != nil {
+		response.OrderUUID = thread.Order.OrderUUID
+	}
+	if thread.Product != nil {
+		response.Type = "product"
+		response.ProductUUID = thread.Product.ProductUUID
+		response.ProductName = thread.Product.ProductName
+	}
+	return response
+}
+
+// MessageToResponse converts a message as seen by viewerID. The message is
+// read when the recipient's read receipt covers it.
+func MessageToResponse(message *entity.Message, viewerID uint, recipientReadUpTo uint) *model.MessageResponse {
+	attachments := make([]model.AttachmentResponse, len(message.Attachments))
+	for i, attachment := range message.Attachments {
+		attachments[i] = model.AttachmentResponse{
+			URL:         attachment.URL,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+		}
+	}
+	return &model.MessageResponse{
+		MessageUUID: message.MessageUUID,
+		SenderRole:  message.SenderRole,
+		SenderName:  message.Sender.Name,
+		Mine:        message.SenderID == viewerID,
+		Body:        message.Body,
+		Attachments: attachments,
+		Read:        message.ID <= recipientReadUpTo,
+		CreatedAt:   message.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func NotificationToResponse(notification *entity.Notification) *model.NotificationResponse {
+	response := &model.NotificationResponse{
+		NotificationUUID: notification.NotificationUUID,
+		Type:             notification.Type,
+		Title:            notification.Title,
+		Message:          notification.Message,
+		ReferenceType:    notification.ReferenceType,
+		ReferenceID:      notification.ReferenceID,
+		CreatedAt:        notification.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if notification.ReadAt != nil {
+		response.ReadAt = notification.ReadAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
@@ -12,29 +12,56 @@ func OrderToResponse(order *entity.Order) *model.OrderResponse {
     for i, item := range order.Items {
         items[i] = model.OrderItemResponse{
             OrderItemUuid: item.OrderItemUUID,
-            ProductName:   item.Product.ProductName,
-            Price:         item.Product.Price,
+            ProductUUID:   item.ProductUUID,
+            ProductName:   item.ProductName,
+            SKU:           item.SKU,
+            VariantAttributes: item.VariantAttributes,
+            ImageURL:      item.ImageURL,
+            Price:         item.UnitPrice,
             Quantity:  item.Quantity,
+            Discount:  item.DiscountAmount,
+            Discounts: DiscountLinesToResponse(item.Discounts),
+            Tax:       item.TaxAmount,
+            TaxBasisPoints: item.TaxBasisPoints,
+            Status:    item.Status,
         }
     }
 
     return &model.OrderResponse{
         OrderUUID:  order.OrderUUID,
         TotalPrice: order.TotalPrice,
+        Currency:   order.Currency,
+        DisplayCurrency: order.DisplayCurrency,
+        ExchangeRate: order.ExchangeRate,
+        DisplayTotalPrice: order.TotalPrice.Convert(order.ExchangeRate),
+        DiscountAmount: order.DiscountAmount,
+        VoucherCode: order.VoucherCode,
+        ShippingCost: order.ShippingCost,
+        TaxAmount: order.TaxAmount,
+        TaxInclusive: order.TaxInclusive,
         Status:     order.Status,
         Items:      items,
         Shipping: model.ShippingResponse{
             ShippingUUID: order.Shipping.ShippingUUID,
+            RecipientName: order.Shipping.RecipientName,
+            Phone:        order.Shipping.Phone,
             Address:      order.Shipping.Address,
             City:         order.Shipping.City,
+            District:     order.Shipping.District,
             Province:     order.Shipping.Province,
             PostalCode:   order.Shipping.PostalCode,
             Status:       order.Shipping.Status,
+            Provider:     order.Shipping.Provider,
+            Service:      order.Shipping.Service,
+            Cost:         order.Shipping.Cost,
+            Weight:       order.Shipping.Weight,
+            EstimatedDays: order.Shipping.EstimatedDays,
         },
         Payment: model.PaymentResponse{
             PaymentUUID:  order.Payment.PaymentUUID,
             PaymentMethod: order.Payment.Method,
             Status:        order.Payment.Status,
+            RefundedAmount: order.Payment.RefundedAmount,
         },
         CreatedAt: order.CreatedAt.Format("2006-01-02 15:04:05"),
     }
@@ -44,6 +71,9 @@ func OrdersToResponse(orders *entity.Order) *model.ListOrderResponse {
     return &model.ListOrderResponse{
         OrderUUID: orders.OrderUUID,
         TotalPrice: orders.TotalPrice,
+        Currency: orders.Currency,
+        DisplayCurrency: orders.DisplayCurrency,
+        DisplayTotalPrice: orders.TotalPrice.Convert(orders.ExchangeRate),
         Status: orders.Status,
         Date: orders.CreatedAt.Format("2006-01-02 15:04"),
     }
@@ -56,8 +86,17 @@ func OrderToResponseForSeller(order *entity.Order) *model.OrdersResponseForSelle
         items[i] = model.OrderItemResponse{
             OrderItemUuid: item.OrderItemUUID,
             Quantity:  item.Quantity,
-            Price:     item.Product.Price,
-            ProductName: item.Product.ProductName,
+            ProductUUID: item.ProductUUID,
+            ProductName: item.ProductName,
+            SKU:       item.SKU,
+            VariantAttributes: item.VariantAttributes,
+            ImageURL:  item.ImageURL,
+            Price:     item.UnitPrice,
+            Discount:  item.DiscountAmount,
+            Discounts: DiscountLinesToResponse(item.Discounts),
+            Tax:       item.TaxAmount,
+            TaxBasisPoints: item.TaxBasisPoints,
+            Status:    item.Status,
         }
     }
 
@@ -65,13 +104,21 @@ func OrderToResponseForSeller(order *entity.Order) *model.OrdersResponseForSelle
         UserName:       order.User.Username,
         OrderUUID:  order.OrderUUID,
         TotalPrice: order.TotalPrice,
+        Currency:   order.Currency,
+        DiscountAmount: order.DiscountAmount,
+        VoucherCode: order.VoucherCode,
+        ShippingCost: order.ShippingCost,
+        TaxAmount: order.TaxAmount,
+        TaxInclusive: order.TaxInclusive,
         Status:     order.Status,
         Items:      items,
         Payment: model.PaymentResponse{
             PaymentUUID:  order.Payment.PaymentUUID,
             PaymentMethod: order.Payment.Method,
             Status:        order.Payment.Status,
+            RefundedAmount: order.Payment.RefundedAmount,
         },
+        Warehouse: WarehouseToSummary(order.Warehouse),
         CreatedAt: order.CreatedAt.Format("2006-01-02 15:04:05"),
     }
 }
@@ -81,21 +128,48 @@ func CreateOrderToResponse(payment *eventmodel.PaymentMessage, shipping *eventmo
     for i, item := range order.Items {
         items[i] = model.OrderItemResponse{
             OrderItemUuid: item.OrderItemUUID,
+            ProductUUID: item.ProductUUID,
+            ProductName: item.ProductName,
+            SKU:       item.SKU,
+            VariantAttributes: item.VariantAttributes,
+            ImageURL:  item.ImageURL,
+            Price:     item.UnitPrice,
             Quantity:  item.Quantity,
+            Discount:  item.DiscountAmount,
+            Discounts: DiscountLinesToResponse(item.Discounts),
+            Tax:       item.TaxAmount,
+            TaxBasisPoints: item.TaxBasisPoints,
         }
     }
     return &model.OrderResponse{
         OrderUUID:  order.OrderUUID,
         TotalPrice: order.TotalPrice,
+        Currency:   order.Currency,
+        DisplayCurrency: order.DisplayCurrency,
+        ExchangeRate: order.ExchangeRate,
+        DisplayTotalPrice: order.TotalPrice.Convert(order.ExchangeRate),
+        DiscountAmount: order.DiscountAmount,
+        VoucherCode: order.VoucherCode,
+        ShippingCost: order.ShippingCost,
+        TaxAmount: order.TaxAmount,
+        TaxInclusive: order.TaxInclusive,
         Status:     order.Status,
         Items:      items,
         Shipping: model.ShippingResponse{
             ShippingUUID: shipping.ShippingUUID,
+            RecipientName: shipping.RecipientName,
+            Phone:        shipping.Phone,
             Address:      shipping.Address,
             City:         shipping.City,
+            District:     shipping.District,
             Province:     shipping.Province,
             PostalCode:   shipping.PostalCode,
             Status:       shipping.Status,
+            Provider:     shipping.Provider,
+            Service:      shipping.Service,
+            Cost:         shipping.Cost,
+            Weight:       shipping.Weight,
+            EstimatedDays: shipping.EstimatedDays,
         },
         Payment: model.PaymentResponse{
             PaymentUUID:  payment.PaymentUUID,
@@ -104,4 +178,15 @@ func CreateOrderToResponse(payment *eventmodel.PaymentMessage, shipping *eventmo
         },
         CreatedAt: order.CreatedAt.Format("2006-01-02 15:04:05"),
     }
-}
\ No newline at end of file
+}
+
+func DiscountLinesToResponse(discounts []entity.OrderItemDiscount) []model.DiscountLineResponse {
+    var lines []model.DiscountLineResponse
+    for _, discount := range discounts {
+        lines = append(lines, model.DiscountLineResponse{
+            Label:  discount.Label,
+            Amount: discount.Amount,
+        })
+    }
+    return lines
+}
@@ -0,0 +1,109 @@
+package converter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+// timelineEventTypes maps the order events written before the status history
+// existed to timeline events, together with the actor that triggers them.
+var timelineEventTypes = map[string]struct{ event, actor string }{
+	"order_created":      {"created", "buyer"},
+	"payment_processed":  {"paid", "buyer"},
+	"shipping_processed": {"shipped", "seller"},
+	"order_delivered":    {"delivered", "seller"},
+	"order_cancelled":    {"cancelled", "buyer"},
+}
+
+type timelineEntry struct {
+	event      model.OrderTimelineEvent
+	occurredAt time.Time
+}
+
+// OrderTimelineToResponse merges the status history and the order events of
+// an order into one list of lifecycle events ordered by time. The history is
+// authoritative; an order event only adds a lifecycle event the history does
+// not hold.
+func OrderTimelineToResponse(order *entity.Order, history []entity.OrderStatusHistory, events []evententity.OrderEvent) *model.OrderTimelineResponse {
+	var entries []timelineEntry
+	seen := make(map[string]bool)
+
+	for _, change := range history {
+		event := timelineEvent(change)
+		if event == "" || seen[event] {
+			continue
+		}
+		seen[event] = true
+		entries = append(entries, timelineEntry{
+			event: model.OrderTimelineEvent{
+				Event:  event,
+				Actor:  change.ActorType,
+				Reason: change.Reason,
+			},
+			occurredAt: change.CreatedAt,
+		})
+	}
+	for _, orderEvent := range events {
+		legacy, ok := timelineEventTypes[orderEvent.EventType]
+		if !ok || seen[legacy.event] {
+			continue
+		}
+		seen[legacy.event] = true
+		entries = append(entries, timelineEntry{
+			event: model.OrderTimelineEvent{
+				Event: legacy.event,
+				Actor: legacy.actor,
+			},
+			occurredAt: orderEvent.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].occurredAt.Before(entries[j].occurredAt)
+	})
+	timeline := make([]model.OrderTimelineEvent, len(entries))
+	for i, entry := range entries {
+		timeline[i] = entry.event
+		timeline[i].OccurredAt = entry.occurredAt.Format("2006-01-02 15:04:05")
+	}
+
+	return &model.OrderTimelineResponse{
+		OrderUUID: order.OrderUUID,
+		Status:    order.Status,
+		Events:    timeline,
+	}
+}
+
+// timelineEvent names the lifecycle event of a status change, or returns an
+// empty string for changes that only mirror another one, such as the payment
+// row following the order to "paid". Shipping "delivered" is kept for orders
+// that went straight from shipped to completed before orders had a delivered
+// status.
+func timelineEvent(change entity.OrderStatusHistory) string {
+	switch change.Machine {
+	case "order":
+		switch change.ToStatus {
+		case "pending":
+			if change.FromStatus == "" {
+				return "created"
+			}
+		case "processed":
+			return "paid"
+		case "shipped", "delivered", "disputed", "completed", "cancelled":
+			return change.ToStatus
+		}
+	case "shipping":
+		if change.ToStatus == "delivered" {
+			return "delivered"
+		}
+	case "payment":
+		if change.ToStatus == "refunded" || change.ToStatus == "partially_refunded" {
+			return "refunded"
+		}
+	}
+	return ""
+}
@@ -11,10 +11,20 @@ func ProductToResponse(product *entity.Product) *model.ProductResponse {
 		Store:       product.Store.StoreName,
 		ProductUUID: product.ProductUUID,
 		ProductName: product.ProductName,
+		SKU:         product.SKU,
+		ImageURL:    product.ImageURL,
+		Attributes:  product.Attributes,
 		Description: product.Description,
 		Price:       product.Price,
+		Currency:    product.Currency,
 		Stock:       product.Stock,
+		LowStockThreshold: product.LowStockThreshold,
 		Category:    product.Category,
+		TaxCategory: product.TaxCategory,
+		Weight:      product.Weight,
+		Length:      product.Length,
+		Width:       product.Width,
+		Height:      product.Height,
 		CreatedAt:   product.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:   product.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
@@ -26,8 +36,11 @@ func ProductsToResponse(product *entity.Product) *model.ProductResponse {
 		Store:       product.Store.StoreName,
 		ProductUUID: product.ProductUUID,
 		ProductName: product.ProductName,
+		SKU:         product.SKU,
+		ImageURL:    product.ImageURL,
 		Description: product.Description,
 		Price:       product.Price,
+		Currency:    product.Currency,
 		Stock:       product.Stock,
 		Category:    product.Category,
 	}
@@ -0,0 +1,51 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func PromotionToResponse(promotion *entity.Promotion) *model.PromotionResponse {
+	response := &model.PromotionResponse{
+		PromotionUUID: promotion.PromotionUUID,
+		Name:          promotion.Name,
+		Type:          promotion.Type,
+		Percent:       promotion.Percent,
+		BuyQuantity:   promotion.BuyQuantity,
+		GetQuantity:   promotion.GetQuantity,
+		IsActive:      promotion.IsActive,
+		CreatedAt:     promotion.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for _, tier := range promotion.Tiers {
+		response.Tiers = append(response.Tiers, model.PromotionTierResponse{
+			MinQuantity: tier.MinQuantity,
+			Percent:     tier.Percent,
+		})
+	}
+	for _, product := range promotion.Products {
+		response.ProductUUIDs = append(response.ProductUUIDs, product.ProductUUID)
+	}
+	if promotion.Categories != "" {
+		response.Categories = strings.Split(promotion.Categories, ",")
+	}
+	if promotion.StartsAt != nil {
+		response.StartsAt = promotion.StartsAt.Format("2006-01-02 15:04:05")
+	}
+	if promotion.EndsAt != nil {
+		response.EndsAt = promotion.EndsAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
+
+func AppliedPromotionToResponse(promotion *entity.Promotion) *model.AppliedPromotionResponse {
+	if promotion == nil {
+		return nil
+	}
+	return &model.AppliedPromotionResponse{
+		PromotionUUID: promotion.PromotionUUID,
+		Name:          promotion.Name,
+		Type:          promotion.Type,
+	}
+}
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+func ReturnToResponse(request *entity.ReturnRequest) *model.ReturnResponse {
+	items := make([]model.ReturnItemResponse, len(request.Items))
+	var itemsAmount money.Amount
+	for i, item := range request.Items {
+		items[i] = model.ReturnItemResponse{
+			OrderItemUUID: item.OrderItem.OrderItemUUID,
+			ProductName:   item.OrderItem.ProductName,
+			Quantity:      item.Quantity,
+			Amount:        item.Amount,
+		}
+		itemsAmount = itemsAmount.Add(item.Amount)
+	}
+	photos := make([]string, len(request.Photos))
+	for i, photo := range request.Photos {
+		photos[i] = photo.URL
+	}
+	response := &model.ReturnResponse{
+		ReturnUUID:   request.ReturnUUID,
+		OrderUUID:    request.Order.OrderUUID,
+		Status:       request.Status,
+		Reason:       request.Reason,
+		SellerNote:   request.SellerNote,
+		Items:        items,
+		Photos:       photos,
+		ItemsAmount:  itemsAmount,
+		RefundAmount: request.RefundAmount,
+		Currency:     request.Currency,
+		CreatedAt:    request.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:    request.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if request.RefundedAt != nil {
+		response.RefundedAt = request.RefundedAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
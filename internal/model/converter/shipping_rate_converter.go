@@ -0,0 +1,33 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
+)
+
+func ShippingRateToResponse(rate *entity.ShippingRate) *model.ShippingRateResponse {
+	return &model.ShippingRateResponse{
+		RateUUID:            rate.RateUUID,
+		Service:             rate.Service,
+		OriginProvince:      rate.OriginProvince,
+		DestinationProvince: rate.DestinationProvince,
+		MinWeight:           rate.MinWeight,
+		MaxWeight:           rate.MaxWeight,
+		Cost:                rate.Cost,
+		Currency:            rate.Currency,
+		EstimatedDays:       rate.EstimatedDays,
+		UpdatedAt:           rate.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func ShippingQuoteToResponse(quote *shipping.Quote) *model.ShippingOptionResponse {
+	return &model.ShippingOptionResponse{
+		Option:        quote.Option(),
+		Provider:      quote.Provider,
+		Service:       quote.Service,
+		Cost:          quote.Cost,
+		Currency:      quote.Currency,
+		EstimatedDays: quote.EstimatedDays,
+	}
+}
@@ -9,6 +9,11 @@ func StoreToResponse(store *entity.Store) *model.StoreResponse {
 	return &model.StoreResponse{
 		StoreName:   store.StoreName,
 		Description: store.Description,
+		Currency:    store.Currency,
+		TaxMode:     store.TaxMode,
+		OriginCity:       store.OriginCity,
+		OriginProvince:   store.OriginProvince,
+		OriginPostalCode: store.OriginPostalCode,
 		CreatedAt:   store.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:   store.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func TaxRateToResponse(rate *entity.TaxRate) *model.TaxRateResponse {
+	response := &model.TaxRateResponse{
+		TaxRateUUID:   rate.TaxRateUUID,
+		Category:      rate.Category,
+		Name:          rate.Name,
+		BasisPoints:   rate.BasisPoints,
+		EffectiveFrom: rate.EffectiveFrom.Format("2006-01-02 15:04:05"),
+		CreatedAt:     rate.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if rate.EffectiveTo != nil {
+		response.EffectiveTo = rate.EffectiveTo.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
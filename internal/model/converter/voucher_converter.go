@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func VoucherToResponse(voucher *entity.Voucher) *model.VoucherResponse {
+	response := &model.VoucherResponse{
+		VoucherUUID:  voucher.VoucherUUID,
+		Code:         voucher.Code,
+		Scope:        "platform",
+		Type:         voucher.Type,
+		Percent:      voucher.Percent,
+		Amount:       voucher.Amount,
+		MaxDiscount:  voucher.MaxDiscount,
+		MinSpend:     voucher.MinSpend,
+		Currency:     voucher.Currency,
+		UsageLimit:   voucher.UsageLimit,
+		PerUserLimit: voucher.PerUserLimit,
+		UsedCount:    voucher.UsedCount,
+		IsActive:     voucher.IsActive,
+		CreatedAt:    voucher.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if voucher.StoreID != nil {
+		response.Scope = "store"
+	}
+	for _, product := range voucher.Products {
+		response.ProductUUIDs = append(response.ProductUUIDs, product.ProductUUID)
+	}
+	if voucher.Categories != "" {
+		response.Categories = strings.Split(voucher.Categories, ",")
+	}
+	if voucher.StartsAt != nil {
+		response.StartsAt = voucher.StartsAt.Format("2006-01-02 15:04:05")
+	}
+	if voucher.EndsAt != nil {
+		response.EndsAt = voucher.EndsAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
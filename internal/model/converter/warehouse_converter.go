@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+func WarehouseToResponse(warehouse *entity.Warehouse) *model.WarehouseResponse {
+	return &model.WarehouseResponse{
+		WarehouseUUID: warehouse.WarehouseUUID,
+		Name:          warehouse.Name,
+		Address:       warehouse.Address,
+		City:          warehouse.City,
+		Province:      warehouse.Province,
+		PostalCode:    warehouse.PostalCode,
+		Priority:      warehouse.Priority,
+		IsActive:      warehouse.IsActive,
+		CreatedAt:     warehouse.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     warehouse.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func WarehouseToSummary(warehouse *entity.Warehouse) *model.WarehouseSummary {
+	if warehouse == nil {
+		return nil
+	}
+	return &model.WarehouseSummary{
+		WarehouseUUID: warehouse.WarehouseUUID,
+		Name:          warehouse.Name,
+		Province:      warehouse.Province,
+	}
+}
+
+func ProductStockToResponse(product *entity.Product, stocks []entity.WarehouseStock) *model.ProductStockResponse {
+	response := &model.ProductStockResponse{
+		ProductUUID: product.ProductUUID,
+		TotalStock:  product.Stock,
+		Unallocated: product.Stock,
+		Warehouses:  make([]model.WarehouseStockResponse, len(stocks)),
+	}
+	for i, stock := range stocks {
+		response.Unallocated -= stock.Stock
+		response.Warehouses[i] = model.WarehouseStockResponse{
+			WarehouseUUID: stock.Warehouse.WarehouseUUID,
+			Name:          stock.Warehouse.Name,
+			Stock:         stock.Stock,
+		}
+	}
+	return response
+}
@@ -36,6 +36,7 @@ var (
     ErrPasswordNotMatch   = NewApiError(fiber.StatusBadRequest, "Password and confirm password do not match", nil)
     ErrStoreNotFound      = NewApiError(fiber.StatusNotFound, "Store not found", nil)
     ErrInvalidRole        = NewApiError(fiber.StatusBadRequest, "Invalid role", nil)
+    ErrInsufficientStock  = NewApiError(fiber.StatusConflict, "Insufficient stock", nil)
 )
 
 func ErrValidationFailed(errors interface{}) *ApiError {
@@ -1,9 +1,14 @@
 package eventmodel
 
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
 type OrderMessage struct {
 	OrderID    uint    `json:"order_id"`
 	OrderUUID  string  `json:"order_uuid"`
 	UserID     uint    `json:"user_id"`
 	Status     string  `json:"status"`
-	TotalPrice float64 `json:"total_price"`
+	TotalPrice money.Amount `json:"total_price"`
+	ActorType  string  `json:"actor_type,omitempty"`
+	ActorID    *uint   `json:"actor_id,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
 }
\ No newline at end of file
@@ -1,9 +1,15 @@
 package eventmodel
 
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
 type PaymentMessage struct {
 	PaymentUUID string  `json:"payment_uuid"`
 	OrderID     uint    `json:"order_id"`
 	Status      string  `json:"status"`
-	Amount      float64 `json:"amount"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string  `json:"currency"`
+	DisplayCurrency string `json:"display_currency"`
+	ExchangeRate money.Rate `json:"exchange_rate"`
 	Method      string  `json:"method"`
+	RefundUUID  string  `json:"refund_uuid,omitempty"`
 }
\ No newline at end of file
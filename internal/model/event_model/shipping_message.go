@@ -1,11 +1,21 @@
 package eventmodel
 
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
 type ShippingMessage struct {
 	ShippingUUID string `json:"shipping_uuid"`
 	OrderID      uint   `json:"order_id"`
+	RecipientName string `json:"recipient_name"`
+	Phone        string `json:"phone"`
 	Address      string `json:"address"`
 	City         string `json:"city"`
+	District     string `json:"district"`
 	Province     string `json:"province"`
 	PostalCode   string `json:"postal_code"`
 	Status       string `json:"status"`
+	Provider     string `json:"provider"`
+	Service      string `json:"service"`
+	Cost         money.Amount `json:"cost"`
+	Weight       int    `json:"weight"`
+	EstimatedDays int   `json:"estimated_days"`
 }
\ No newline at end of file
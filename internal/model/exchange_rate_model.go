@@ -0,0 +1,24 @@
+package model
+
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
+type ExchangeRateRequest struct {
+	BaseCurrency  string     `json:"base_currency" validate:"required,iso4217"`
+	QuoteCurrency string     `json:"quote_currency" validate:"required,iso4217,nefield=BaseCurrency"`
+	Rate          money.Rate `json:"rate" validate:"required,gt=0"`
+}
+
+type ImportExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates" validate:"required,min=1,dive"`
+}
+
+type GetExchangeRatesRequest struct {
+	BaseCurrency string `json:"-" validate:"omitempty,iso4217"`
+}
+
+type ExchangeRateResponse struct {
+	BaseCurrency  string     `json:"base_currency"`
+	QuoteCurrency string     `json:"quote_currency"`
+	Rate          money.Rate `json:"rate"`
+	UpdatedAt     string     `json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import "io"
+
+type ExportOrdersRequest struct {
+	UserID   uint   `json:"-"`
+	Format   string `json:"-" validate:"required,oneof=csv xlsx"`
+	Status   string `json:"-" validate:"omitempty,oneof=pending processed shipped delivered disputed completed cancelled"`
+	SortDate string `json:"-" validate:"omitempty,oneof=asc desc"`
+	OrderFilter
+}
+
+type SearchExportRequest struct {
+	UserID uint `json:"-"`
+	Page   int  `json:"-" validate:"min=1"`
+	Limit  int  `json:"-" validate:"min=1,max=100"`
+}
+
+type GetExportRequest struct {
+	UserID     uint   `json:"-"`
+	ExportUUID string `json:"-" validate:"required,uuid"`
+}
+
+type ExportResponse struct {
+	ExportUUID  string `json:"export_uuid"`
+	Format      string `json:"format"`
+	Status      string `json:"status"`
+	RowCount    int    `json:"row_count"`
+	FileName    string `json:"file_name,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+	Error       string `json:"error,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+}
+
+// ExportFile is an export ready to be downloaded. Write writes the file to
+// the response as it is sent, so it is never held in memory as a whole.
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	Write       func(w io.Writer) error
+}
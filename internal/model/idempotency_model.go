@@ -0,0 +1,16 @@
+package model
+
+type IdempotencyRequest struct {
+	UserID      uint   `validate:"required"`
+	Key         string `validate:"required,max=255"`
+	RequestHash string `validate:"required,len=64"`
+	// ClaimToken is set by Begin when the request claims the key.
+	ClaimToken string
+}
+
+// IdempotentResponse is a stored response replayed for a repeated key.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
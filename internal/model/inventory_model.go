@@ -0,0 +1,31 @@
+package model
+
+type AdjustStockRequest struct {
+	UserID        uint   `json:"-" validate:"required"`
+	ProductUUID   string `json:"-" validate:"required,uuid"`
+	Quantity      int    `json:"quantity" validate:"required"`
+	Reason        string `json:"reason" validate:"required,oneof=adjust return import"`
+	ReferenceID   string `json:"reference_id" validate:"omitempty,max=64"`
+	Note          string `json:"note" validate:"omitempty,max=500"`
+	WarehouseUUID string `json:"warehouse_uuid" validate:"omitempty,uuid"`
+}
+
+type GetStockHistoryRequest struct {
+	UserID      uint   `json:"-" validate:"required"`
+	ProductUUID string `json:"-" validate:"required,uuid"`
+	Reason      string `json:"-" validate:"omitempty,oneof=sale cancel adjust return import"`
+	Page        int    `json:"-"`
+	Limit       int    `json:"-"`
+}
+
+type InventoryMovementResponse struct {
+	MovementUUID  string `json:"movement_uuid"`
+	Quantity      int    `json:"quantity"`
+	StockAfter    int    `json:"stock_after"`
+	WarehouseUUID string `json:"warehouse_uuid,omitempty"`
+	Reason        string `json:"reason"`
+	ReferenceType string `json:"reference_type,omitempty"`
+	ReferenceID   string `json:"reference_id,omitempty"`
+	Note          string `json:"note,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
@@ -0,0 +1,77 @@
+package model
+
+type StartThreadRequest struct {
+	UserID      uint                `json:"-"`
+	Role        string              `json:"-"`
+	OrderUUID   string              `json:"order_uuid" validate:"omitempty,uuid"`
+	ProductUUID string              `json:"product_uuid" validate:"omitempty,uuid"`
+	Body        string              `json:"body" validate:"required,max=2000"`
+	Attachments []AttachmentRequest `json:"attachments" validate:"max=5,dive"`
+}
+
+type SendMessageRequest struct {
+	UserID      uint                `json:"-"`
+	Role        string              `json:"-"`
+	ThreadUUID  string              `json:"-" validate:"required,uuid"`
+	Body        string              `json:"body" validate:"required,max=2000"`
+	Attachments []AttachmentRequest `json:"attachments" validate:"max=5,dive"`
+}
+
+type AttachmentRequest struct {
+	URL         string `json:"url" validate:"required,url,max=2048"`
+	FileName    string `json:"file_name" validate:"max=255"`
+	ContentType string `json:"content_type" validate:"max=100"`
+}
+
+type SearchThreadRequest struct {
+	UserID  uint   `json:"-"`
+	Role    string `json:"-"`
+	StoreID uint   `json:"-"`
+	Unread  bool   `json:"-"`
+	Page    int    `json:"-"`
+	Limit   int    `json:"-"`
+}
+
+type GetThreadRequest struct {
+	UserID     uint   `json:"-"`
+	Role       string `json:"-"`
+	ThreadUUID string `json:"-" validate:"required,uuid"`
+	Page       int    `json:"-"`
+	Limit      int    `json:"-"`
+}
+
+type UnreadCountResponse struct {
+	UnreadThreads  int64 `json:"unread_threads"`
+	UnreadMessages int64 `json:"unread_messages"`
+}
+
+type ThreadResponse struct {
+	ThreadUUID    string            `json:"thread_uuid"`
+	Type          string            `json:"type"`
+	OrderUUID     string            `json:"order_uuid,omitempty"`
+	ProductUUID   string            `json:"product_uuid,omitempty"`
+	ProductName   string            `json:"product_name,omitempty"`
+	StoreName     string            `json:"store_name"`
+	BuyerName     string            `json:"buyer_name"`
+	UnreadCount   int64             `json:"unread_count"`
+	LastMessageAt string            `json:"last_message_at"`
+	CreatedAt     string            `json:"created_at"`
+	Messages      []MessageResponse `json:"messages,omitempty"`
+}
+
+type MessageResponse struct {
+	MessageUUID string               `json:"message_uuid"`
+	SenderRole  string               `json:"sender_role"`
+	SenderName  string               `json:"sender_name"`
+	Mine        bool                 `json:"mine"`
+	Body        string               `json:"body"`
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+	Read        bool                 `json:"read"`
+	CreatedAt   string               `json:"created_at"`
+}
+
+type AttachmentResponse struct {
+	URL         string `json:"url"`
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
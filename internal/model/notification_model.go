@@ -0,0 +1,29 @@
+package model
+
+type GetNotificationsRequest struct {
+	UserID uint `json:"-"`
+	Unread bool `json:"-"`
+	Page   int  `json:"-"`
+	Limit  int  `json:"-"`
+}
+
+type ReadNotificationRequest struct {
+	UserID           uint   `json:"-"`
+	NotificationUUID string `json:"-" validate:"required,uuid"`
+}
+
+type NotificationResponse struct {
+	NotificationUUID string `json:"notification_uuid"`
+	Type             string `json:"type"`
+	Title            string `json:"title"`
+	Message          string `json:"message"`
+	ReferenceType    string `json:"reference_type,omitempty"`
+	ReferenceID      string `json:"reference_id,omitempty"`
+	ReadAt           string `json:"read_at,omitempty"`
+	CreatedAt        string `json:"created_at"`
+}
+
+type StockSubscriptionRequest struct {
+	UserID      uint   `json:"-"`
+	ProductUUID string `json:"-" validate:"required,uuid"`
+}
@@ -0,0 +1,15 @@
+package model
+
+type GetOrderDocumentRequest struct {
+	UserID    uint   `json:"-"`
+	OrderUUID string `json:"-" validate:"required,uuid"`
+	Type      string `json:"-" validate:"required,oneof=invoice receipt"`
+}
+
+// OrderDocumentFile is a rendered invoice or receipt, ready to be downloaded.
+type OrderDocumentFile struct {
+	Number   string
+	FileName string
+	Checksum string
+	Content  []byte
+}
@@ -1,10 +1,36 @@
 package model
 
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
 type CreateOrder struct {
 	UserID          uint                   `json:"-"`
 	Items           []OrderItemRequest     `json:"items" validate:"required,dive"`
+	// AddressID names an address of the buyer's address book to ship to,
+	// instead of an inline shipping_address; without either the default
+	// address is used.
+	AddressID       string                 `json:"address_id" validate:"omitempty,uuid"`
 	ShippingAddress ShippingAddressRequest `json:"shipping_address" validate:"required"`
 	Payments        PaymentRequest         `json:"payments" validate:"required"`
+	Currency        string                 `json:"currency" validate:"omitempty,iso4217"`
+	VoucherCode     string                 `json:"voucher_code" validate:"omitempty,alphanum,max=32"`
+	ShippingOption  string                 `json:"shipping_option" validate:"max=101"`
+}
+
+// PlaceOrder is the part of a purchase that belongs to a single store.
+type PlaceOrder struct {
+	UserID          uint
+	StoreID         uint
+	CheckoutID      *uint
+	Items           []OrderItemRequest
+	ShippingAddress ShippingAddressRequest
+	PaymentMethod   string
+	Currency        string
+	VoucherCode     string
+	ShippingOption  string
 }
 
 type OrderItemRequest struct {
@@ -13,8 +39,11 @@ type OrderItemRequest struct {
 }
 
 type ShippingAddressRequest struct {
+	RecipientName string `json:"recipient_name" validate:"max=255"`
+	Phone      string `json:"phone" validate:"omitempty,e164"`
 	Address    string `json:"address" validate:"required"`
 	City       string `json:"city" validate:"required"`
+	District   string `json:"district" validate:"max=255"`
 	Province   string `json:"province" validate:"required"`
 	PostalCode string `json:"postal_code" validate:"required,len=5,numeric"`
 }
@@ -25,46 +54,100 @@ type PaymentRequest struct {
 
 type OrderResponse struct {
 	OrderUUID  string              `json:"order_uuid"`
-	TotalPrice float64             `json:"total_price"`
+	TotalPrice money.Amount             `json:"total_price"`
+	Currency   string              `json:"currency"`
+	DisplayCurrency string         `json:"display_currency"`
+	ExchangeRate money.Rate        `json:"exchange_rate"`
+	DisplayTotalPrice money.Amount `json:"display_total_price"`
+	DiscountAmount money.Amount    `json:"discount_amount,omitempty"`
+	VoucherCode string             `json:"voucher_code,omitempty"`
+	ShippingCost money.Amount      `json:"shipping_cost"`
+	TaxAmount  money.Amount        `json:"tax_amount"`
+	TaxInclusive bool              `json:"tax_inclusive"`
 	Status     string              `json:"status"`
 	Items      []OrderItemResponse `json:"items"`
 	Shipping   ShippingResponse    `json:"shipping"`
 	Payment    PaymentResponse     `json:"payment"`
+	Warehouse  *WarehouseSummary   `json:"warehouse,omitempty"`
 	CreatedAt  string              `json:"created_at"`
 }
 
 type OrderItemResponse struct {
 	OrderItemUuid string  `json:"order_item_uuid"`
+	ProductUUID   string  `json:"product_uuid,omitempty"`
 	ProductName   string  `json:"product_name,omitempty"`
-	Price         float64 `json:"price,omitempty"`
+	SKU           string  `json:"sku,omitempty"`
+	VariantAttributes map[string]string `json:"variant_attributes,omitempty"`
+	ImageURL      string  `json:"image_url,omitempty"`
+	Price         money.Amount `json:"price,omitempty"`
 	Quantity      int     `json:"quantity"`
+	Discount      money.Amount `json:"discount,omitempty"`
+	Discounts     []DiscountLineResponse `json:"discounts,omitempty"`
+	Tax           money.Amount `json:"tax,omitempty"`
+	TaxBasisPoints int         `json:"tax_basis_points,omitempty"`
+	Status        string  `json:"status,omitempty"`
+}
+
+// DiscountLineResponse is a discount of an order item and the promotion or
+// voucher that produced it.
+type DiscountLineResponse struct {
+	Label  string       `json:"label"`
+	Amount money.Amount `json:"amount"`
 }
 
 type ShippingResponse struct {
 	ShippingUUID string `json:"shipping_uuid"`
+	RecipientName string `json:"recipient_name,omitempty"`
+	Phone        string `json:"phone,omitempty"`
 	Address      string `json:"address"`
 	City         string `json:"city"`
+	District     string `json:"district,omitempty"`
 	Province     string `json:"province"`
 	PostalCode   string `json:"postal_code"`
 	Status       string `json:"status"`
+	Provider     string `json:"provider,omitempty"`
+	Service      string `json:"service,omitempty"`
+	Cost         money.Amount `json:"cost"`
+	Weight       int    `json:"weight,omitempty"`
+	EstimatedDays int   `json:"estimated_days,omitempty"`
 }
 
 type PaymentResponse struct {
 	PaymentUUID   string `json:"payment_uuid"`
 	PaymentMethod string `json:"payment_method"`
 	Status        string `json:"status"`
+	RefundedAmount money.Amount `json:"refunded_amount,omitempty"`
 }
 
 type SearchOrderRequest struct {
 	UserID uint   `json:"-"`
-	Status string `json:"-" validate:"omitempty,oneof=pending processed shipped completed cancelled"`
+	Status string `json:"-" validate:"omitempty,oneof=pending processed shipped delivered disputed completed cancelled"`
+	OrderFilter
 	Page   int    `json:"-"`
 	Limit  int    `json:"-"`
 }
 
+// OrderFilter holds the filters shared by the buyer and seller order lists.
+// Dates are calendar days in server time and both ends are included. Orders
+// of a store can be in different currencies, so totals are compared with the
+// total of the orders in Currency only. Search matches the start of the order
+// UUID, the buyer's name or the name of an ordered product.
+type OrderFilter struct {
+	StartDate     string        `json:"-" validate:"omitempty,datetime=2006-01-02"`
+	EndDate       string        `json:"-" validate:"omitempty,datetime=2006-01-02"`
+	Search        string        `json:"-" validate:"max=100"`
+	MinTotal      *money.Amount `json:"-"`
+	MaxTotal      *money.Amount `json:"-"`
+	Currency      string        `json:"-" validate:"omitempty,iso4217"`
+	PaymentMethod string        `json:"-" validate:"omitempty,oneof=cash transfer"`
+}
+
 type ListOrderResponse struct {
 	OrderUUID  string  `json:"order_uuid"`
-	TotalPrice float64 `json:"total_price"`
+	TotalPrice money.Amount `json:"total_price"`
+	Currency   string  `json:"currency"`
+	DisplayCurrency string `json:"display_currency"`
+	DisplayTotalPrice money.Amount `json:"display_total_price"`
 	Status     string  `json:"status"`
 	Date       string  `json:"date"`
 }
@@ -75,21 +158,42 @@ type GetOrderDetails struct {
 }
 
 type SearchOrderRequestBySeller struct {
-	Status   string `json:"-" validate:"omitempty,oneof=pending processed shipped completed cancelled"`
+	Status   string `json:"-" validate:"omitempty,oneof=pending processed shipped delivered disputed completed cancelled"`
 	SortDate string `json:"-" validate:"omitempty,oneof=asc desc"`
+	OrderFilter
 	UserID   uint   `json:"-"`
 	StoreID  uint   `json:"-"`
 	Page     int    `json:"-"`
 	Limit    int    `json:"-"`
+	// CreatedBefore limits the list to orders placed at or before it; exports
+	// set it so their batches stay stable.
+	CreatedBefore *time.Time `json:"-"`
+	// After continues the list behind an order instead of paging by offset,
+	// so orders leaving or joining the filter meanwhile cannot shift it.
+	After *OrderCursor `json:"-"`
+}
+
+// OrderCursor is the position of an order in a list sorted by creation time
+// and ID.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        uint
 }
 
 type OrdersResponseForSeller struct {
 	UserName   string              `json:"user_name"`
 	OrderUUID  string              `json:"order_uuid"`
-	TotalPrice float64             `json:"total_price"`
+	TotalPrice money.Amount             `json:"total_price"`
+	Currency   string              `json:"currency"`
+	DiscountAmount money.Amount    `json:"discount_amount,omitempty"`
+	VoucherCode string             `json:"voucher_code,omitempty"`
+	ShippingCost money.Amount      `json:"shipping_cost"`
+	TaxAmount  money.Amount        `json:"tax_amount"`
+	TaxInclusive bool              `json:"tax_inclusive"`
 	Status     string              `json:"status"`
 	Items      []OrderItemResponse `json:"items"`
 	Payment    PaymentResponse     `json:"payment"`
+	Warehouse  *WarehouseSummary   `json:"warehouse,omitempty"`
 	CreatedAt  string              `json:"created_at"`
 }
 
@@ -98,6 +202,24 @@ type CancelOrderRequest struct {
 	UserID    uint   `json:"-"`
 }
 
+type CancelOrderItemRequest struct {
+	UserID        uint   `json:"-"`
+	OrderUUID     string `json:"-" validate:"required,uuid"`
+	OrderItemUUID string `json:"-" validate:"required,uuid"`
+	Reason        string `json:"reason" validate:"max=200"`
+}
+
+type ConfirmOrderRequest struct {
+	OrderUUID string `json:"-" validate:"required,uuid"`
+	UserID    uint   `json:"-"`
+}
+
+type ReportOrderProblemRequest struct {
+	OrderUUID string `json:"-" validate:"required,uuid"`
+	UserID    uint   `json:"-"`
+	Reason    string `json:"reason" validate:"required,max=200"`
+}
+
 type CheckoutOrderRequest struct {
 	OrderUUID string `json:"-" validate:"required,uuid"`
 	UserID    uint   `json:"-"`
@@ -107,4 +229,50 @@ type UpdateShippingStatusRequest struct {
 	OrderUUID string `json:"-" validate:"required,uuid"`
 	UserID    uint   `json:"-"`
 	Status    string `json:"status" validate:"required,oneof=shipped delivered"`
-}
\ No newline at end of file
+}
+
+type CreateCheckout struct {
+	UserID          uint                   `json:"-"`
+	Items           []OrderItemRequest     `json:"items" validate:"required,min=1,dive"`
+	AddressID       string                 `json:"address_id" validate:"omitempty,uuid"`
+	ShippingAddress ShippingAddressRequest `json:"shipping_address" validate:"required"`
+	Payments        PaymentRequest         `json:"payments" validate:"required"`
+	Currency        string                 `json:"currency" validate:"omitempty,iso4217"`
+	VoucherCode     string                 `json:"voucher_code" validate:"omitempty,alphanum,max=32"`
+	ShippingOption  string                 `json:"shipping_option" validate:"max=101"`
+}
+
+type GetCheckoutRequest struct {
+	UserID       uint   `json:"-"`
+	CheckoutUUID string `json:"-" validate:"required,uuid"`
+}
+
+type PayCheckoutRequest struct {
+	UserID       uint   `json:"-"`
+	CheckoutUUID string `json:"-" validate:"required,uuid"`
+}
+
+type CheckoutResponse struct {
+	CheckoutUUID  string          `json:"checkout_uuid"`
+	Status        string          `json:"status"`
+	PaymentMethod string          `json:"payment_method"`
+	Currency      string          `json:"currency"`
+	TotalAmount   money.Amount    `json:"total_amount"`
+	PaidAmount    money.Amount    `json:"paid_amount"`
+	PaidAt        string          `json:"paid_at,omitempty"`
+	Orders        []OrderResponse `json:"orders"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+type OrderTimelineResponse struct {
+	OrderUUID string               `json:"order_uuid"`
+	Status    string               `json:"status"`
+	Events    []OrderTimelineEvent `json:"events"`
+}
+
+type OrderTimelineEvent struct {
+	Event      string `json:"event"`
+	Actor      string `json:"actor"`
+	Reason     string `json:"reason,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
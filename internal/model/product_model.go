@@ -1,12 +1,24 @@
 package model
 
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
 type RegisterProduct struct {
 	AuthID      uint    `json:"-"`
 	ProductName string  `json:"product_name" validate:"required,min=3,max=255"`
+	SKU         string  `json:"sku" validate:"max=64"`
+	ImageURL    string  `json:"image_url" validate:"omitempty,url,max=2048"`
+	Attributes  map[string]string `json:"attributes" validate:"max=20,dive,keys,min=1,max=50,endkeys,max=255"`
 	Description string  `json:"description" validate:"required,min=10"`
-	Price       float64 `json:"price" validate:"required,gt=0"`
+	Price       money.Amount `json:"price" validate:"required,gt=0"`
+	Currency    string  `json:"currency" validate:"omitempty,iso4217"`
 	Stock       int     `json:"stock" validate:"required,gte=0"`
 	Category    string  `json:"category" validate:"required,oneof=clothes electronics accessories"`
+	LowStockThreshold int `json:"low_stock_threshold" validate:"omitempty,gte=0"`
+	TaxCategory string  `json:"tax_category" validate:"omitempty,max=50"`
+	Weight      int     `json:"weight" validate:"gte=0"`
+	Length      int     `json:"length" validate:"gte=0"`
+	Width       int     `json:"width" validate:"gte=0"`
+	Height      int     `json:"height" validate:"gte=0"`
 }
 
 type ProductResponse struct {
@@ -14,10 +26,22 @@ type ProductResponse struct {
 	StoreID     uint    `json:"store_id,omitempty"`
 	Store       string  `json:"store,omitempty"`
 	ProductName string  `json:"product_name"`
+	SKU         string  `json:"sku,omitempty"`
+	ImageURL    string  `json:"image_url,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Price       money.Amount `json:"price"`
+	Currency    string  `json:"currency"`
+	DisplayPrice *money.Amount `json:"display_price,omitempty"`
+	DisplayCurrency string `json:"display_currency,omitempty"`
 	Stock       int     `json:"stock"`
+	LowStockThreshold int `json:"low_stock_threshold,omitempty"`
 	Category    string  `json:"category"`
+	TaxCategory string  `json:"tax_category,omitempty"`
+	Weight      int     `json:"weight,omitempty"`
+	Length      int     `json:"length,omitempty"`
+	Width       int     `json:"width,omitempty"`
+	Height      int     `json:"height,omitempty"`
 	CreatedAt   string  `json:"created_at,omitempty"`
 	UpdatedAt   string  `json:"updated_at,omitempty"`
 }
@@ -27,8 +51,12 @@ type GetProductsRequest struct {
 	UserID   uint    `json:"-"`
 	Search   string  `json:"-"`
 	Category string  `json:"-" validate:"omitempty,oneof=clothes electronics accessories"`
-	PriceMin float64 `json:"-" validate:"omitempty,gt=0"`
-	PriceMax float64 `json:"-" validate:"omitempty,gt=0"`
+	PriceMin money.Amount `json:"-" validate:"omitempty,gt=0"`
+	PriceMax money.Amount `json:"-" validate:"omitempty,gt=0"`
+	// PriceCurrency is the currency of PriceMin and PriceMax, only products
+	// priced in it are compared.
+	PriceCurrency string `json:"-" validate:"omitempty,iso4217"`
+	Currency string  `json:"-" validate:"omitempty,iso4217"`
 	Page     int     `json:"-"`
 	Limit    int     `json:"-"`
 }
@@ -42,10 +70,22 @@ type UpdateProduct struct {
 	UserID      uint    `json:"-" validate:"required"`
 	ProductUUID string  `json:"-" validate:"required,uuid"`
 	ProductName string  `json:"product_name" validate:"omitempty,min=3,max=255"`
+	SKU         *string `json:"sku" validate:"omitempty,max=64"`
+	ImageURL    *string `json:"image_url" validate:"omitempty,max=2048,len=0|url"`
+	// Attributes replace the attributes of the product when given, an empty
+	// object removes them.
+	Attributes  map[string]string `json:"attributes" validate:"max=20,dive,keys,min=1,max=50,endkeys,max=255"`
 	Description string  `json:"description" validate:"omitempty,min=10"`
-	Price       float64 `json:"price" validate:"omitempty,gt=0"`
+	Price       money.Amount `json:"price" validate:"omitempty,gt=0"`
+	Currency    string  `json:"currency" validate:"omitempty,iso4217"`
 	Stock       int     `json:"stock" validate:"omitempty,gte=0"`
 	Category    string  `json:"category" validate:"omitempty,oneof=clothes electronics accessories"`
+	LowStockThreshold *int `json:"low_stock_threshold" validate:"omitempty,gte=0"`
+	TaxCategory string  `json:"tax_category" validate:"omitempty,max=50"`
+	Weight      *int    `json:"weight" validate:"omitempty,gte=0"`
+	Length      *int    `json:"length" validate:"omitempty,gte=0"`
+	Width       *int    `json:"width" validate:"omitempty,gte=0"`
+	Height      *int    `json:"height" validate:"omitempty,gte=0"`
 }
 
 type DeleteProductRequest struct {
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+type CreatePromotionRequest struct {
+	UserID       uint                   `json:"-"`
+	Name         string                 `json:"name" validate:"required,max=100"`
+	Type         string                 `json:"type" validate:"required,oneof=percentage buy_x_get_y tiered bundle"`
+	Percent      int                    `json:"percent" validate:"omitempty,min=1,max=100"`
+	BuyQuantity  int                    `json:"buy_quantity" validate:"omitempty,gte=1"`
+	GetQuantity  int                    `json:"get_quantity" validate:"omitempty,gte=1"`
+	Tiers        []PromotionTierRequest `json:"tiers" validate:"max=10,dive"`
+	ProductUUIDs []string               `json:"product_uuids" validate:"max=100,dive,uuid"`
+	Categories   []string               `json:"categories" validate:"dive,oneof=clothes electronics accessories"`
+	StartsAt     *time.Time             `json:"starts_at"`
+	EndsAt       *time.Time             `json:"ends_at"`
+}
+
+type PromotionTierRequest struct {
+	MinQuantity int `json:"min_quantity" validate:"required,gte=2"`
+	Percent     int `json:"percent" validate:"required,min=1,max=100"`
+}
+
+type UpdatePromotionRequest struct {
+	UserID        uint       `json:"-"`
+	PromotionUUID string     `json:"-" validate:"required,uuid"`
+	Name          string     `json:"name" validate:"omitempty,max=100"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	IsActive      *bool      `json:"is_active"`
+}
+
+type SearchPromotionRequest struct {
+	UserID uint `json:"-"`
+	Page   int  `json:"-"`
+	Limit  int  `json:"-"`
+}
+
+type GetPromotionRequest struct {
+	UserID        uint   `json:"-"`
+	PromotionUUID string `json:"-" validate:"required,uuid"`
+}
+
+type PromotionResponse struct {
+	PromotionUUID string                  `json:"promotion_uuid"`
+	Name          string                  `json:"name"`
+	Type          string                  `json:"type"`
+	Percent       int                     `json:"percent,omitempty"`
+	BuyQuantity   int                     `json:"buy_quantity,omitempty"`
+	GetQuantity   int                     `json:"get_quantity,omitempty"`
+	Tiers         []PromotionTierResponse `json:"tiers,omitempty"`
+	ProductUUIDs  []string                `json:"product_uuids,omitempty"`
+	Categories    []string                `json:"categories,omitempty"`
+	StartsAt      string                  `json:"starts_at,omitempty"`
+	EndsAt        string                  `json:"ends_at,omitempty"`
+	IsActive      bool                    `json:"is_active"`
+	CreatedAt     string                  `json:"created_at"`
+}
+
+type PromotionTierResponse struct {
+	MinQuantity int `json:"min_quantity"`
+	Percent     int `json:"percent"`
+}
+
+// AppliedPromotionResponse names the promotion that produced a discount.
+type AppliedPromotionResponse struct {
+	PromotionUUID string `json:"promotion_uuid"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+}
+
+type PreviewOrderRequest struct {
+	UserID uint               `json:"-"`
+	Items  []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PreviewOrderResponse struct {
+	Stores []PreviewStoreResponse `json:"stores"`
+}
+
+type PreviewStoreResponse struct {
+	StoreName string                `json:"store_name"`
+	Currency  string                `json:"currency"`
+	Subtotal  money.Amount          `json:"subtotal"`
+	Discount  money.Amount          `json:"discount"`
+	Total     money.Amount          `json:"total"`
+	Items     []PreviewItemResponse `json:"items"`
+}
+
+type PreviewItemResponse struct {
+	ProductUUID string                    `json:"product_uuid"`
+	ProductName string                    `json:"product_name"`
+	Quantity    int                       `json:"quantity"`
+	UnitPrice   money.Amount              `json:"unit_price"`
+	Subtotal    money.Amount              `json:"subtotal"`
+	Discount    money.Amount              `json:"discount"`
+	Total       money.Amount              `json:"total"`
+	Promotion   *AppliedPromotionResponse `json:"promotion,omitempty"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
+type CreateReturnRequest struct {
+	UserID    uint                `json:"-"`
+	OrderUUID string              `json:"-" validate:"required,uuid"`
+	Reason    string              `json:"reason" validate:"required,max=1000"`
+	Photos    []string            `json:"photos" validate:"max=5,dive,required,url,max=2048"`
+	Items     []ReturnItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type ReturnItemRequest struct {
+	OrderItemUUID string `json:"order_item_uuid" validate:"required,uuid"`
+	Quantity      int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type SearchReturnRequest struct {
+	UserID  uint   `json:"-"`
+	StoreID uint   `json:"-"`
+	Status  string `json:"-" validate:"omitempty,oneof=requested approved rejected inspected refunded"`
+	Page    int    `json:"-"`
+	Limit   int    `json:"-"`
+}
+
+type GetReturnRequest struct {
+	UserID     uint   `json:"-"`
+	ReturnUUID string `json:"-" validate:"required,uuid"`
+}
+
+type ApproveReturnRequest struct {
+	UserID     uint   `json:"-"`
+	ReturnUUID string `json:"-" validate:"required,uuid"`
+	Note       string `json:"note" validate:"max=1000"`
+}
+
+type RejectReturnRequest struct {
+	UserID     uint   `json:"-"`
+	ReturnUUID string `json:"-" validate:"required,uuid"`
+	Note       string `json:"note" validate:"required,max=1000"`
+}
+
+type InspectReturnRequest struct {
+	UserID       uint          `json:"-"`
+	ReturnUUID   string        `json:"-" validate:"required,uuid"`
+	RefundAmount *money.Amount `json:"refund_amount"`
+	Note         string        `json:"note" validate:"max=1000"`
+}
+
+type ReturnResponse struct {
+	ReturnUUID   string               `json:"return_uuid"`
+	OrderUUID    string               `json:"order_uuid"`
+	Status       string               `json:"status"`
+	Reason       string               `json:"reason"`
+	SellerNote   string               `json:"seller_note,omitempty"`
+	Items        []ReturnItemResponse `json:"items"`
+	Photos       []string             `json:"photos"`
+	ItemsAmount  money.Amount         `json:"items_amount"`
+	RefundAmount money.Amount         `json:"refund_amount"`
+	Currency     string               `json:"currency"`
+	RefundedAt   string               `json:"refunded_at,omitempty"`
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
+}
+
+type ReturnItemResponse struct {
+	OrderItemUUID string       `json:"order_item_uuid"`
+	ProductName   string       `json:"product_name"`
+	Quantity      int          `json:"quantity"`
+	Amount        money.Amount `json:"amount"`
+}
+
+// IssueRefund describes a refund on the payment of an order. ReferenceType
+// and ReferenceID name what caused it, e.g. "return" and the return UUID.
+type IssueRefund struct {
+	Amount        money.Amount
+	Reason        string
+	ReferenceType string
+	ReferenceID   string
+}
@@ -0,0 +1,79 @@
+package model
+
+import "github.com/abdisetiakawan/go-ecommerce/internal/money"
+
+// ShippingRateRequest is a row of the shipping rate table. Weights are in
+// grams; an empty province matches every province and a max_weight of 0 has
+// no upper bound.
+type ShippingRateRequest struct {
+	Service             string       `json:"service" validate:"required,max=50,excludesall=:"`
+	OriginProvince      string       `json:"origin_province" validate:"max=255"`
+	DestinationProvince string       `json:"destination_province" validate:"max=255"`
+	MinWeight           int          `json:"min_weight" validate:"gte=0"`
+	MaxWeight           int          `json:"max_weight" validate:"omitempty,gtfield=MinWeight"`
+	Cost                money.Amount `json:"cost" validate:"gte=0"`
+	Currency            string       `json:"currency" validate:"omitempty,iso4217"`
+	EstimatedDays       int          `json:"estimated_days" validate:"gte=0"`
+}
+
+type CreateShippingRatesRequest struct {
+	Rates []ShippingRateRequest `json:"rates" validate:"required,min=1,max=500,dive"`
+}
+
+type UpdateShippingRateRequest struct {
+	RateUUID string `json:"-" validate:"required,uuid"`
+	ShippingRateRequest
+}
+
+type DeleteShippingRateRequest struct {
+	RateUUID string `json:"-" validate:"required,uuid"`
+}
+
+type SearchShippingRateRequest struct {
+	Service             string `json:"-"`
+	OriginProvince      string `json:"-"`
+	DestinationProvince string `json:"-"`
+	Page                int    `json:"-"`
+	Limit               int    `json:"-"`
+}
+
+type ShippingRateResponse struct {
+	RateUUID            string       `json:"rate_uuid"`
+	Service             string       `json:"service"`
+	OriginProvince      string       `json:"origin_province"`
+	DestinationProvince string       `json:"destination_province"`
+	MinWeight           int          `json:"min_weight"`
+	MaxWeight           int          `json:"max_weight"`
+	Cost                money.Amount `json:"cost"`
+	Currency            string       `json:"currency"`
+	EstimatedDays       int          `json:"estimated_days"`
+	UpdatedAt           string       `json:"updated_at"`
+}
+
+type ShippingOptionsRequest struct {
+	UserID          uint                   `json:"-"`
+	Items           []OrderItemRequest     `json:"items" validate:"required,min=1,dive"`
+	AddressID       string                 `json:"address_id" validate:"omitempty,uuid"`
+	ShippingAddress ShippingAddressRequest `json:"shipping_address" validate:"required"`
+}
+
+type ShippingOptionsResponse struct {
+	Stores []StoreShippingOptions `json:"stores"`
+}
+
+// StoreShippingOptions are the options an order of the store can ship with,
+// cheapest first. Weight is the billable weight of the parcel in grams.
+type StoreShippingOptions struct {
+	StoreName string                   `json:"store_name"`
+	Weight    int                      `json:"weight"`
+	Options   []ShippingOptionResponse `json:"options"`
+}
+
+type ShippingOptionResponse struct {
+	Option        string       `json:"option"`
+	Provider      string       `json:"provider"`
+	Service       string       `json:"service"`
+	Cost          money.Amount `json:"cost"`
+	Currency      string       `json:"currency"`
+	EstimatedDays int          `json:"estimated_days,omitempty"`
+}
@@ -4,11 +4,21 @@ type RegisterStore struct {
 	ID          uint   `json:"-"`
 	StoreName   string `json:"store_name" validate:"required"`
 	Description string `json:"description" validate:"required"`
+	Currency    string `json:"currency" validate:"omitempty,iso4217"`
+	TaxMode     string `json:"tax_mode" validate:"omitempty,oneof=exclusive inclusive"`
+	OriginCity       string `json:"origin_city" validate:"max=255"`
+	OriginProvince   string `json:"origin_province" validate:"max=255"`
+	OriginPostalCode string `json:"origin_postal_code" validate:"omitempty,len=5,numeric"`
 }
 
 type StoreResponse struct {
 	StoreName   string `json:"store_name"`
 	Description string `json:"description"`
+	Currency    string `json:"currency"`
+	TaxMode     string `json:"tax_mode"`
+	OriginCity       string `json:"origin_city,omitempty"`
+	OriginProvince   string `json:"origin_province,omitempty"`
+	OriginPostalCode string `json:"origin_postal_code,omitempty"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
 }
@@ -17,4 +27,9 @@ type UpdateStore struct {
 	ID          uint   `json:"-"`
 	StoreName   string `json:"store_name"`
 	Description string `json:"description"`
+	Currency    string `json:"currency" validate:"omitempty,iso4217"`
+	TaxMode     string `json:"tax_mode" validate:"omitempty,oneof=exclusive inclusive"`
+	OriginCity       string `json:"origin_city" validate:"max=255"`
+	OriginProvince   string `json:"origin_province" validate:"max=255"`
+	OriginPostalCode string `json:"origin_postal_code" validate:"omitempty,len=5,numeric"`
 }
\ No newline at end of file
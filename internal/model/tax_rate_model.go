@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// CreateTaxRateRequest adds a rate for a tax category. Rates are in basis
+// points: 1100 is 11%. Without effective_from the rate applies at once.
+type CreateTaxRateRequest struct {
+	Category      string     `json:"category" validate:"required,max=50"`
+	Name          string     `json:"name" validate:"required,max=100"`
+	BasisPoints   int        `json:"basis_points" validate:"gte=0,lte=10000"`
+	EffectiveFrom *time.Time `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+}
+
+// UpdateTaxRateRequest changes a tax rate. Once a rate is in effect only its
+// name and effective_to may change.
+type UpdateTaxRateRequest struct {
+	TaxRateUUID   string     `json:"-" validate:"required,uuid"`
+	Name          *string    `json:"name" validate:"omitempty,min=1,max=100"`
+	BasisPoints   *int       `json:"basis_points" validate:"omitempty,gte=0,lte=10000"`
+	EffectiveFrom *time.Time `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+}
+
+type SearchTaxRateRequest struct {
+	Category string `json:"-"`
+	Page     int    `json:"-"`
+	Limit    int    `json:"-"`
+}
+
+type TaxRateResponse struct {
+	TaxRateUUID   string `json:"tax_rate_uuid"`
+	Category      string `json:"category"`
+	Name          string `json:"name"`
+	BasisPoints   int    `json:"basis_points"`
+	EffectiveFrom string `json:"effective_from"`
+	EffectiveTo   string `json:"effective_to,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
@@ -12,7 +12,8 @@ type RegisterUser struct {
 type LoginUser struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
-	Role     string `json:"role" validate:"required,oneof=seller buyer"`
+	Role     string `json:"role" validate:"required,oneof=seller buyer admin"`
+	CartToken string `json:"-"`
 }
 
 type AuthResponse struct {
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+type CreateVoucherRequest struct {
+	UserID       uint          `json:"-"`
+	Role         string        `json:"-"`
+	Code         string        `json:"code" validate:"required,alphanum,min=3,max=32"`
+	Type         string        `json:"type" validate:"required,oneof=percentage fixed"`
+	Percent      int           `json:"percent" validate:"omitempty,min=1,max=100"`
+	Amount       money.Amount  `json:"amount"`
+	MaxDiscount  *money.Amount `json:"max_discount"`
+	MinSpend     money.Amount  `json:"min_spend"`
+	Currency     string        `json:"currency" validate:"omitempty,iso4217"`
+	ProductUUIDs []string      `json:"product_uuids" validate:"max=100,dive,uuid"`
+	Categories   []string      `json:"categories" validate:"dive,oneof=clothes electronics accessories"`
+	UsageLimit   int           `json:"usage_limit" validate:"gte=0"`
+	PerUserLimit int           `json:"per_user_limit" validate:"gte=0"`
+	StartsAt     *time.Time    `json:"starts_at"`
+	EndsAt       *time.Time    `json:"ends_at"`
+}
+
+type UpdateVoucherRequest struct {
+	UserID       uint          `json:"-"`
+	Role         string        `json:"-"`
+	VoucherUUID  string        `json:"-" validate:"required,uuid"`
+	MinSpend     *money.Amount `json:"min_spend"`
+	UsageLimit   *int          `json:"usage_limit" validate:"omitempty,gte=0"`
+	PerUserLimit *int          `json:"per_user_limit" validate:"omitempty,gte=0"`
+	StartsAt     *time.Time    `json:"starts_at"`
+	EndsAt       *time.Time    `json:"ends_at"`
+	IsActive     *bool         `json:"is_active"`
+}
+
+type SearchVoucherRequest struct {
+	UserID uint   `json:"-"`
+	Role   string `json:"-"`
+	Page   int    `json:"-"`
+	Limit  int    `json:"-"`
+}
+
+type GetVoucherRequest struct {
+	UserID      uint   `json:"-"`
+	Role        string `json:"-"`
+	VoucherUUID string `json:"-" validate:"required,uuid"`
+}
+
+type VoucherResponse struct {
+	VoucherUUID  string        `json:"voucher_uuid"`
+	Code         string        `json:"code"`
+	Scope        string        `json:"scope"`
+	Type         string        `json:"type"`
+	Percent      int           `json:"percent,omitempty"`
+	Amount       money.Amount  `json:"amount,omitempty"`
+	MaxDiscount  *money.Amount `json:"max_discount,omitempty"`
+	MinSpend     money.Amount  `json:"min_spend"`
+	Currency     string        `json:"currency"`
+	ProductUUIDs []string      `json:"product_uuids,omitempty"`
+	Categories   []string      `json:"categories,omitempty"`
+	UsageLimit   int           `json:"usage_limit"`
+	PerUserLimit int           `json:"per_user_limit"`
+	UsedCount    int           `json:"used_count"`
+	StartsAt     string        `json:"starts_at,omitempty"`
+	EndsAt       string        `json:"ends_at,omitempty"`
+	IsActive     bool          `json:"is_active"`
+	CreatedAt    string        `json:"created_at"`
+}
@@ -0,0 +1,55 @@
+package model
+
+type RegisterWarehouse struct {
+	UserID     uint   `json:"-"`
+	Name       string `json:"name" validate:"required,max=255"`
+	Address    string `json:"address" validate:"required,max=255"`
+	City       string `json:"city" validate:"required,max=255"`
+	Province   string `json:"province" validate:"required,max=255"`
+	PostalCode string `json:"postal_code" validate:"required,len=5,numeric"`
+	Priority   int    `json:"priority" validate:"omitempty,gte=0"`
+}
+
+type UpdateWarehouse struct {
+	UserID        uint   `json:"-"`
+	WarehouseUUID string `json:"-" validate:"required,uuid"`
+	Name          string `json:"name" validate:"omitempty,max=255"`
+	Address       string `json:"address" validate:"omitempty,max=255"`
+	City          string `json:"city" validate:"omitempty,max=255"`
+	Province      string `json:"province" validate:"omitempty,max=255"`
+	PostalCode    string `json:"postal_code" validate:"omitempty,len=5,numeric"`
+	Priority      *int   `json:"priority" validate:"omitempty,gte=0"`
+	IsActive      *bool  `json:"is_active"`
+}
+
+type WarehouseResponse struct {
+	WarehouseUUID string `json:"warehouse_uuid"`
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+	Priority      int    `json:"priority"`
+	IsActive      bool   `json:"is_active"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+type WarehouseSummary struct {
+	WarehouseUUID string `json:"warehouse_uuid"`
+	Name          string `json:"name"`
+	Province      string `json:"province"`
+}
+
+type WarehouseStockResponse struct {
+	WarehouseUUID string `json:"warehouse_uuid"`
+	Name          string `json:"name"`
+	Stock         int    `json:"stock"`
+}
+
+type ProductStockResponse struct {
+	ProductUUID string                   `json:"product_uuid"`
+	TotalStock  int                      `json:"total_stock"`
+	Unallocated int                      `json:"unallocated"`
+	Warehouses  []WarehouseStockResponse `json:"warehouses"`
+}
@@ -0,0 +1,296 @@
+// Package money provides an exact decimal amount used for prices, totals and
+// payments.
+//
+// An Amount is an integer number of minor units (cents), so adding and
+// multiplying by quantities never introduces floating-point error. Amounts are
+// stored in DECIMAL(20,2) columns and encoded in JSON as plain decimal numbers
+// with two fraction digits, e.g. 1250.50.
+//
+// Rounding rule: whenever a value has more precision than a cent (parsing
+// input with more than two fraction digits, or applying a ratio such as a
+// percentage), it is rounded half away from zero: 0.005 becomes 0.01 and
+// -0.005 becomes -0.01.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the ISO 4217 code assumed for stores, products and orders
+// that do not name a currency.
+const DefaultCurrency = "IDR"
+
+// Scale is the number of fraction digits carried by an Amount.
+const Scale = 2
+
+const minorPerMajor = 100
+
+// maxIntegerDigits keeps parsed values inside both int64 minor units and the
+// DECIMAL(20,2) column type.
+const maxIntegerDigits = 16
+
+var (
+	ErrInvalidAmount  = errors.New("money: invalid amount")
+	ErrAmountTooLarge = errors.New("money: amount out of range")
+)
+
+// Amount is a monetary value in minor units.
+type Amount int64
+
+// FromMinor returns the amount for a number of minor units.
+func FromMinor(minor int64) Amount {
+	return Amount(minor)
+}
+
+// Minor returns the amount as a number of minor units.
+func (a Amount) Minor() int64 {
+	return int64(a)
+}
+
+// Add returns a + b.
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+// Sub returns a - b.
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+// Mul returns the amount multiplied by a whole quantity. The result is exact.
+func (a Amount) Mul(quantity int) Amount {
+	return a * Amount(quantity)
+}
+
+// MulRatio returns a * num / den rounded half away from zero to the nearest
+// minor unit. It panics if den is zero.
+func (a Amount) MulRatio(num, den int64) Amount {
+	product := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(num))
+	return Amount(divRound(product, big.NewInt(den)))
+}
+
+// Allocate splits an amount over weights in proportion to them using the
+// largest remainder method: every share is rounded toward zero, and the minor
+// units left over go one each to the shares with the largest dropped
+// fractions, earlier shares first on ties. The shares add up to the amount
+// exactly, and when the amount is at most the sum of the weights no share
+// exceeds its weight. Weights must be non-negative; when they are all zero
+// the amount is split evenly. No weights give no shares.
+func (a Amount) Allocate(weights []Amount) []Amount {
+	if len(weights) == 0 {
+		return nil
+	}
+	if a < 0 {
+		shares := (-a).Allocate(weights)
+		for i := range shares {
+			shares[i] = -shares[i]
+		}
+		return shares
+	}
+	total := new(big.Int)
+	for _, weight := range weights {
+		total.Add(total, big.NewInt(int64(weight)))
+	}
+	if total.Sign() == 0 {
+		even := make([]Amount, len(weights))
+		for i := range even {
+			even[i] = 1
+		}
+		return a.Allocate(even)
+	}
+	shares := make([]Amount, len(weights))
+	fractions := make([]*big.Int, len(weights))
+	left := a
+	for i, weight := range weights {
+		product := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(weight)))
+		quotient, fraction := new(big.Int).QuoRem(product, total, new(big.Int))
+		shares[i] = Amount(quotient.Int64())
+		fractions[i] = fraction
+		left -= shares[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fractions[order[i]].Cmp(fractions[order[j]]) > 0
+	})
+	for _, i := range order[:left] {
+		shares[i]++
+	}
+	return shares
+}
+
+// Convert returns the amount expressed in another currency using rate, the
+// number of target units per source unit.
+func (a Amount) Convert(rate Rate) Amount {
+	return a.MulRatio(int64(rate), rateUnit)
+}
+
+// IsZero reports whether the amount is zero.
+func (a Amount) IsZero() bool {
+	return a == 0
+}
+
+// String formats the amount with exactly two fraction digits.
+func (a Amount) String() string {
+	minor := int64(a)
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorPerMajor, minor%minorPerMajor)
+}
+
+// Parse reads a decimal string such as "12", "12.5" or "-0.125". Fraction
+// digits beyond Scale are rounded half away from zero. An empty string parses
+// as zero.
+func Parse(s string) (Amount, error) {
+	value, err := parseDecimal(s, Scale, maxIntegerDigits)
+	return Amount(value), err
+}
+
+// parseDecimal reads s as a fixed-point number with scale fraction digits.
+func parseDecimal(s string, scale int, maxDigits int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(s, ".")
+	if integer == "" && fraction == "" {
+		return 0, ErrInvalidAmount
+	}
+	if !isDigits(integer) || !isDigits(fraction) {
+		return 0, ErrInvalidAmount
+	}
+	integer = strings.TrimLeft(integer, "0")
+	if len(integer) > maxDigits {
+		return 0, ErrAmountTooLarge
+	}
+
+	roundUp := len(fraction) > scale && fraction[scale] >= '5'
+	if len(fraction) > scale {
+		fraction = fraction[:scale]
+	}
+	for len(fraction) < scale {
+		fraction += "0"
+	}
+	digits := strings.TrimLeft(integer+fraction, "0")
+	var value int64
+	if digits != "" {
+		parsed, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return 0, ErrAmountTooLarge
+		}
+		value = parsed
+	}
+	if roundUp {
+		value++
+	}
+
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// divRound divides num by den rounding half away from zero.
+func divRound(num, den *big.Int) int64 {
+	if den.Sign() == 0 {
+		panic("money: zero denominator")
+	}
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient.Int64()
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON encodes the amount as a JSON number with two fraction digits.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string. The digits
+// are read as text, never through float64.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so the amount is written as an exact decimal.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements sql.Scanner for DECIMAL and legacy numeric columns.
+func (a *Amount) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case int64:
+		*a = Amount(v * minorPerMajor)
+		return nil
+	case float64:
+		return a.scanString(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("money: cannot scan %T into Amount", value)
+	}
+}
+
+func (a *Amount) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
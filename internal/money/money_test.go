@@ -0,0 +1,206 @@
+package money
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+		err  error
+	}{
+		{"", 0, nil},
+		{"12", 1200, nil},
+		{"12.5", 1250, nil},
+		{"+3.10", 310, nil},
+		{".5", 50, nil},
+		{"5.", 500, nil},
+		{"0012.30", 1230, nil},
+		{" 7.25 ", 725, nil},
+		{"-12.34", -1234, nil},
+		{"0.004", 0, nil},
+		{"0.005", 1, nil},
+		{"-0.005", -1, nil},
+		{"0.0049", 0, nil},
+		{"-0.125", -13, nil},
+		{"1.995", 200, nil},
+		{"9999999999999999.99", 999999999999999999, nil},
+		{"12345678901234567", 0, ErrAmountTooLarge},
+		{"-", 0, ErrInvalidAmount},
+		{".", 0, ErrInvalidAmount},
+		{"abc", 0, ErrInvalidAmount},
+		{"1.2.3", 0, ErrInvalidAmount},
+		{"1,000", 0, ErrInvalidAmount},
+		{"1e5", 0, ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != tt.err {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.in, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   Amount
+		want string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{-5, "-0.05"},
+		{-100, "-1.00"},
+		{125050, "1250.50"},
+		{999999999999999999, "9999999999999999.99"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMulRatio(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		num, den int64
+		want     Amount
+	}{
+		{1000, 15, 100, 150},
+		{1, 1, 3, 0},
+		{2, 1, 3, 1},
+		{5, 1, 2, 3},
+		{-5, 1, 2, -3},
+		{7, 1, -2, -4},
+		{-7, -1, 2, 4},
+		{14, 1, 10, 1},
+		{15, 1, 10, 2},
+		{-15, 1, 10, -2},
+		{-14, 1, 10, -1},
+		{999999999999999999, 3, 3, 999999999999999999},
+	}
+	for _, tt := range tests {
+		if got := tt.amount.MulRatio(tt.num, tt.den); got != tt.want {
+			t.Errorf("Amount(%d).MulRatio(%d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Rate
+		err  error
+	}{
+		{"1", RateOne, nil},
+		{"15850.5", 1585050000000, nil},
+		{"0.0000631", 6310, nil},
+		{"0.00000001", 1, nil},
+		{"0.000000004", 0, nil},
+		{"0.000000005", 1, nil},
+		{"0.123456789", 12345679, nil},
+		{"-1.5", -150000000, nil},
+		{"12345678901", 1234567890100000000, nil},
+		{"1234567890123", 0, ErrAmountTooLarge},
+		{"x", 0, ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		got, err := ParseRate(tt.in)
+		if err != tt.err {
+			t.Errorf("ParseRate(%q) error = %v, want %v", tt.in, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRate(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRateString(t *testing.T) {
+	tests := []struct {
+		in   Rate
+		want string
+	}{
+		{RateOne, "1"},
+		{1585050000000, "15850.5"},
+		{6310, "0.0000631"},
+		{1, "0.00000001"},
+		{-150000000, "-1.5"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Rate(%d).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		rate   string
+		want   Amount
+	}{
+		{10000, "1", 10000},
+		{100, "15850.5", 1585050},
+		{1, "0.0000631", 0},
+		{150, "0.333", 50},
+		{1, "0.5", 1},
+		{3, "0.5", 2},
+		{-3, "0.5", -2},
+		{1, "0.49999999", 0},
+		{-1, "0.49999999", 0},
+	}
+	for _, tt := range tests {
+		rate, err := ParseRate(tt.rate)
+		if err != nil {
+			t.Fatalf("ParseRate(%q): %v", tt.rate, err)
+		}
+		if got := tt.amount.Convert(rate); got != tt.want {
+			t.Errorf("Amount(%d).Convert(%s) = %d, want %d", tt.amount, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		amount  Amount
+		weights []Amount
+		want    []Amount
+	}{
+		{100, []Amount{1, 1, 1}, []Amount{34, 33, 33}},
+		{1000, []Amount{300, 700}, []Amount{300, 700}},
+		{10, []Amount{1, 2, 3, 4}, []Amount{1, 2, 3, 4}},
+		{5, []Amount{3, 3, 4}, []Amount{2, 1, 2}},
+		{10, []Amount{1, 1, 5}, []Amount{2, 1, 7}},
+		{999, []Amount{1000, 1}, []Amount{998, 1}},
+		{7, []Amount{2, 0, 5}, []Amount{2, 0, 5}},
+		{0, []Amount{5, 5}, []Amount{0, 0}},
+		{-100, []Amount{1, 1, 1}, []Amount{-34, -33, -33}},
+		{100, []Amount{0, 0, 0}, []Amount{34, 33, 33}},
+		{7, []Amount{0, 0}, []Amount{4, 3}},
+		{10, nil, nil},
+	}
+	for _, tt := range tests {
+		got := tt.amount.Allocate(tt.weights)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Amount(%d).Allocate(%v) = %v, want %v", tt.amount, tt.weights, got, tt.want)
+			continue
+		}
+		if len(tt.weights) == 0 {
+			continue
+		}
+		var sum Amount
+		for _, share := range got {
+			sum = sum.Add(share)
+		}
+		if sum != tt.amount {
+			t.Errorf("Amount(%d).Allocate(%v) shares add up to %d", tt.amount, tt.weights, sum)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RateScale is the number of fraction digits carried by a Rate.
+const RateScale = 8
+
+const rateUnit = 100000000
+
+// maxRateDigits keeps parsed rates inside the DECIMAL(20,8) column type.
+const maxRateDigits = 12
+
+// RateOne is the identity rate used when no conversion is needed.
+const RateOne Rate = rateUnit
+
+// Rate is an exchange rate with eight fraction digits: the number of quote
+// currency units one base currency unit buys.
+type Rate int64
+
+// ParseRate reads a decimal exchange rate such as "15850.5" or "0.0000631".
+// Fraction digits beyond RateScale are rounded half away from zero.
+func ParseRate(s string) (Rate, error) {
+	value, err := parseDecimal(s, RateScale, maxRateDigits)
+	return Rate(value), err
+}
+
+// Inverse returns the rate for the opposite direction, rounded half away from
+// zero. It panics if the rate is zero.
+func (r Rate) Inverse() Rate {
+	return Rate(divRound(big.NewInt(rateUnit*rateUnit), big.NewInt(int64(r))))
+}
+
+// String formats the rate with trailing zero fraction digits removed.
+func (r Rate) String() string {
+	value := int64(r)
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	fraction := strings.TrimRight(fmt.Sprintf("%08d", value%rateUnit), "0")
+	if fraction == "" {
+		return fmt.Sprintf("%s%d", sign, value/rateUnit)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, value/rateUnit, fraction)
+}
+
+// MarshalJSON encodes the rate as a JSON number.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so the rate is written as an exact decimal.
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner for DECIMAL columns.
+func (r *Rate) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*r = 0
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("money: cannot scan %T into Rate", value)
+	}
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
@@ -0,0 +1,191 @@
+// Package pdf writes simple PDF documents: pages of text in the Helvetica
+// standard fonts and straight lines, enough for invoices and receipts.
+//
+// Standard fonts are built into every PDF reader, so no font is embedded and
+// documents stay small. Text is encoded as WinAnsi, which covers Latin-1;
+// other characters are written as "?". Coordinates are in points measured
+// from the top-left corner of an A4 page.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font selects one of the two fonts of a document.
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+// Document is a PDF being written. The zero value is not usable; create one
+// with New.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+}
+
+// New starts a document with one empty page.
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.AddPage()
+	return d
+}
+
+// AddPage starts a new page; later drawing goes to it.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+}
+
+// Text writes s with its baseline starting at (x, y).
+func (d *Document) Text(x, y float64, font Font, size float64, s string) {
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		font+1, num(size), num(x), num(PageHeight-y), escape(encode(s)))
+}
+
+// TextRight writes s so that it ends at x.
+func (d *Document) TextRight(x, y float64, font Font, size float64, s string) {
+	d.Text(x-Width(s, font, size), y, font, size, s)
+}
+
+// Line draws a thin line from (x1, y1) to (x2, y2).
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "0.5 w %s %s m %s %s l S\n",
+		num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// objects 1 to 5 are fixed; every page then takes a page object
+	// followed by its content stream
+	const firstPage = 6
+	kids := new(bytes.Buffer)
+	for i := range d.pages {
+		fmt.Fprintf(kids, "%d 0 R ", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (go-ecommerce) >>", escape(encode(d.title))))
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// Width returns the width of s in points.
+func Width(s string, font Font, size float64) float64 {
+	widths := &helvetica
+	if font == Bold {
+		widths = &helveticaBold
+	}
+	total := 0
+	for _, c := range encode(s) {
+		if c >= 32 && c < 127 {
+			total += widths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Truncate shortens s with "..." so that it fits in width points.
+func Truncate(s string, font Font, size float64, width float64) string {
+	if Width(s, font, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if truncated := string(runes) + "..."; Width(truncated, font, size) <= width {
+			return truncated
+		}
+	}
+	return ""
+}
+
+// winAnsi maps the characters of WinAnsiEncoding outside Latin-1.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+func encode(s string) []byte {
+	encoded := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 32 && r < 127, r >= 160 && r <= 255:
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+func escape(s []byte) []byte {
+	escaped := make([]byte, 0, len(s))
+	for _, c := range s {
+		if c == '(' || c == ')' || c == '\\' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, c)
+	}
+	return escaped
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Glyph widths of the printable ASCII characters, in thousandths of the font
+// size, from the Adobe font metrics of the standard fonts.
+var helvetica = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBold = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
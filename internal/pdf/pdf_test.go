@@ -0,0 +1,147 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+	}{
+		{"Invoice 42", []byte("Invoice 42")},
+		{"café", []byte("caf\xe9")},
+		{"€ 5 – “ok”", []byte("\x80 5 \x96 \x93ok\x94")},
+		{"日本", []byte("??")},
+		{"line\nbreak", []byte("line?break")},
+	}
+	for _, tt := range tests {
+		if got := encode(tt.in); !bytes.Equal(got, tt.want) {
+			t.Errorf("encode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if got, want := escape([]byte(`Total (IDR) \ net`)), `Total \(IDR\) \\ net`; string(got) != want {
+		t.Errorf("escape = %q, want %q", got, want)
+	}
+}
+
+func TestNum(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{12, "12"},
+		{0.5, "0.5"},
+		{841.89, "841.89"},
+		{-3.25, "-3.25"},
+	}
+	for _, tt := range tests {
+		if got := num(tt.in); got != tt.want {
+			t.Errorf("num(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		font Font
+		size float64
+		want float64
+	}{
+		{"", Regular, 10, 0},
+		{"Hi", Regular, 10, 9.44},
+		{"Hi", Bold, 10, 10},
+		{"Hi", Regular, 20, 18.88},
+		// characters outside ASCII count as an average glyph
+		{"é", Regular, 10, 5.56},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s, tt.font, tt.size); got != tt.want {
+			t.Errorf("Width(%q, %d, %v) = %v, want %v", tt.s, tt.font, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width float64
+		want  string
+	}{
+		{"Hello world", 50, "Hello world"},
+		{"Hello world", Width("Hello world", Regular, 10), "Hello world"},
+		{"Hello world", 20, "H..."},
+		{"Hello world", 5, ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, Regular, 10, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %v) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	d := New("Invoice (INV-1)")
+	d.Text(50, 50, Bold, 12, "Total (IDR)")
+	d.AddPage()
+	d.Line(0, 10, 100, 10)
+	out := d.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("document does not start with a header and end with %%%%EOF: %q", out)
+	}
+
+	// every xref entry points at its object, and startxref at the table
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(out)
+	if match == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	table := regexp.MustCompile(`^xref\n0 (\d+)\n0000000000 65535 f \n((?:\d{10} 00000 n \n)*)trailer\n`).FindSubmatch(out[xref:])
+	if table == nil {
+		t.Fatalf("no xref table at offset %d", xref)
+	}
+	size, _ := strconv.Atoi(string(table[1]))
+	if size != 10 {
+		t.Errorf("xref size = %d, want 10 for 5 fixed objects and 2 pages", size)
+	}
+	for i, entry := range regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(table[2], -1) {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, out[offset:offset+10], want)
+		}
+	}
+
+	// every stream is as long as its /Length says
+	streams := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindAllSubmatch(out, -1)
+	if len(streams) != 2 {
+		t.Fatalf("%d content streams, want 2", len(streams))
+	}
+	for i, stream := range streams {
+		if length, _ := strconv.Atoi(string(stream[1])); length != len(stream[2]) {
+			t.Errorf("stream %d: /Length %d, content %d bytes", i, length, len(stream[2]))
+		}
+	}
+
+	wantText := fmt.Sprintf("BT /F2 12 Tf 50 %s Td (Total \\(IDR\\)) Tj ET\n", num(PageHeight-50))
+	if string(streams[0][2]) != wantText {
+		t.Errorf("first page = %q, want %q", streams[0][2], wantText)
+	}
+	wantLine := fmt.Sprintf("0.5 w 0 %s m 100 %s l S\n", num(PageHeight-10), num(PageHeight-10))
+	if string(streams[1][2]) != wantLine {
+		t.Errorf("second page = %q, want %q", streams[1][2], wantLine)
+	}
+	for _, want := range []string{"/Kids [6 0 R 8 0 R] /Count 2", "/Title (Invoice \\(INV-1\\))", "/Contents 7 0 R", "/Contents 9 0 R"} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("document has no %q", want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AddressRepository struct {
+	DB *gorm.DB
+}
+
+func NewAddressRepository(DB *gorm.DB) interfaces.AddressRepository {
+	return &AddressRepository{DB: DB}
+}
+
+func (r *AddressRepository) CreateAddress(db *gorm.DB, address *entity.Address) error {
+	return db.Create(address).Error
+}
+
+func (r *AddressRepository) UpdateAddress(db *gorm.DB, address *entity.Address) error {
+	return db.Save(address).Error
+}
+
+func (r *AddressRepository) DeleteAddress(db *gorm.DB, address *entity.Address) error {
+	return db.Delete(address).Error
+}
+
+// LockAddressBook locks the user row until the surrounding transaction ends,
+// so changes of the user's default address are serialized.
+func (r *AddressRepository) LockAddressBook(db *gorm.DB, userID uint) error {
+	var user entity.User
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Take(&user, userID).Error
+}
+
+// FindAddressesByUser returns the address book of a user, the default address
+// first and then the most recently added.
+func (r *AddressRepository) FindAddressesByUser(db *gorm.DB, userID uint) ([]entity.Address, error) {
+	var addresses []entity.Address
+	err := db.Where("user_id = ?", userID).
+		Order("is_default DESC, id DESC").
+		Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *AddressRepository) FindAddressByUUID(db *gorm.DB, userID uint, addressUUID string) (*entity.Address, error) {
+	var address entity.Address
+	if err := db.Where("address_uuid = ? AND user_id = ?", addressUUID, userID).Take(&address).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (r *AddressRepository) FindDefaultAddress(db *gorm.DB, userID uint) (*entity.Address, error) {
+	var address entity.Address
+	if err := db.Where("user_id = ? AND is_default = ?", userID, true).Take(&address).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &address, nil
+}
+
+// ClearDefault unmarks the default address of a user.
+func (r *AddressRepository) ClearDefault(db *gorm.DB, userID uint) error {
+	return db.Model(&entity.Address{}).
+		Where("user_id = ? AND is_default = ?", userID, true).
+		Update("is_default", false).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type CartRepository struct {
+	DB *gorm.DB
+}
+
+func NewCartRepository(DB *gorm.DB) interfaces.CartRepository {
+	return &CartRepository{DB: DB}
+}
+
+// FindCart loads the owner's cart with its items and their live product data.
+// A buyer's cart is looked up by user, a guest cart by token.
+func (r *CartRepository) FindCart(db *gorm.DB, owner model.CartOwner) (*entity.Cart, error) {
+	query := db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Items.Product.Store")
+	if owner.UserID != 0 {
+		query = query.Where("user_id = ?", owner.UserID)
+	} else {
+		query = query.Where("guest_token = ?", owner.GuestToken)
+	}
+
+	var cart entity.Cart
+	if err := query.First(&cart).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (r *CartRepository) CreateCart(db *gorm.DB, cart *entity.Cart) error {
+	return db.Create(cart).Error
+}
+
+func (r *CartRepository) DeleteCart(db *gorm.DB, cart *entity.Cart) error {
+	if err := r.ClearItems(db, cart.ID); err != nil {
+		return err
+	}
+	return db.Unscoped().Delete(cart).Error
+}
+
+func (r *CartRepository) SaveItem(db *gorm.DB, item *entity.CartItem) error {
+	return db.Omit("Cart", "Product").Save(item).Error
+}
+
+func (r *CartRepository) DeleteItem(db *gorm.DB, cartID, productID uint) (bool, error) {
+	result := db.Unscoped().Where("cart_id = ? AND product_id = ?", cartID, productID).Delete(&entity.CartItem{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *CartRepository) ClearItems(db *gorm.DB, cartID uint) error {
+	return db.Unscoped().Where("cart_id = ?", cartID).Delete(&entity.CartItem{}).Error
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CheckoutRepository struct {
+	DB *gorm.DB
+}
+
+func NewCheckoutRepository(DB *gorm.DB) interfaces.CheckoutRepository {
+	return &CheckoutRepository{DB: DB}
+}
+
+func (r *CheckoutRepository) CreateCheckout(db *gorm.DB, checkout *entity.Checkout) error {
+	return db.Omit("Orders").Create(checkout).Error
+}
+
+func (r *CheckoutRepository) UpdateCheckout(db *gorm.DB, checkout *entity.Checkout) error {
+	return db.Omit("Orders", "User").Save(checkout).Error
+}
+
+func (r *CheckoutRepository) FindCheckoutByUUID(db *gorm.DB, userID uint, checkoutUUID string) (*entity.Checkout, error) {
+	return r.findCheckout(db, userID, checkoutUUID)
+}
+
+// FindCheckoutByUUIDForUpdate loads the checkout and locks its row, so two
+// payments of the same checkout are serialized.
+func (r *CheckoutRepository) FindCheckoutByUUIDForUpdate(db *gorm.DB, userID uint, checkoutUUID string) (*entity.Checkout, error) {
+	return r.findCheckout(db.Clauses(clause.Locking{Strength: "UPDATE"}), userID, checkoutUUID)
+}
+
+func (r *CheckoutRepository) findCheckout(db *gorm.DB, userID uint, checkoutUUID string) (*entity.Checkout, error) {
+	var checkout entity.Checkout
+	err := db.Preload("Orders", func(db *gorm.DB) *gorm.DB {
+		return db.Order("orders.id ASC")
+	}).
+		Preload("Orders.Items").
+		Preload("Orders.Payment").
+		Preload("Orders.Shipping").
+		Where("checkout_uuid = ? AND user_id = ?", checkoutUUID, userID).
+		Take(&checkout).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &checkout, nil
+}
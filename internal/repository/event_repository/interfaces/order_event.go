@@ -11,4 +11,5 @@ type OrderEventRepository interface {
 	UpdateOrderEvent(event *evententity.OrderEvent) error
 	GetPendingEvents() ([]evententity.OrderEvent, error)
 	GetFailedEvents(duration time.Duration) ([]evententity.OrderEvent, error)
+	GetEventsByOrderID(orderID uint) ([]evententity.OrderEvent, error)
 }
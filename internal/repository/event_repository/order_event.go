@@ -36,3 +36,9 @@ func (r *OrderEventRepository) GetFailedEvents(duration time.Duration) ([]evente
 		time.Now().Add(-duration)).Find(&events).Error
 	return events, err
 }
+
+func (r *OrderEventRepository) GetEventsByOrderID(orderID uint) ([]evententity.OrderEvent, error) {
+	var events []evententity.OrderEvent
+	err := r.DB.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&events).Error
+	return events, err
+}
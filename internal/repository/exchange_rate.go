@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExchangeRateRepository struct {
+	DB *gorm.DB
+}
+
+func NewExchangeRateRepository(DB *gorm.DB) interfaces.ExchangeRateRepository {
+	return &ExchangeRateRepository{DB: DB}
+}
+
+// UpsertRates stores the given rates, replacing the rate of pairs that are
+// already known.
+func (r *ExchangeRateRepository) UpsertRates(db *gorm.DB, rates []entity.ExchangeRate) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at", "deleted_at"}),
+	}).Create(&rates).Error
+}
+
+func (r *ExchangeRateRepository) GetRates(request *model.GetExchangeRatesRequest) ([]entity.ExchangeRate, error) {
+	query := r.DB.Model(&entity.ExchangeRate{})
+	if request.BaseCurrency != "" {
+		query = query.Where("base_currency = ?", request.BaseCurrency)
+	}
+	var rates []entity.ExchangeRate
+	if err := query.Order("base_currency ASC, quote_currency ASC").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// FindRate returns the stored rate for the pair in either direction. Callers
+// check BaseCurrency to know whether the rate has to be inverted.
+func (r *ExchangeRateRepository) FindRate(db *gorm.DB, from, to string) (*entity.ExchangeRate, error) {
+	var rate entity.ExchangeRate
+	err := db.Where("(base_currency = ? AND quote_currency = ?) OR (base_currency = ? AND quote_currency = ?)", from, to, to, from).
+		Order(clause.Expr{SQL: "base_currency = ? DESC", Vars: []interface{}{from}}).
+		First(&rate).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExportRepository struct {
+	DB *gorm.DB
+}
+
+func NewExportRepository(DB *gorm.DB) interfaces.ExportRepository {
+	return &ExportRepository{DB: DB}
+}
+
+func (r *ExportRepository) CreateExport(export *entity.OrderExport) error {
+	return r.DB.Omit(clause.Associations).Create(export).Error
+}
+
+func (r *ExportRepository) UpdateExport(export *entity.OrderExport) error {
+	return r.DB.Omit(clause.Associations).Save(export).Error
+}
+
+func (r *ExportRepository) FindExport(storeID uint, exportUUID string) (*entity.OrderExport, error) {
+	return r.findExport(r.DB, storeID, exportUUID)
+}
+
+// GetExports lists the exports of the store, newest first.
+func (r *ExportRepository) GetExports(storeID uint, request *model.SearchExportRequest) ([]entity.OrderExport, int64, error) {
+	query := r.DB.Model(&entity.OrderExport{}).Where("store_id = ?", storeID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var exports []entity.OrderExport
+	if err := query.Order("id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&exports).Error; err != nil {
+		return nil, 0, err
+	}
+	return exports, total, nil
+}
+
+// ClaimPendingExport marks the oldest pending export as running and returns
+// it. The status update only succeeds for one worker, so an export is never
+// run twice. It returns model.ErrNotFound when nothing is pending.
+func (r *ExportRepository) ClaimPendingExport(startedAt time.Time) (*entity.OrderExport, error) {
+	for {
+		var export entity.OrderExport
+		if err := r.DB.Where("status = ?", "pending").Order("id ASC").Take(&export).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, model.ErrNotFound
+			}
+			return nil, err
+		}
+		result := r.DB.Model(&entity.OrderExport{}).
+			Where("id = ? AND status = ?", export.ID, "pending").
+			Updates(map[string]interface{}{"status": "running", "started_at": startedAt})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			export.Status = "running"
+			export.StartedAt = &startedAt
+			return &export, nil
+		}
+	}
+}
+
+// FailStaleExports fails running exports that started before startedBefore,
+// whose worker was stopped before it finished.
+func (r *ExportRepository) FailStaleExports(startedBefore time.Time, reason string) (int64, error) {
+	result := r.DB.Model(&entity.OrderExport{}).
+		Where("status = ? AND started_at < ?", "running", startedBefore).
+		Updates(map[string]interface{}{"status": "failed", "error": reason})
+	return result.RowsAffected, result.Error
+}
+
+// FindExpiredExports loads the completed exports past their expiry.
+func (r *ExportRepository) FindExpiredExports(now time.Time) ([]entity.OrderExport, error) {
+	var exports []entity.OrderExport
+	err := r.DB.Where("status = ? AND expires_at < ?", "completed", now).Find(&exports).Error
+	return exports, err
+}
+
+func (r *ExportRepository) findExport(db *gorm.DB, storeID uint, exportUUID string) (*entity.OrderExport, error) {
+	var export entity.OrderExport
+	if err := db.Where("export_uuid = ? AND store_id = ?", exportUUID, storeID).Take(&export).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &export, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository struct {
+	DB *gorm.DB
+}
+
+func NewIdempotencyRepository(DB *gorm.DB) interfaces.IdempotencyRepository {
+	return &IdempotencyRepository{DB: DB}
+}
+
+// CreateKey inserts the key unless the user already holds it. It reports
+// whether the key was inserted, which makes claiming a key atomic between
+// concurrent requests.
+func (r *IdempotencyRepository) CreateKey(db *gorm.DB, key *entity.IdempotencyKey) (bool, error) {
+	result := db.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *IdempotencyRepository) FindKey(db *gorm.DB, userID uint, key string) (*entity.IdempotencyKey, error) {
+	var record entity.IdempotencyKey
+	err := db.Where("user_id = ? AND idempotency_key = ?", userID, key).Take(&record).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// TakeOverKey claims a key left processing since before staleBefore for a
+// new request under claimToken. It reports whether the key was taken, so only
+// one of several concurrent retries wins it.
+func (r *IdempotencyRepository) TakeOverKey(db *gorm.DB, userID uint, key string, claimToken string, lockedAt, staleBefore time.Time) (bool, error) {
+	result := db.Model(&entity.IdempotencyKey{}).
+		Where("user_id = ? AND idempotency_key = ? AND status = ?", userID, key, "processing").
+		Where("locked_at IS NULL OR locked_at <= ?", staleBefore).
+		Updates(map[string]interface{}{"locked_at": lockedAt, "claim_token": claimToken})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CompleteKey stores the response of the request holding key.ClaimToken. It
+// fails with model.ErrConflict when the claim is no longer held, because the
+// key was taken over or deleted.
+func (r *IdempotencyRepository) CompleteKey(db *gorm.DB, key *entity.IdempotencyKey) error {
+	result := db.Model(&entity.IdempotencyKey{}).
+		Where("user_id = ? AND idempotency_key = ? AND claim_token = ?", key.UserID, key.Key, key.ClaimToken).
+		Updates(map[string]interface{}{
+			"status":          "completed",
+			"response_status": key.ResponseStatus,
+			"content_type":    key.ContentType,
+			"response_body":   key.ResponseBody,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrConflict
+	}
+	return nil
+}
+
+// DeleteKey deletes the key if it is still under claimToken. It fails with
+// model.ErrConflict when the claim is no longer held.
+func (r *IdempotencyRepository) DeleteKey(db *gorm.DB, userID uint, key string, claimToken string) error {
+	result := db.Where("user_id = ? AND idempotency_key = ? AND claim_token = ?", userID, key, claimToken).Delete(&entity.IdempotencyKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrConflict
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpiredKeys(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("expires_at <= ?", now).Delete(&entity.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
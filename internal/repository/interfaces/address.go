@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"gorm.io/gorm"
+)
+
+type AddressRepository interface {
+	CreateAddress(db *gorm.DB, address *entity.Address) error
+	UpdateAddress(db *gorm.DB, address *entity.Address) error
+	DeleteAddress(db *gorm.DB, address *entity.Address) error
+	LockAddressBook(db *gorm.DB, userID uint) error
+	FindAddressesByUser(db *gorm.DB, userID uint) ([]entity.Address, error)
+	FindAddressByUUID(db *gorm.DB, userID uint, addressUUID string) (*entity.Address, error)
+	FindDefaultAddress(db *gorm.DB, userID uint) (*entity.Address, error)
+	ClearDefault(db *gorm.DB, userID uint) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type CartRepository interface {
+	FindCart(db *gorm.DB, owner model.CartOwner) (*entity.Cart, error)
+	CreateCart(db *gorm.DB, cart *entity.Cart) error
+	DeleteCart(db *gorm.DB, cart *entity.Cart) error
+	SaveItem(db *gorm.DB, item *entity.CartItem) error
+	DeleteItem(db *gorm.DB, cartID, productID uint) (bool, error)
+	ClearItems(db *gorm.DB, cartID uint) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"gorm.io/gorm"
+)
+
+type CheckoutRepository interface {
+	CreateCheckout(db *gorm.DB, checkout *entity.Checkout) error
+	UpdateCheckout(db *gorm.DB, checkout *entity.Checkout) error
+	FindCheckoutByUUID(db *gorm.DB, userID uint, checkoutUUID string) (*entity.Checkout, error)
+	FindCheckoutByUUIDForUpdate(db *gorm.DB, userID uint, checkoutUUID string) (*entity.Checkout, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type ExchangeRateRepository interface {
+	UpsertRates(db *gorm.DB, rates []entity.ExchangeRate) error
+	GetRates(request *model.GetExchangeRatesRequest) ([]entity.ExchangeRate, error)
+	FindRate(db *gorm.DB, from, to string) (*entity.ExchangeRate, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type ExportRepository interface {
+	CreateExport(export *entity.OrderExport) error
+	UpdateExport(export *entity.OrderExport) error
+	FindExport(storeID uint, exportUUID string) (*entity.OrderExport, error)
+	GetExports(storeID uint, request *model.SearchExportRequest) ([]entity.OrderExport, int64, error)
+	ClaimPendingExport(startedAt time.Time) (*entity.OrderExport, error)
+	FailStaleExports(startedBefore time.Time, reason string) (int64, error)
+	FindExpiredExports(now time.Time) ([]entity.OrderExport, error)
+}
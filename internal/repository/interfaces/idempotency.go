@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepository interface {
+	CreateKey(db *gorm.DB, key *entity.IdempotencyKey) (bool, error)
+	FindKey(db *gorm.DB, userID uint, key string) (*entity.IdempotencyKey, error)
+	TakeOverKey(db *gorm.DB, userID uint, key string, claimToken string, lockedAt, staleBefore time.Time) (bool, error)
+	CompleteKey(db *gorm.DB, key *entity.IdempotencyKey) error
+	DeleteKey(db *gorm.DB, userID uint, key string, claimToken string) error
+	DeleteExpiredKeys(db *gorm.DB, now time.Time) (int64, error)
+}
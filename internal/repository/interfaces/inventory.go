@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type InventoryRepository interface {
+	CreateMovement(db *gorm.DB, movement *entity.InventoryMovement) error
+	GetMovementsByProduct(productID uint, request *model.GetStockHistoryRequest) ([]entity.InventoryMovement, int64, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type MessageRepository interface {
+	FindOrCreateThread(db *gorm.DB, thread *entity.MessageThread) error
+	FindThreadByUUID(db *gorm.DB, threadUUID string) (*entity.MessageThread, error)
+	TouchThread(db *gorm.DB, threadID uint, at time.Time) error
+	GetThreads(request *model.SearchThreadRequest) ([]entity.MessageThread, int64, error)
+	CreateMessage(db *gorm.DB, message *entity.Message) error
+	GetMessages(threadID uint, page, limit int) ([]entity.Message, int64, error)
+	LatestMessageID(db *gorm.DB, threadID uint) (uint, error)
+	MarkRead(db *gorm.DB, read *entity.MessageThreadRead) error
+	GetReadReceipts(threadID uint) (map[uint]uint, error)
+	UnreadCounts(request *model.SearchThreadRequest, threadIDs []uint) (map[uint]int64, error)
+	CountUnread(request *model.SearchThreadRequest) (threads int64, messages int64, err error)
+}
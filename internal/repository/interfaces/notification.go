@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	CreateNotifications(db *gorm.DB, notifications []entity.Notification) error
+	GetNotifications(request *model.GetNotificationsRequest) ([]entity.Notification, int64, error)
+	FindNotificationByUUID(userID uint, notificationUUID string) (*entity.Notification, error)
+	UpdateNotification(notification *entity.Notification) error
+}
@@ -1,14 +1,23 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
 )
 
 type OrderRepository interface {
 	FindStoreByProductUUIDs(productUUIDs []string) (uint, error)
 	UpdateOrder(order *entity.Order) error
-	CreateOrder(order *entity.Order) error
+	CreateOrder(db *gorm.DB, order *entity.Order) error
+	FindUnpaidOrders(db *gorm.DB, createdBefore time.Time, unremindedOnly bool, limit int) ([]entity.Order, error)
+	FindDeliveredOrders(db *gorm.DB, deliveredBefore time.Time, unremindedOnly bool, limit int) ([]entity.Order, error)
+	LockOrder(db *gorm.DB, orderID uint) error
+	CancelOrderItem(db *gorm.DB, order *entity.Order, item *entity.OrderItem, repriced []entity.OrderItem) error
+	MarkPaymentReminderSent(db *gorm.DB, orderID uint, sentAt time.Time) (bool, error)
+	MarkCompletionReminderSent(db *gorm.DB, orderID uint, sentAt time.Time) (bool, error)
 	GetOrdersByBuyer(request *model.SearchOrderRequest) ([]entity.Order, int64, error)
 	GetOrderByIdByBuyer(request *model.GetOrderDetails) (*entity.Order, error)
 	GetOrdersBySeller(request *model.SearchOrderRequestBySeller) ([]entity.Order, int64, error)
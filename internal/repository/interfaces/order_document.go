@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"gorm.io/gorm"
+)
+
+type OrderDocumentRepository interface {
+	FindDocument(db *gorm.DB, orderID uint, documentType string) (*entity.OrderDocument, error)
+	FindDocumentForUpdate(db *gorm.DB, orderID uint, documentType string) (*entity.OrderDocument, error)
+	NextSequence(db *gorm.DB, storeID uint, documentType string) (int, error)
+	CreateDocument(db *gorm.DB, document *entity.OrderDocument) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
+	"gorm.io/gorm"
+)
+
+type OrderStatusRepository interface {
+	Transition(db *gorm.DB, change *entity.OrderStatusHistory) error
+	CurrentStatus(db *gorm.DB, machine statemachine.Machine, orderID uint) (string, error)
+	GetHistoryByOrderID(db *gorm.DB, orderID uint) ([]entity.OrderStatusHistory, error)
+}
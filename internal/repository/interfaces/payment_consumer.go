@@ -6,4 +6,5 @@ type PaymentConsumer interface {
     CreatePayment(ctx context.Context) error
     CancelPayment(ctx context.Context) error 
     CheckoutPayment(ctx context.Context) error
+    RefundPayment(ctx context.Context) error
 }
\ No newline at end of file
@@ -3,14 +3,20 @@ package interfaces
 import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
 )
 
 type ProductRepository interface {
-	CreateProduct(product *entity.Product) error
+	CreateProduct(db *gorm.DB, product *entity.Product) error
 	GetProducts(request *model.GetProductsRequest) ([]entity.Product, int64, error)
 	GetProductById(userID uint, productUUID string) (*entity.Product, error)
-	UpdateProduct(product *entity.Product) error
+	UpdateProduct(db *gorm.DB, product *entity.Product) error
 	DeleteProduct(product *entity.Product) error
 	FindProductByUUID(productUUID string) (entity.Product, error)
 	FindProductByID(productID uint) (entity.Product, error)
+	FindProductsByUUIDsForUpdate(db *gorm.DB, productUUIDs []string) ([]entity.Product, error)
+	FindProductsByUUIDs(db *gorm.DB, productUUIDs []string) ([]entity.Product, error)
+	DecreaseStock(db *gorm.DB, productID uint, quantity int) error
+	IncreaseStock(db *gorm.DB, productID uint, quantity int) error
+	FindProductWithStore(db *gorm.DB, productID uint) (*entity.Product, error)
 }
\ No newline at end of file
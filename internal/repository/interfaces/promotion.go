@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	CreatePromotion(db *gorm.DB, promotion *entity.Promotion) error
+	UpdatePromotion(promotion *entity.Promotion) error
+	FindPromotionByUUID(storeID uint, promotionUUID string) (*entity.Promotion, error)
+	GetPromotions(storeID uint, request *model.SearchPromotionRequest) ([]entity.Promotion, int64, error)
+	FindActivePromotions(db *gorm.DB, storeIDs []uint, at time.Time) ([]entity.Promotion, error)
+}
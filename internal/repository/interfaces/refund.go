@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+type RefundRepository interface {
+	CreateRefund(db *gorm.DB, refund *entity.Refund) error
+	SumRefundsByOrderID(db *gorm.DB, orderID uint) (money.Amount, error)
+}
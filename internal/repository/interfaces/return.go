@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type ReturnRepository interface {
+	CreateReturn(db *gorm.DB, request *entity.ReturnRequest) error
+	UpdateReturn(db *gorm.DB, request *entity.ReturnRequest) error
+	FindReturnByBuyer(userID uint, returnUUID string) (*entity.ReturnRequest, error)
+	FindReturnBySeller(storeID uint, returnUUID string) (*entity.ReturnRequest, error)
+	FindReturnBySellerForUpdate(db *gorm.DB, storeID uint, returnUUID string) (*entity.ReturnRequest, error)
+	GetReturns(request *model.SearchReturnRequest) ([]entity.ReturnRequest, int64, error)
+	ReturnedItems(db *gorm.DB, orderID uint) (map[uint]entity.ReturnItem, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type ShippingRateRepository interface {
+	CreateRates(db *gorm.DB, rates []entity.ShippingRate) error
+	UpdateRate(rate *entity.ShippingRate) error
+	DeleteRate(rate *entity.ShippingRate) error
+	FindRateByUUID(rateUUID string) (*entity.ShippingRate, error)
+	GetRates(request *model.SearchShippingRateRequest) ([]entity.ShippingRate, int64, error)
+	FindMatchingRates(db *gorm.DB, origin, destination string, weight int) ([]entity.ShippingRate, error)
+}
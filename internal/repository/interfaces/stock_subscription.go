@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"gorm.io/gorm"
+)
+
+type StockSubscriptionRepository interface {
+	UpsertSubscription(subscription *entity.StockSubscription) error
+	DeleteSubscription(userID uint, productID uint) (bool, error)
+	FindPendingByProduct(db *gorm.DB, productID uint) ([]entity.StockSubscription, error)
+	MarkNotified(db *gorm.DB, subscriptionIDs []uint) error
+}
@@ -8,6 +8,7 @@ import (
 type StoreRepository interface {
 	GetStoreIDByUserID(userID uint) (uint, error)
 	FindStoreByUserID(userID uint) (entity.Store, error)
+	FindStoreByID(db *gorm.DB, storeID uint) (*entity.Store, error)
 	HasStore(db *gorm.DB, userID uint) (bool, error)
 	CreateStore(store *entity.Store) error
 	UpdateStore(store *entity.Store) error
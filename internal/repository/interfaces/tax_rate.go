@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type TaxRateRepository interface {
+	CreateRate(rate *entity.TaxRate) error
+	UpdateRate(rate *entity.TaxRate) error
+	FindRateByUUID(taxRateUUID string) (*entity.TaxRate, error)
+	GetRates(request *model.SearchTaxRateRequest) ([]entity.TaxRate, int64, error)
+	FindEffectiveRates(db *gorm.DB, categories []string, at time.Time) ([]entity.TaxRate, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type VoucherRepository interface {
+	CreateVoucher(db *gorm.DB, voucher *entity.Voucher) error
+	UpdateVoucher(voucher *entity.Voucher) error
+	CodeExists(code string) (bool, error)
+	FindVoucherByUUID(storeID *uint, voucherUUID string) (*entity.Voucher, error)
+	GetVouchers(storeID *uint, request *model.SearchVoucherRequest) ([]entity.Voucher, int64, error)
+	FindVoucherByCodeForUpdate(db *gorm.DB, code string) (*entity.Voucher, error)
+	CountRedemptions(db *gorm.DB, voucherID uint, userID uint) (int64, error)
+	Redeem(db *gorm.DB, redemption *entity.VoucherRedemption) error
+	Release(db *gorm.DB, orderID uint) error
+}
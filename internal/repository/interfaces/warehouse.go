@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"gorm.io/gorm"
+)
+
+type WarehouseRepository interface {
+	CreateWarehouse(warehouse *entity.Warehouse) error
+	UpdateWarehouse(warehouse *entity.Warehouse) error
+	FindWarehousesByStore(db *gorm.DB, storeID uint, activeOnly bool) ([]entity.Warehouse, error)
+	FindWarehouseByUUID(storeID uint, warehouseUUID string) (*entity.Warehouse, error)
+	FindStocksForUpdate(db *gorm.DB, warehouseIDs []uint, productIDs []uint) ([]entity.WarehouseStock, error)
+	ChangeStock(db *gorm.DB, warehouseID uint, productID uint, quantity int) error
+	GetStocksByProduct(productID uint) ([]entity.WarehouseStock, error)
+}
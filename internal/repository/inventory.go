@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type InventoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewInventoryRepository(DB *gorm.DB) interfaces.InventoryRepository {
+	return &InventoryRepository{DB: DB}
+}
+
+func (r *InventoryRepository) CreateMovement(db *gorm.DB, movement *entity.InventoryMovement) error {
+	return db.Create(movement).Error
+}
+
+func (r *InventoryRepository) GetMovementsByProduct(productID uint, request *model.GetStockHistoryRequest) ([]entity.InventoryMovement, int64, error) {
+	query := r.DB.Model(&entity.InventoryMovement{}).Where("product_id = ?", productID)
+	if request.Reason != "" {
+		query = query.Where("reason = ?", request.Reason)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var movements []entity.InventoryMovement
+	if err := query.Preload("Warehouse").Order("id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+	return movements, total, nil
+}
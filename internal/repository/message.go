@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MessageRepository struct {
+	DB *gorm.DB
+}
+
+func NewMessageRepository(DB *gorm.DB) interfaces.MessageRepository {
+	return &MessageRepository{DB: DB}
+}
+
+// FindOrCreateThread creates the thread unless the order, or the buyer's
+// pre-sale thread of the product, already has one. In both cases thread is
+// filled with the stored row, so concurrent first messages share a thread.
+func (r *MessageRepository) FindOrCreateThread(db *gorm.DB, thread *entity.MessageThread) error {
+	result := db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(thread)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	query := db.Model(&entity.MessageThread{})
+	if thread.OrderID != nil {
+		query = query.Where("order_id = ?", *thread.OrderID)
+	} else {
+		query = query.Where("product_id = ? AND buyer_id = ?", *thread.ProductID, thread.BuyerID)
+	}
+	*thread = entity.MessageThread{}
+	return query.Take(thread).Error
+}
+
+func (r *MessageRepository) FindThreadByUUID(db *gorm.DB, threadUUID string) (*entity.MessageThread, error) {
+	var thread entity.MessageThread
+	if err := r.preloadThread(db).Where("thread_uuid = ?", threadUUID).Take(&thread).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &thread, nil
+}
+
+func (r *MessageRepository) TouchThread(db *gorm.DB, threadID uint, at time.Time) error {
+	return db.Model(&entity.MessageThread{}).Where("id = ?", threadID).Update("last_message_at", at).Error
+}
+
+// GetThreads lists the threads the user takes part in, most recently active
+// first. Admins see every thread.
+func (r *MessageRepository) GetThreads(request *model.SearchThreadRequest) ([]entity.MessageThread, int64, error) {
+	query := r.participantScope(r.DB.Model(&entity.MessageThread{}), request)
+	if request.Unread {
+		query = query.Where("message_threads.id IN (?)",
+			r.unreadQuery(request).Distinct("messages.thread_id"))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var threads []entity.MessageThread
+	if err := r.preloadThread(query).Order("last_message_at DESC, id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&threads).Error; err != nil {
+		return nil, 0, err
+	}
+	return threads, total, nil
+}
+
+func (r *MessageRepository) CreateMessage(db *gorm.DB, message *entity.Message) error {
+	return db.Omit("Sender").Create(message).Error
+}
+
+// GetMessages returns a page of the thread's messages, newest first.
+func (r *MessageRepository) GetMessages(threadID uint, page, limit int) ([]entity.Message, int64, error) {
+	query := r.DB.Model(&entity.Message{}).Where("thread_id = ?", threadID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var messages []entity.Message
+	if err := query.Preload("Sender").Preload("Attachments").
+		Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&messages).Error; err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+func (r *MessageRepository) LatestMessageID(db *gorm.DB, threadID uint) (uint, error) {
+	var id uint
+	err := db.Model(&entity.Message{}).
+		Select("COALESCE(MAX(id), 0)").
+		Where("thread_id = ?", threadID).
+		Scan(&id).Error
+	return id, err
+}
+
+// MarkRead moves the user's read receipt of the thread forward. A receipt
+// never moves back, so a stale request cannot mark messages unread again.
+func (r *MessageRepository) MarkRead(db *gorm.DB, read *entity.MessageThreadRead) error {
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "thread_id"}, {Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_read_message_id": gorm.Expr("GREATEST(last_read_message_id, VALUES(last_read_message_id))"),
+			"read_at":              gorm.Expr("VALUES(read_at)"),
+		}),
+	}).Create(read).Error
+}
+
+// GetReadReceipts returns, per user ID, the last message of the thread the
+// user has read.
+func (r *MessageRepository) GetReadReceipts(threadID uint) (map[uint]uint, error) {
+	var reads []entity.MessageThreadRead
+	if err := r.DB.Where("thread_id = ?", threadID).Find(&reads).Error; err != nil {
+		return nil, err
+	}
+	receipts := make(map[uint]uint, len(reads))
+	for _, read := range reads {
+		receipts[read.UserID] = read.LastReadMessageID
+	}
+	return receipts, nil
+}
+
+// UnreadCounts returns, per thread ID, how many messages of the given threads
+// the user has not read yet.
+func (r *MessageRepository) UnreadCounts(request *model.SearchThreadRequest, threadIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(threadIDs))
+	if len(threadIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		ThreadID uint
+		Unread   int64
+	}
+	err := r.unreadQuery(request).
+		Select("messages.thread_id, COUNT(*) AS unread").
+		Where("messages.thread_id IN ?", threadIDs).
+		Group("messages.thread_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ThreadID] = row.Unread
+	}
+	return counts, nil
+}
+
+// CountUnread returns how many threads have unread messages for the user and
+// how many messages that are in total.
+func (r *MessageRepository) CountUnread(request *model.SearchThreadRequest) (int64, int64, error) {
+	var row struct {
+		Threads  int64
+		Messages int64
+	}
+	err := r.unreadQuery(request).
+		Select("COUNT(DISTINCT messages.thread_id) AS threads, COUNT(*) AS messages").
+		Scan(&row).Error
+	return row.Threads, row.Messages, err
+}
+
+// unreadQuery selects the messages the user has not read: messages of others
+// after the user's read receipt. Admins are not participants of a thread, so
+// for them only threads they have opened before are counted.
+func (r *MessageRepository) unreadQuery(request *model.SearchThreadRequest) *gorm.DB {
+	query := r.DB.Model(&entity.Message{}).
+		Joins("JOIN message_threads ON message_threads.id = messages.thread_id AND message_threads.deleted_at IS NULL").
+		Joins("LEFT JOIN message_thread_reads ON message_thread_reads.thread_id = messages.thread_id AND message_thread_reads.user_id = ?", request.UserID).
+		Where("messages.sender_id <> ?", request.UserID).
+		Where("messages.id > COALESCE(message_thread_reads.last_read_message_id, 0)")
+	if request.Role == "admin" {
+		query = query.Where("message_thread_reads.id IS NOT NULL")
+	}
+	return r.participantScope(query, request)
+}
+
+func (r *MessageRepository) participantScope(query *gorm.DB, request *model.SearchThreadRequest) *gorm.DB {
+	switch request.Role {
+	case "admin":
+		return query
+	case "seller":
+		return query.Where("message_threads.store_id = ?", request.StoreID)
+	default:
+		return query.Where("message_threads.buyer_id = ?", request.UserID)
+	}
+}
+
+func (r *MessageRepository) preloadThread(query *gorm.DB) *gorm.DB {
+	return query.Preload("Order").Preload("Product").Preload("Buyer").Preload("Store")
+}
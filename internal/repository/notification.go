@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository struct {
+	DB *gorm.DB
+}
+
+func NewNotificationRepository(DB *gorm.DB) interfaces.NotificationRepository {
+	return &NotificationRepository{DB: DB}
+}
+
+func (r *NotificationRepository) CreateNotifications(db *gorm.DB, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return db.Create(&notifications).Error
+}
+
+func (r *NotificationRepository) GetNotifications(request *model.GetNotificationsRequest) ([]entity.Notification, int64, error) {
+	query := r.DB.Model(&entity.Notification{}).Where("user_id = ?", request.UserID)
+	if request.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []entity.Notification
+	if err := query.Order("id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *NotificationRepository) FindNotificationByUUID(userID uint, notificationUUID string) (*entity.Notification, error) {
+	var notification entity.Notification
+	if err := r.DB.Where("notification_uuid = ? AND user_id = ?", notificationUUID, userID).Take(&notification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepository) UpdateNotification(notification *entity.Notification) error {
+	return r.DB.Save(notification).Error
+}
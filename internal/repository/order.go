@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository struct {
@@ -50,8 +54,102 @@ func (r *OrderRepository) UpdateOrder(order *entity.Order) error {
 	return r.DB.Save(order).Error
 }
 
-func (r *OrderRepository) CreateOrder(order *entity.Order) error {
-	return r.DB.Create(order).Error
+func (r *OrderRepository) CreateOrder(db *gorm.DB, order *entity.Order) error {
+	return db.Create(order).Error
+}
+
+// FindUnpaidOrders returns pending orders placed before createdBefore, oldest
+// first, with their items. With unremindedOnly set, orders whose buyer was
+// already reminded to pay are skipped.
+func (r *OrderRepository) FindUnpaidOrders(db *gorm.DB, createdBefore time.Time, unremindedOnly bool, limit int) ([]entity.Order, error) {
+	query := db.Preload("Items").
+		Where("status = ? AND created_at <= ?", "pending", createdBefore)
+	if unremindedOnly {
+		query = query.Where("payment_reminder_sent_at IS NULL")
+	}
+	var orders []entity.Order
+	err := query.Order("created_at ASC").Limit(limit).Find(&orders).Error
+	return orders, err
+}
+
+// FindDeliveredOrders returns orders that have been in the delivered status
+// since before deliveredBefore, according to the status history. With
+// unremindedOnly set, orders whose buyer was already reminded are skipped.
+func (r *OrderRepository) FindDeliveredOrders(db *gorm.DB, deliveredBefore time.Time, unremindedOnly bool, limit int) ([]entity.Order, error) {
+	query := db.Select("orders.*").
+		Joins("JOIN order_status_history ON order_status_history.order_id = orders.id AND order_status_history.machine = ? AND order_status_history.to_status = ?", "order", "delivered").
+		Where("orders.status = ? AND order_status_history.created_at <= ?", "delivered", deliveredBefore)
+	if unremindedOnly {
+		query = query.Where("orders.completion_reminder_sent_at IS NULL")
+	}
+	var orders []entity.Order
+	err := query.Order("order_status_history.created_at ASC").Limit(limit).Find(&orders).Error
+	return orders, err
+}
+
+// LockOrder locks the order row until the surrounding transaction ends, so
+// changes to its items, returns and refunds are serialized.
+func (r *OrderRepository) LockOrder(db *gorm.DB, orderID uint) error {
+	var order entity.Order
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Take(&order, orderID).Error
+}
+
+// CancelOrderItem stores a cancelled item together with the new order totals
+// and voucher, and the new payment amount while the payment is still pending.
+// Repriced items, which lost the order's voucher, are stored without their
+// voucher discount lines. The caller must hold the order lock.
+func (r *OrderRepository) CancelOrderItem(db *gorm.DB, order *entity.Order, item *entity.OrderItem, repriced []entity.OrderItem) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.OrderItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"status":       item.Status,
+			"cancelled_at": item.CancelledAt,
+		}).Error; err != nil {
+			return err
+		}
+		for _, repricedItem := range repriced {
+			if err := tx.Model(&entity.OrderItem{}).Where("id = ?", repricedItem.ID).Updates(map[string]interface{}{
+				"total_price":     repricedItem.TotalPrice,
+				"discount_amount": repricedItem.DiscountAmount,
+				"tax_amount":      repricedItem.TaxAmount,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("order_item_id = ? AND voucher_id IS NOT NULL", repricedItem.ID).Delete(&entity.OrderItemDiscount{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&entity.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+			"total_price":     order.TotalPrice,
+			"tax_amount":      order.TaxAmount,
+			"discount_amount": order.DiscountAmount,
+			"voucher_code":    order.VoucherCode,
+		}).Error; err != nil {
+			return err
+		}
+		if order.Payment != nil && order.Payment.Status == "pending" {
+			return tx.Model(&entity.Payment{}).Where("id = ? AND status = ?", order.Payment.ID, "pending").
+				Update("amount", order.Payment.Amount).Error
+		}
+		return nil
+	})
+}
+
+// MarkPaymentReminderSent flags the payment reminder of an order as sent. It
+// reports false when it was already flagged, so a reminder goes out once.
+func (r *OrderRepository) MarkPaymentReminderSent(db *gorm.DB, orderID uint, sentAt time.Time) (bool, error) {
+	result := db.Model(&entity.Order{}).
+		Where("id = ? AND payment_reminder_sent_at IS NULL", orderID).
+		Update("payment_reminder_sent_at", sentAt)
+	return result.RowsAffected == 1, result.Error
+}
+
+// MarkCompletionReminderSent flags the completion reminder of an order as
+// sent. It reports false when it was already flagged.
+func (r *OrderRepository) MarkCompletionReminderSent(db *gorm.DB, orderID uint, sentAt time.Time) (bool, error) {
+	result := db.Model(&entity.Order{}).
+		Where("id = ? AND completion_reminder_sent_at IS NULL", orderID).
+		Update("completion_reminder_sent_at", sentAt)
+	return result.RowsAffected == 1, result.Error
 }
 
 func (r *OrderRepository) GetOrdersByBuyer(request *model.SearchOrderRequest) ([]entity.Order, int64, error) {
@@ -63,7 +161,7 @@ func (r *OrderRepository) GetOrdersByBuyer(request *model.SearchOrderRequest) ([
     }
     
     var orders []entity.Order
-    if err := filteredQuery.Offset((request.Page - 1) * request.Limit).Limit(request.Limit).Find(&orders).Error; err != nil {
+    if err := filteredQuery.Order("created_at DESC").Order("id DESC").Offset((request.Page - 1) * request.Limit).Limit(request.Limit).Find(&orders).Error; err != nil {
         return nil, 0, err
     }
     
@@ -76,16 +174,64 @@ func (r *OrderRepository) FilterOrders(request *model.SearchOrderRequest) func(d
 		if status := request.Status; status != "" {
 			db = db.Where("status = ?", status)
 		}
+		return db.Scopes(r.FilterOrderList(&request.OrderFilter))
+	}
+}
+
+// FilterOrderList applies the date range, total range, payment method and
+// free-text filters of the order lists. The owner and date filters narrow the
+// orders through idx_orders_user_created, idx_orders_store_created and
+// idx_orders_created_at; search and payment method are then checked per
+// remaining order through the order_items and payments indexes on order_id.
+// Search matches the product name snapshot of the items, so deleted products
+// are still found.
+func (r *OrderRepository) FilterOrderList(filter *model.OrderFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.StartDate != "" {
+			if start, err := time.ParseInLocation("2006-01-02", filter.StartDate, time.Local); err == nil {
+				db = db.Where("orders.created_at >= ?", start)
+			}
+		}
+		if filter.EndDate != "" {
+			if end, err := time.ParseInLocation("2006-01-02", filter.EndDate, time.Local); err == nil {
+				db = db.Where("orders.created_at < ?", end.AddDate(0, 0, 1))
+			}
+		}
+		if filter.MinTotal != nil || filter.MaxTotal != nil {
+			db = db.Where("orders.currency = ?", filter.Currency)
+		}
+		if filter.MinTotal != nil {
+			db = db.Where("orders.total_price >= ?", *filter.MinTotal)
+		}
+		if filter.MaxTotal != nil {
+			db = db.Where("orders.total_price <= ?", *filter.MaxTotal)
+		}
+		if filter.PaymentMethod != "" {
+			db = db.Where("EXISTS (SELECT 1 FROM payments WHERE payments.order_id = orders.id AND payments.method = ? AND payments.deleted_at IS NULL)", filter.PaymentMethod)
+		}
+		if search := strings.TrimSpace(filter.Search); search != "" {
+			pattern := "%" + escapeLike(search) + "%"
+			db = db.Where(`(orders.order_uuid LIKE ?
+				OR EXISTS (SELECT 1 FROM users WHERE users.id = orders.user_id AND users.name LIKE ?)
+				OR EXISTS (SELECT 1 FROM order_items
+					WHERE order_items.order_id = orders.id AND order_items.deleted_at IS NULL AND order_items.product_name LIKE ?))`,
+				escapeLike(search)+"%", pattern, pattern)
+		}
 		return db
 	}
 }
 
+// escapeLike escapes the wildcards of a LIKE pattern, so they match literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *OrderRepository) GetOrderByIdByBuyer(request *model.GetOrderDetails) (*entity.Order, error) {
 	var order entity.Order
 	if err := r.DB.Preload("Items", func (db *gorm.DB) *gorm.DB {
 		return db.Order("order_items.created_at ASC")
 	}).
-    Preload("Items.Product").
+    Preload("Items.Discounts").
 	Preload("Payment").
 	Preload("Shipping").
 	Where(&entity.Order{
@@ -99,10 +245,6 @@ func (r *OrderRepository) GetOrderByIdByBuyer(request *model.GetOrderDetails) (*
 		return nil, err
 	}
 
-    for i := range order.Items {
-        order.Items[i].ProductName = order.Items[i].Product.ProductName
-    }
-    
 	return &order, nil
 }
 
@@ -110,30 +252,35 @@ func (r *OrderRepository) GetOrdersBySeller(request *model.SearchOrderRequestByS
     var orders []entity.Order
     var total int64
 
-    subquery := r.DB.Model(&entity.OrderItem{}).
-        Select("DISTINCT order_id").
-        Joins("JOIN products ON order_items.product_id = products.id").
-        Where("products.store_id = ?", request.StoreID)
-
     query := r.DB.Model(&entity.Order{}).
-        Preload("Items.Product").
+        Preload("Items").
         Preload("Payment").
         Preload("Shipping").
         Preload("User").
-        Where("id IN (?)", subquery)
+        Preload("Warehouse").
+        Where("orders.store_id = ?", request.StoreID)
 
     if request.Status != "" {
         query = query.Where("status = ?", request.Status)
     }
+    query = query.Scopes(r.FilterOrderList(&request.OrderFilter))
+    if request.CreatedBefore != nil {
+        query = query.Where("created_at <= ?", *request.CreatedBefore)
+    }
 
-    if request.SortDate != "" {
+    // id breaks ties between orders placed in the same instant, so pages
+    // never overlap
+    if request.SortDate == "asc" {
+        query = query.Order("created_at ASC").Order("id ASC")
+    } else {
+        query = query.Order("created_at DESC").Order("id DESC")
+    }
+    if after := request.After; after != nil {
         if request.SortDate == "asc" {
-            query = query.Order("created_at ASC")
+            query = query.Where("(orders.created_at > ? OR (orders.created_at = ? AND orders.id > ?))", after.CreatedAt, after.CreatedAt, after.ID)
         } else {
-            query = query.Order("created_at DESC")
+            query = query.Where("(orders.created_at < ? OR (orders.created_at = ? AND orders.id < ?))", after.CreatedAt, after.CreatedAt, after.ID)
         }
-    } else {
-        query = query.Order("created_at DESC")
     }
 
     if err := query.Count(&total).Error; err != nil {
@@ -150,16 +297,13 @@ func (r *OrderRepository) GetOrdersBySeller(request *model.SearchOrderRequestByS
 func (r *OrderRepository) GetOrderBySeller(order_uuid string, store_id uint) (*entity.Order, error) {
     var order entity.Order
 
-    if err := r.DB.Preload("Items.Product", func(db *gorm.DB) *gorm.DB {
-        return r.DB.Where("store_id = ?", store_id)
-    }).
+    if err := r.DB.Preload("Items").
+        Preload("Items.Discounts").
         Preload("Payment").
         Preload("Shipping").
         Preload("User").
-        Where("order_uuid = ?", order_uuid).
-        Joins("JOIN order_items ON order_items.order_id = orders.id").
-        Joins("JOIN products ON products.id = order_items.product_id").
-        Where("products.store_id = ?", store_id).
+        Preload("Warehouse").
+        Where("order_uuid = ? AND store_id = ?", order_uuid, store_id).
         Take(&order).Error; err != nil {
 
         if err == gorm.ErrRecordNotFound {
@@ -9,19 +9,21 @@ import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	eventmodel "github.com/abdisetiakawan/go-ecommerce/internal/model/event_model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 // OrderConsumerHandler implement interfaces.OrderConsumer dan sarama.ConsumerGroupHandler
 type OrderConsumerHandler struct {
-	db    *gorm.DB
-	kafka *helper.KafkaConsumer
+	db         *gorm.DB
+	kafka      *helper.KafkaConsumer
+	statusRepo interfaces.OrderStatusRepository
 }
 
 // Konstruktor
-func NewOrderConsumerHandler(db *gorm.DB, kafka *helper.KafkaConsumer) interfaces.OrderConsumer {
-	return &OrderConsumerHandler{db: db, kafka: kafka}
+func NewOrderConsumerHandler(db *gorm.DB, kafka *helper.KafkaConsumer, statusRepo interfaces.OrderStatusRepository) interfaces.OrderConsumer {
+	return &OrderConsumerHandler{db: db, kafka: kafka, statusRepo: statusRepo}
 }
 
 // ChangeOrderStatus mulai konsumsi topic
@@ -41,9 +43,18 @@ func (h *OrderConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 			logrus.WithError(err).Error("Failed to unmarshal order message")
 			continue
 		}
-		if err := h.db.Model(&entity.Order{}).
-			Where("id = ?", m.OrderID).
-			Update("status", m.Status).Error; err != nil {
+		actorType := m.ActorType
+		if actorType == "" {
+			actorType = "system"
+		}
+		if err := applyConsumedStatus(h.db, h.statusRepo, &entity.OrderStatusHistory{
+			OrderID:   m.OrderID,
+			Machine:   string(statemachine.Order),
+			ToStatus:  m.Status,
+			ActorType: actorType,
+			ActorID:   m.ActorID,
+			Reason:    m.Reason,
+		}); err != nil {
 			logrus.WithError(err).Error("Failed to update order status")
 		}
 		session.MarkMessage(msg, "")
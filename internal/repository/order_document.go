@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderDocumentRepository struct {
+	DB *gorm.DB
+}
+
+func NewOrderDocumentRepository(DB *gorm.DB) interfaces.OrderDocumentRepository {
+	return &OrderDocumentRepository{DB: DB}
+}
+
+func (r *OrderDocumentRepository) FindDocument(db *gorm.DB, orderID uint, documentType string) (*entity.OrderDocument, error) {
+	var document entity.OrderDocument
+	if err := db.Where("order_id = ? AND type = ?", orderID, documentType).Take(&document).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindDocumentForUpdate is FindDocument as a locking read, which sees the
+// documents committed by other transactions since the surrounding one began.
+func (r *OrderDocumentRepository) FindDocumentForUpdate(db *gorm.DB, orderID uint, documentType string) (*entity.OrderDocument, error) {
+	return r.FindDocument(db.Clauses(clause.Locking{Strength: "UPDATE"}), orderID, documentType)
+}
+
+// NextSequence returns the next document number of the store. The store row
+// stays locked until the surrounding transaction ends, so documents issued
+// concurrently get consecutive numbers.
+func (r *OrderDocumentRepository) NextSequence(db *gorm.DB, storeID uint, documentType string) (int, error) {
+	var store entity.Store
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Take(&store, storeID).Error; err != nil {
+		return 0, err
+	}
+	var last int
+	err := db.Model(&entity.OrderDocument{}).
+		Select("COALESCE(MAX(sequence), 0)").
+		Where("store_id = ? AND type = ?", storeID, documentType).
+		Scan(&last).Error
+	return last + 1, err
+}
+
+// CreateDocument stores an issued document. Documents are never updated.
+func (r *OrderDocumentRepository) CreateDocument(db *gorm.DB, document *entity.OrderDocument) error {
+	return db.Omit(clause.Associations).Create(document).Error
+}
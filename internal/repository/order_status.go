@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
+	"gorm.io/gorm"
+)
+
+type OrderStatusRepository struct {
+	DB *gorm.DB
+}
+
+func NewOrderStatusRepository(DB *gorm.DB) interfaces.OrderStatusRepository {
+	return &OrderStatusRepository{DB: DB}
+}
+
+// Transition checks the change against the state machine, moves the order,
+// payment or shipping row from FromStatus to ToStatus and records the change
+// in the status history, all or nothing. The update is conditional on the
+// current status, so when another request changed the row first
+// statemachine.ErrStaleStatus is returned. A change from the empty status only
+// records the creation of a row the caller has just inserted.
+func (r *OrderStatusRepository) Transition(db *gorm.DB, change *entity.OrderStatusHistory) error {
+	machine := statemachine.Machine(change.Machine)
+	if err := statemachine.Check(machine, change.FromStatus, change.ToStatus); err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if change.FromStatus != "" {
+			result := statusQuery(tx, machine, change.OrderID).
+				Where("status = ?", change.FromStatus).
+				Update("status", change.ToStatus)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return statemachine.ErrStaleStatus
+			}
+		}
+		return tx.Omit("Order").Create(change).Error
+	})
+}
+
+func (r *OrderStatusRepository) CurrentStatus(db *gorm.DB, machine statemachine.Machine, orderID uint) (string, error) {
+	var statuses []string
+	if err := statusQuery(db, machine, orderID).Limit(1).Pluck("status", &statuses).Error; err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", model.ErrNotFound
+	}
+	return statuses[0], nil
+}
+
+func (r *OrderStatusRepository) GetHistoryByOrderID(db *gorm.DB, orderID uint) ([]entity.OrderStatusHistory, error) {
+	var history []entity.OrderStatusHistory
+	err := db.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&history).Error
+	return history, err
+}
+
+// statusQuery selects the row holding the machine's status for an order.
+func statusQuery(db *gorm.DB, machine statemachine.Machine, orderID uint) *gorm.DB {
+	switch machine {
+	case statemachine.Payment:
+		return db.Model(&entity.Payment{}).Where("order_id = ?", orderID)
+	case statemachine.Shipping:
+		return db.Model(&entity.Shipping{}).Where("order_id = ?", orderID)
+	default:
+		return db.Model(&entity.Order{}).Where("id = ?", orderID)
+	}
+}
+
+// applyConsumedStatus moves a record to the status carried by a Kafka
+// message. A message repeating the current status is a replay and is
+// ignored; a transition the state machine does not allow is rejected.
+func applyConsumedStatus(db *gorm.DB, statusRepo interfaces.OrderStatusRepository, change *entity.OrderStatusHistory) error {
+	current, err := statusRepo.CurrentStatus(db, statemachine.Machine(change.Machine), change.OrderID)
+	if err != nil {
+		return err
+	}
+	if current == change.ToStatus {
+		return nil
+	}
+	change.FromStatus = current
+	return statusRepo.Transition(db, change)
+}
@@ -3,23 +3,27 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	eventmodel "github.com/abdisetiakawan/go-ecommerce/internal/model/event_model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentConsumerHandler struct {
-    db    *gorm.DB
-    kafka *helper.KafkaConsumer
+    db         *gorm.DB
+    kafka      *helper.KafkaConsumer
+    statusRepo interfaces.OrderStatusRepository
 }
 
-func NewPaymentConsumerHandler(db *gorm.DB, kafka *helper.KafkaConsumer) interfaces.PaymentConsumer {
-    return &PaymentConsumerHandler{db: db, kafka: kafka}
+func NewPaymentConsumerHandler(db *gorm.DB, kafka *helper.KafkaConsumer, statusRepo interfaces.OrderStatusRepository) interfaces.PaymentConsumer {
+    return &PaymentConsumerHandler{db: db, kafka: kafka, statusRepo: statusRepo}
 }
 
 func (h *PaymentConsumerHandler) CreatePayment(ctx context.Context) error {
@@ -34,6 +38,10 @@ func (h *PaymentConsumerHandler) CheckoutPayment(ctx context.Context) error {
     return h.kafka.Consume(ctx, []string{"checkout_payment_topic"}, h)
 }
 
+func (h *PaymentConsumerHandler) RefundPayment(ctx context.Context) error {
+    return h.kafka.Consume(ctx, []string{"refund_payment_topic"}, h)
+}
+
 // Sarama ConsumerGroupHandler interface implementation
 func (h *PaymentConsumerHandler) Setup(_ sarama.ConsumerGroupSession) error   { return nil }
 func (h *PaymentConsumerHandler) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
@@ -51,33 +59,124 @@ func (h *PaymentConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSessio
             payment := &entity.Payment{
                 PaymentUUID: paymentMessage.PaymentUUID,
                 Amount:      paymentMessage.Amount,
+                Currency:    paymentMessage.Currency,
+                DisplayCurrency: paymentMessage.DisplayCurrency,
+                ExchangeRate: paymentMessage.ExchangeRate,
                 Method:      paymentMessage.Method,
                 OrderID:     paymentMessage.OrderID,
                 Status:      paymentMessage.Status,
             }
-            if err := h.db.Create(payment).Error; err != nil {
+            err := h.db.Transaction(func(tx *gorm.DB) error {
+                if err := tx.Create(payment).Error; err != nil {
+                    return err
+                }
+                return h.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+                    OrderID:   payment.OrderID,
+                    Machine:   string(statemachine.Payment),
+                    ToStatus:  payment.Status,
+                    ActorType: "system",
+                    Reason:    "Payment created",
+                })
+            })
+            if err != nil {
                 logrus.WithError(err).Error("Failed to create payment")
                 continue
             }
 
         case "cancel_payment_topic":
-            if err := h.db.Model(&entity.Payment{}).
-                Where("order_id = ?", paymentMessage.OrderID).
-                Update("status", "cancelled").Error; err != nil {
+            if err := applyConsumedStatus(h.db, h.statusRepo, &entity.OrderStatusHistory{
+                OrderID:   paymentMessage.OrderID,
+                Machine:   string(statemachine.Payment),
+                ToStatus:  "cancelled",
+                ActorType: "system",
+                Reason:    "Order cancelled",
+            }); err != nil {
                 logrus.WithError(err).Error("Failed to cancel payment")
                 continue
             }
 
         case "checkout_payment_topic":
-            if err := h.db.Model(&entity.Payment{}).
-                Where("order_id = ?", paymentMessage.OrderID).
-                Update("status", paymentMessage.Status).Error; err != nil {
+            if err := applyConsumedStatus(h.db, h.statusRepo, &entity.OrderStatusHistory{
+                OrderID:   paymentMessage.OrderID,
+                Machine:   string(statemachine.Payment),
+                ToStatus:  paymentMessage.Status,
+                ActorType: "system",
+                Reason:    "Payment confirmed",
+            }); err != nil {
                 logrus.WithError(err).Error("Failed to checkout payment")
                 continue
             }
+
+        case "refund_payment_topic":
+            if err := h.db.Transaction(func(tx *gorm.DB) error {
+                return applyRefund(tx, h.statusRepo, paymentMessage.RefundUUID)
+            }); err != nil {
+                logrus.WithError(err).Error("Failed to refund payment")
+                continue
+            }
         }
 
         session.MarkMessage(msg, "")
     }
     return nil
-}
\ No newline at end of file
+}
+
+// applyRefund books a pending refund on the payment of its order: the
+// refunded amount grows, the payment moves to "partially_refunded" or
+// "refunded" and a return waiting for the refund is marked refunded. A refund
+// that was already booked is a replay and is ignored.
+func applyRefund(tx *gorm.DB, statusRepo interfaces.OrderStatusRepository, refundUUID string) error {
+    var refund entity.Refund
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("refund_uuid = ?", refundUUID).
+        Take(&refund).Error; err != nil {
+        return err
+    }
+    if refund.Status == "completed" {
+        return nil
+    }
+
+    var payment entity.Payment
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("order_id = ?", refund.OrderID).
+        Take(&payment).Error; err != nil {
+        return err
+    }
+    refunded := payment.RefundedAmount.Add(refund.Amount)
+    status := "partially_refunded"
+    if refunded >= payment.Amount {
+        status = "refunded"
+    }
+    if status != payment.Status {
+        if err := statusRepo.Transition(tx, &entity.OrderStatusHistory{
+            OrderID:    refund.OrderID,
+            Machine:    string(statemachine.Payment),
+            FromStatus: payment.Status,
+            ToStatus:   status,
+            ActorType:  "system",
+            Reason:     refund.Reason,
+        }); err != nil {
+            return err
+        }
+    }
+    if err := tx.Model(&payment).Update("refunded_amount", refunded).Error; err != nil {
+        return err
+    }
+
+    now := time.Now()
+    if err := tx.Model(&refund).Updates(map[string]interface{}{
+        "status":       "completed",
+        "completed_at": now,
+    }).Error; err != nil {
+        return err
+    }
+    if refund.ReferenceType == "return" {
+        return tx.Model(&entity.ReturnRequest{}).
+            Where("return_uuid = ? AND status = ?", refund.ReferenceID, "inspected").
+            Updates(map[string]interface{}{
+                "status":      "refunded",
+                "refunded_at": now,
+            }).Error
+    }
+    return nil
+}
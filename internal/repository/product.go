@@ -5,6 +5,7 @@ import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository struct {
@@ -15,8 +16,8 @@ func NewProductRepository(DB *gorm.DB) interfaces.ProductRepository {
 	return &ProductRepository{DB}
 }
 
-func (r *ProductRepository) CreateProduct(product *entity.Product) error {
-	return r.DB.Create(product).Error
+func (r *ProductRepository) CreateProduct(db *gorm.DB, product *entity.Product) error {
+	return db.Create(product).Error
 }
 
 func (r *ProductRepository) GetProducts(request *model.GetProductsRequest) ([]entity.Product, int64, error) {
@@ -38,6 +39,9 @@ func (r *ProductRepository) GetProducts(request *model.GetProductsRequest) ([]en
 	if request.Category != "" {
 		query = query.Where("category = ?", request.Category)
 	}
+	if request.PriceMin > 0 || request.PriceMax > 0 {
+		query = query.Where("products.currency = ?", request.PriceCurrency)
+	}
 	if request.PriceMin > 0 {
 		query = query.Where("price >= ?", request.PriceMin)
 	}
@@ -79,8 +83,10 @@ func (r *ProductRepository) GetProductById(userID uint, productUUID string) (*en
     return &product, nil
 }
 
-func (r *ProductRepository) UpdateProduct(product *entity.Product) error {
-	return r.DB.Save(product).Error
+// UpdateProduct saves the product details. Stock is never written here; it
+// only changes through the inventory ledger.
+func (r *ProductRepository) UpdateProduct(db *gorm.DB, product *entity.Product) error {
+	return db.Omit("Stock").Save(product).Error
 }
 
 func (r *ProductRepository) DeleteProduct(product *entity.Product) error {
@@ -97,4 +103,60 @@ func (r *ProductRepository) FindProductByID(productID uint) (entity.Product, err
 	var product entity.Product
 	err := r.DB.Where("id = ?", productID).First(&product).Error
 	return product, err
+}
+
+// FindProductsByUUIDsForUpdate loads every product in one query and locks the
+// rows until the surrounding transaction ends. Rows are locked in primary key
+// order so concurrent checkouts cannot deadlock on each other.
+func (r *ProductRepository) FindProductsByUUIDsForUpdate(db *gorm.DB, productUUIDs []string) ([]entity.Product, error) {
+	var products []entity.Product
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_uuid IN ?", productUUIDs).
+		Order("id ASC").
+		Find(&products).Error
+	return products, err
+}
+
+// FindProductsByUUIDs loads every product in one query with its store,
+// without locking.
+func (r *ProductRepository) FindProductsByUUIDs(db *gorm.DB, productUUIDs []string) ([]entity.Product, error) {
+	var products []entity.Product
+	err := db.Preload("Store").
+		Where("product_uuid IN ?", productUUIDs).
+		Order("id ASC").
+		Find(&products).Error
+	return products, err
+}
+
+// DecreaseStock atomically takes quantity units out of stock. The update only
+// matches while enough stock is left, so two buyers can never both claim the
+// last units of a product.
+func (r *ProductRepository) DecreaseStock(db *gorm.DB, productID uint, quantity int) error {
+	result := db.Model(&entity.Product{}).
+		Where("id = ? AND stock >= ?", productID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrInsufficientStock
+	}
+	return nil
+}
+
+func (r *ProductRepository) IncreaseStock(db *gorm.DB, productID uint, quantity int) error {
+	return db.Model(&entity.Product{}).
+		Where("id = ?", productID).
+		Update("stock", gorm.Expr("stock + ?", quantity)).Error
+}
+
+func (r *ProductRepository) FindProductWithStore(db *gorm.DB, productID uint) (*entity.Product, error) {
+	var product entity.Product
+	if err := db.Preload("Store").Where("id = ?", productID).Take(&product).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &product, nil
 }
\ No newline at end of file
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromotionRepository struct {
+	DB *gorm.DB
+}
+
+func NewPromotionRepository(DB *gorm.DB) interfaces.PromotionRepository {
+	return &PromotionRepository{DB: DB}
+}
+
+// CreatePromotion creates the promotion with its tiers and product scope. The
+// products themselves are not written.
+func (r *PromotionRepository) CreatePromotion(db *gorm.DB, promotion *entity.Promotion) error {
+	return db.Omit("Store", "Products.*").Create(promotion).Error
+}
+
+func (r *PromotionRepository) UpdatePromotion(promotion *entity.Promotion) error {
+	return r.DB.Omit(clause.Associations).Save(promotion).Error
+}
+
+func (r *PromotionRepository) FindPromotionByUUID(storeID uint, promotionUUID string) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	if err := r.preload(r.DB).Where("promotion_uuid = ? AND store_id = ?", promotionUUID, storeID).Take(&promotion).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// GetPromotions lists the promotions of a store, newest first.
+func (r *PromotionRepository) GetPromotions(storeID uint, request *model.SearchPromotionRequest) ([]entity.Promotion, int64, error) {
+	query := r.DB.Model(&entity.Promotion{}).Where("store_id = ?", storeID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var promotions []entity.Promotion
+	if err := r.preload(query).Order("id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&promotions).Error; err != nil {
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+// FindActivePromotions returns the active promotions of the stores that run
+// at the given time, oldest first.
+func (r *PromotionRepository) FindActivePromotions(db *gorm.DB, storeIDs []uint, at time.Time) ([]entity.Promotion, error) {
+	var promotions []entity.Promotion
+	err := r.preload(db).
+		Where("store_id IN ? AND is_active = ?", storeIDs, true).
+		Where("starts_at IS NULL OR starts_at <= ?", at).
+		Where("ends_at IS NULL OR ends_at > ?", at).
+		Order("id ASC").
+		Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *PromotionRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_quantity ASC")
+	}).Preload("Products")
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type RefundRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefundRepository(DB *gorm.DB) interfaces.RefundRepository {
+	return &RefundRepository{DB: DB}
+}
+
+func (r *RefundRepository) CreateRefund(db *gorm.DB, refund *entity.Refund) error {
+	return db.Omit("Order").Create(refund).Error
+}
+
+// SumRefundsByOrderID returns the total of every refund issued for the order,
+// including refunds the payment consumer has not booked yet.
+func (r *RefundRepository) SumRefundsByOrderID(db *gorm.DB, orderID uint) (money.Amount, error) {
+	var refunds []entity.Refund
+	if err := db.Select("amount").Where("order_id = ?", orderID).Find(&refunds).Error; err != nil {
+		return 0, err
+	}
+	var total money.Amount
+	for _, refund := range refunds {
+		total = total.Add(refund.Amount)
+	}
+	return total, nil
+}
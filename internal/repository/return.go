@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnRepository struct {
+	DB *gorm.DB
+}
+
+func NewReturnRepository(DB *gorm.DB) interfaces.ReturnRepository {
+	return &ReturnRepository{DB: DB}
+}
+
+func (r *ReturnRepository) CreateReturn(db *gorm.DB, request *entity.ReturnRequest) error {
+	return db.Omit("Order", "User", "Store", "Items.OrderItem").Create(request).Error
+}
+
+func (r *ReturnRepository) UpdateReturn(db *gorm.DB, request *entity.ReturnRequest) error {
+	return db.Omit(clause.Associations).Save(request).Error
+}
+
+func (r *ReturnRepository) FindReturnByBuyer(userID uint, returnUUID string) (*entity.ReturnRequest, error) {
+	return r.findReturn(r.DB.Where("return_uuid = ? AND user_id = ?", returnUUID, userID))
+}
+
+func (r *ReturnRepository) FindReturnBySeller(storeID uint, returnUUID string) (*entity.ReturnRequest, error) {
+	return r.findReturn(r.DB.Where("return_uuid = ? AND store_id = ?", returnUUID, storeID))
+}
+
+// FindReturnBySellerForUpdate loads the return and locks its row, so two
+// decisions of the seller on the same return are serialized.
+func (r *ReturnRepository) FindReturnBySellerForUpdate(db *gorm.DB, storeID uint, returnUUID string) (*entity.ReturnRequest, error) {
+	return r.findReturn(db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("return_uuid = ? AND store_id = ?", returnUUID, storeID))
+}
+
+// GetReturns lists the returns of a buyer, or of a store when StoreID is set,
+// newest first.
+func (r *ReturnRepository) GetReturns(request *model.SearchReturnRequest) ([]entity.ReturnRequest, int64, error) {
+	query := r.DB.Model(&entity.ReturnRequest{})
+	if request.StoreID != 0 {
+		query = query.Where("store_id = ?", request.StoreID)
+	} else {
+		query = query.Where("user_id = ?", request.UserID)
+	}
+	if request.Status != "" {
+		query = query.Where("status = ?", request.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var requests []entity.ReturnRequest
+	if err := r.preload(query).Order("id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+// ReturnedItems returns, per order item ID, the quantity and the amount
+// already covered by returns of the order that were not rejected.
+func (r *ReturnRepository) ReturnedItems(db *gorm.DB, orderID uint) (map[uint]entity.ReturnItem, error) {
+	var rows []entity.ReturnItem
+	err := db.Model(&entity.ReturnItem{}).
+		Select("return_items.order_item_id, SUM(return_items.quantity) AS quantity, SUM(return_items.amount) AS amount").
+		Joins("JOIN return_requests ON return_requests.id = return_items.return_request_id").
+		Where("return_requests.order_id = ? AND return_requests.status <> ?", orderID, "rejected").
+		Group("return_items.order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[uint]entity.ReturnItem, len(rows))
+	for _, row := range rows {
+		items[row.OrderItemID] = row
+	}
+	return items, nil
+}
+
+func (r *ReturnRepository) findReturn(db *gorm.DB) (*entity.ReturnRequest, error) {
+	var request entity.ReturnRequest
+	if err := r.preload(db).Take(&request).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *ReturnRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Order.Payment").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("return_items.id ASC")
+		}).
+		Preload("Items.OrderItem").
+		Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("return_photos.id ASC")
+		})
+}
@@ -9,17 +9,19 @@ import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	eventmodel "github.com/abdisetiakawan/go-ecommerce/internal/model/event_model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type ShippingConsumerHandler struct {
-    db    *gorm.DB
-    kafka *helper.KafkaConsumer
+    db         *gorm.DB
+    kafka      *helper.KafkaConsumer
+    statusRepo interfaces.OrderStatusRepository
 }
 
-func NewShippingConsumerHandler(db *gorm.DB, kafka *helper.KafkaConsumer) interfaces.ShippingConsumer {
-    return &ShippingConsumerHandler{db: db, kafka: kafka}
+func NewShippingConsumerHandler(db *gorm.DB, kafka *helper.KafkaConsumer, statusRepo interfaces.OrderStatusRepository) interfaces.ShippingConsumer {
+    return &ShippingConsumerHandler{db: db, kafka: kafka, statusRepo: statusRepo}
 }
 
 func (h *ShippingConsumerHandler) CreateShipping(ctx context.Context) error {
@@ -46,21 +48,45 @@ func (h *ShippingConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSessi
             shipping := &entity.Shipping{
                 ShippingUUID: shippingMessage.ShippingUUID,
                 OrderID:      shippingMessage.OrderID,
+                RecipientName: shippingMessage.RecipientName,
+                Phone:       shippingMessage.Phone,
                 Address:      shippingMessage.Address,
                 City:        shippingMessage.City,
+                District:    shippingMessage.District,
                 Province:    shippingMessage.Province,
                 PostalCode:  shippingMessage.PostalCode,
                 Status:      shippingMessage.Status,
+                Provider:    shippingMessage.Provider,
+                Service:     shippingMessage.Service,
+                Cost:        shippingMessage.Cost,
+                Weight:      shippingMessage.Weight,
+                EstimatedDays: shippingMessage.EstimatedDays,
             }
-            if err := h.db.Create(shipping).Error; err != nil {
+            err := h.db.Transaction(func(tx *gorm.DB) error {
+                if err := tx.Create(shipping).Error; err != nil {
+                    return err
+                }
+                return h.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+                    OrderID:   shipping.OrderID,
+                    Machine:   string(statemachine.Shipping),
+                    ToStatus:  shipping.Status,
+                    ActorType: "system",
+                    Reason:    "Shipping created",
+                })
+            })
+            if err != nil {
                 logrus.WithError(err).Error("Failed to create shipping")
                 continue
             }
 
         case "cancel_shipping_topic":
-            if err := h.db.Model(&entity.Shipping{}).
-                Where("order_id = ?", shippingMessage.OrderID).
-                Update("status", "cancelled").Error; err != nil {
+            if err := applyConsumedStatus(h.db, h.statusRepo, &entity.OrderStatusHistory{
+                OrderID:   shippingMessage.OrderID,
+                Machine:   string(statemachine.Shipping),
+                ToStatus:  "cancelled",
+                ActorType: "system",
+                Reason:    "Order cancelled",
+            }); err != nil {
                 logrus.WithError(err).Error("Failed to cancel shipping")
                 continue
             }
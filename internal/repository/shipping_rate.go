@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type ShippingRateRepository struct {
+	DB *gorm.DB
+}
+
+func NewShippingRateRepository(DB *gorm.DB) interfaces.ShippingRateRepository {
+	return &ShippingRateRepository{DB: DB}
+}
+
+func (r *ShippingRateRepository) CreateRates(db *gorm.DB, rates []entity.ShippingRate) error {
+	return db.Create(&rates).Error
+}
+
+func (r *ShippingRateRepository) UpdateRate(rate *entity.ShippingRate) error {
+	return r.DB.Save(rate).Error
+}
+
+func (r *ShippingRateRepository) DeleteRate(rate *entity.ShippingRate) error {
+	return r.DB.Delete(rate).Error
+}
+
+func (r *ShippingRateRepository) FindRateByUUID(rateUUID string) (*entity.ShippingRate, error) {
+	var rate entity.ShippingRate
+	if err := r.DB.Where("rate_uuid = ?", rateUUID).Take(&rate).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// GetRates lists the rate table ordered by service, route and weight band.
+func (r *ShippingRateRepository) GetRates(request *model.SearchShippingRateRequest) ([]entity.ShippingRate, int64, error) {
+	query := r.DB.Model(&entity.ShippingRate{})
+	if request.Service != "" {
+		query = query.Where("service = ?", request.Service)
+	}
+	if request.OriginProvince != "" {
+		query = query.Where("origin_province = ?", request.OriginProvince)
+	}
+	if request.DestinationProvince != "" {
+		query = query.Where("destination_province = ?", request.DestinationProvince)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rates []entity.ShippingRate
+	if err := query.Order("service ASC, origin_province ASC, destination_province ASC, min_weight ASC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&rates).Error; err != nil {
+		return nil, 0, err
+	}
+	return rates, total, nil
+}
+
+// FindMatchingRates returns the rates of every service that cover a parcel of
+// weight grams from the origin to the destination province, including the
+// rates that match any province. Provinces compare case-insensitively under
+// the default collation.
+func (r *ShippingRateRepository) FindMatchingRates(db *gorm.DB, origin, destination string, weight int) ([]entity.ShippingRate, error) {
+	var rates []entity.ShippingRate
+	err := db.Where("origin_province IN (?, '') AND destination_province IN (?, '')", origin, destination).
+		Where("min_weight <= ? AND (max_weight = 0 OR max_weight > ?)", weight, weight).
+		Order("service ASC, id ASC").
+		Find(&rates).Error
+	return rates, err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockSubscriptionRepository struct {
+	DB *gorm.DB
+}
+
+func NewStockSubscriptionRepository(DB *gorm.DB) interfaces.StockSubscriptionRepository {
+	return &StockSubscriptionRepository{DB: DB}
+}
+
+// UpsertSubscription creates the subscription, or re-arms an existing one that
+// was already notified so the buyer hears about the next restock as well.
+func (r *StockSubscriptionRepository) UpsertSubscription(subscription *entity.StockSubscription) error {
+	return r.DB.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"notified_at": nil,
+			"updated_at":  time.Now(),
+		}),
+	}).Create(subscription).Error
+}
+
+func (r *StockSubscriptionRepository) DeleteSubscription(userID uint, productID uint) (bool, error) {
+	result := r.DB.Unscoped().
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Delete(&entity.StockSubscription{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *StockSubscriptionRepository) FindPendingByProduct(db *gorm.DB, productID uint) ([]entity.StockSubscription, error) {
+	var subscriptions []entity.StockSubscription
+	err := db.Where("product_id = ? AND notified_at IS NULL", productID).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *StockSubscriptionRepository) MarkNotified(db *gorm.DB, subscriptionIDs []uint) error {
+	if len(subscriptionIDs) == 0 {
+		return nil
+	}
+	return db.Model(&entity.StockSubscription{}).
+		Where("id IN ?", subscriptionIDs).
+		Update("notified_at", time.Now()).Error
+}
@@ -37,6 +37,17 @@ func (r *StoreRepository) FindStoreByUserID(userID uint) (entity.Store, error) {
 	return store, nil
 }
 
+func (r *StoreRepository) FindStoreByID(db *gorm.DB, storeID uint) (*entity.Store, error) {
+	var store entity.Store
+	if err := db.First(&store, storeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrStoreNotFound
+		}
+		return nil, err
+	}
+	return &store, nil
+}
+
 func (r *StoreRepository) HasStore(db *gorm.DB, userID uint) (bool, error) {
     var count int64
     err := db.Model(&entity.Store{}).Where("user_id = ?", userID).Count(&count).Error
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type TaxRateRepository struct {
+	DB *gorm.DB
+}
+
+func NewTaxRateRepository(DB *gorm.DB) interfaces.TaxRateRepository {
+	return &TaxRateRepository{DB: DB}
+}
+
+func (r *TaxRateRepository) CreateRate(rate *entity.TaxRate) error {
+	return r.DB.Create(rate).Error
+}
+
+func (r *TaxRateRepository) UpdateRate(rate *entity.TaxRate) error {
+	return r.DB.Save(rate).Error
+}
+
+func (r *TaxRateRepository) FindRateByUUID(taxRateUUID string) (*entity.TaxRate, error) {
+	var rate entity.TaxRate
+	if err := r.DB.Where("tax_rate_uuid = ?", taxRateUUID).Take(&rate).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// GetRates lists the tax rates by category, the latest rate of a category
+// first.
+func (r *TaxRateRepository) GetRates(request *model.SearchTaxRateRequest) ([]entity.TaxRate, int64, error) {
+	query := r.DB.Model(&entity.TaxRate{})
+	if request.Category != "" {
+		query = query.Where("category = ?", request.Category)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rates []entity.TaxRate
+	if err := query.Order("category ASC, effective_from DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&rates).Error; err != nil {
+		return nil, 0, err
+	}
+	return rates, total, nil
+}
+
+// FindEffectiveRates returns the rates of the categories in effect at the
+// given time, the latest effective_from of a category first.
+func (r *TaxRateRepository) FindEffectiveRates(db *gorm.DB, categories []string, at time.Time) ([]entity.TaxRate, error) {
+	var rates []entity.TaxRate
+	err := db.Where("category IN ?", categories).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Order("category ASC, effective_from DESC, id DESC").
+		Find(&rates).Error
+	return rates, err
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VoucherRepository struct {
+	DB *gorm.DB
+}
+
+func NewVoucherRepository(DB *gorm.DB) interfaces.VoucherRepository {
+	return &VoucherRepository{DB: DB}
+}
+
+// CreateVoucher creates the voucher with its product scope. The products
+// themselves are not written. It fails with model.ErrConflict when another
+// voucher already has the code.
+func (r *VoucherRepository) CreateVoucher(db *gorm.DB, voucher *entity.Voucher) error {
+	err := db.Omit("Store", "Products.*").Create(voucher).Error
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		return model.ErrConflict
+	}
+	return err
+}
+
+// UpdateVoucher writes the settings of a voucher that can be edited. The
+// used count is left alone, so an edit never overwrites redemptions counted
+// while it was made.
+func (r *VoucherRepository) UpdateVoucher(voucher *entity.Voucher) error {
+	return r.DB.Model(&entity.Voucher{}).Where("id = ?", voucher.ID).Updates(map[string]interface{}{
+		"min_spend":      voucher.MinSpend,
+		"usage_limit":    voucher.UsageLimit,
+		"per_user_limit": voucher.PerUserLimit,
+		"starts_at":      voucher.StartsAt,
+		"ends_at":        voucher.EndsAt,
+		"is_active":      voucher.IsActive,
+	}).Error
+}
+
+func (r *VoucherRepository) CodeExists(code string) (bool, error) {
+	var count int64
+	err := r.DB.Unscoped().Model(&entity.Voucher{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// scope restricts a query to the platform vouchers when storeID is nil and to
+// the vouchers of the store otherwise.
+func (r *VoucherRepository) scope(db *gorm.DB, storeID *uint) *gorm.DB {
+	if storeID == nil {
+		return db.Where("store_id IS NULL")
+	}
+	return db.Where("store_id = ?", *storeID)
+}
+
+func (r *VoucherRepository) FindVoucherByUUID(storeID *uint, voucherUUID string) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	if err := r.scope(r.DB, storeID).Preload("Products").Where("voucher_uuid = ?", voucherUUID).Take(&voucher).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+// GetVouchers lists the platform vouchers or the vouchers of a store, newest
+// first.
+func (r *VoucherRepository) GetVouchers(storeID *uint, request *model.SearchVoucherRequest) ([]entity.Voucher, int64, error) {
+	query := r.scope(r.DB.Model(&entity.Voucher{}), storeID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var vouchers []entity.Voucher
+	if err := query.Preload("Products").Order("id DESC").
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&vouchers).Error; err != nil {
+		return nil, 0, err
+	}
+	return vouchers, total, nil
+}
+
+// FindVoucherByCodeForUpdate loads a voucher with its product scope and locks
+// it until the surrounding transaction ends, so concurrent orders using the
+// voucher are checked against its limits one after another.
+func (r *VoucherRepository) FindVoucherByCodeForUpdate(db *gorm.DB, code string) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Products").Where("code = ?", code).Take(&voucher).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *VoucherRepository) CountRedemptions(db *gorm.DB, voucherID uint, userID uint) (int64, error) {
+	var count int64
+	err := db.Model(&entity.VoucherRedemption{}).Where("voucher_id = ? AND user_id = ?", voucherID, userID).Count(&count).Error
+	return count, err
+}
+
+// Redeem records the use of a voucher and counts it. The count is a
+// conditional update that fails with model.ErrConflict once the usage limit
+// is reached.
+func (r *VoucherRepository) Redeem(db *gorm.DB, redemption *entity.VoucherRedemption) error {
+	result := db.Model(&entity.Voucher{}).
+		Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", redemption.VoucherID).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrConflict
+	}
+	return db.Omit(clause.Associations).Create(redemption).Error
+}
+
+// Release deletes the voucher use of an order and gives it back to the
+// voucher. Orders without a voucher are left alone.
+func (r *VoucherRepository) Release(db *gorm.DB, orderID uint) error {
+	var redemption entity.VoucherRedemption
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderID).Take(&redemption).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if err := db.Delete(&redemption).Error; err != nil {
+		return err
+	}
+	return db.Model(&entity.Voucher{}).
+		Where("id = ? AND used_count > 0", redemption.VoucherID).
+		Update("used_count", gorm.Expr("used_count - 1")).Error
+}
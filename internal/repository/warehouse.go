@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WarehouseRepository struct {
+	DB *gorm.DB
+}
+
+func NewWarehouseRepository(DB *gorm.DB) interfaces.WarehouseRepository {
+	return &WarehouseRepository{DB: DB}
+}
+
+func (r *WarehouseRepository) CreateWarehouse(warehouse *entity.Warehouse) error {
+	return r.DB.Create(warehouse).Error
+}
+
+func (r *WarehouseRepository) UpdateWarehouse(warehouse *entity.Warehouse) error {
+	return r.DB.Save(warehouse).Error
+}
+
+func (r *WarehouseRepository) FindWarehousesByStore(db *gorm.DB, storeID uint, activeOnly bool) ([]entity.Warehouse, error) {
+	query := db.Where("store_id = ?", storeID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var warehouses []entity.Warehouse
+	err := query.Order("priority DESC, id ASC").Find(&warehouses).Error
+	return warehouses, err
+}
+
+func (r *WarehouseRepository) FindWarehouseByUUID(storeID uint, warehouseUUID string) (*entity.Warehouse, error) {
+	var warehouse entity.Warehouse
+	if err := r.DB.Where("warehouse_uuid = ? AND store_id = ?", warehouseUUID, storeID).Take(&warehouse).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.ErrNotFound
+		}
+		return nil, err
+	}
+	return &warehouse, nil
+}
+
+// FindStocksForUpdate locks the stock rows of the given products in the given
+// warehouses until the surrounding transaction ends.
+func (r *WarehouseRepository) FindStocksForUpdate(db *gorm.DB, warehouseIDs []uint, productIDs []uint) ([]entity.WarehouseStock, error) {
+	var stocks []entity.WarehouseStock
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("warehouse_id IN ? AND product_id IN ?", warehouseIDs, productIDs).
+		Order("id ASC").
+		Find(&stocks).Error
+	return stocks, err
+}
+
+// ChangeStock applies a signed quantity to the stock of a product in a
+// warehouse. Decrements only succeed while enough stock is left, increments
+// create the stock row on first use.
+func (r *WarehouseRepository) ChangeStock(db *gorm.DB, warehouseID uint, productID uint, quantity int) error {
+	if quantity < 0 {
+		result := db.Model(&entity.WarehouseStock{}).
+			Where("warehouse_id = ? AND product_id = ? AND stock >= ?", warehouseID, productID, -quantity).
+			Update("stock", gorm.Expr("stock + ?", quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrInsufficientStock
+		}
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"stock": gorm.Expr("stock + ?", quantity),
+		}),
+	}).Create(&entity.WarehouseStock{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Stock:       quantity,
+	}).Error
+}
+
+func (r *WarehouseRepository) GetStocksByProduct(productID uint) ([]entity.WarehouseStock, error) {
+	var stocks []entity.WarehouseStock
+	err := r.DB.Preload("Warehouse").Where("product_id = ?", productID).Order("id ASC").Find(&stocks).Error
+	return stocks, err
+}
@@ -0,0 +1,73 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+// RateTableName is the provider name of options quoted from the rate table.
+const RateTableName = "table"
+
+// RateTable quotes parcels from the shipping rate table, one option per
+// service.
+type RateTable struct {
+	db    *gorm.DB
+	rates repo.ShippingRateRepository
+}
+
+func NewRateTable(db *gorm.DB, rates repo.ShippingRateRepository) Provider {
+	return &RateTable{db: db, rates: rates}
+}
+
+func (t *RateTable) Name() string {
+	return RateTableName
+}
+
+// Quote returns the price of every service whose rates cover the parcel. When
+// several rows of a service match, the most specific one wins: a row naming
+// both provinces beats a row naming one, which beats a row naming none.
+func (t *RateTable) Quote(ctx context.Context, parcel *Parcel) ([]Quote, error) {
+	rates, err := t.rates.FindMatchingRates(t.db.WithContext(ctx), strings.TrimSpace(parcel.OriginProvince), strings.TrimSpace(parcel.DestinationProvince), parcel.Weight)
+	if err != nil {
+		return nil, err
+	}
+	best := make(map[string]*entity.ShippingRate)
+	var services []string
+	for i := range rates {
+		rate := &rates[i]
+		current, ok := best[rate.Service]
+		if !ok {
+			services = append(services, rate.Service)
+		}
+		if !ok || specificity(rate) > specificity(current) {
+			best[rate.Service] = rate
+		}
+	}
+	quotes := make([]Quote, 0, len(services))
+	for _, service := range services {
+		rate := best[service]
+		quotes = append(quotes, Quote{
+			Provider:      RateTableName,
+			Service:       rate.Service,
+			Cost:          rate.Cost,
+			Currency:      rate.Currency,
+			EstimatedDays: rate.EstimatedDays,
+		})
+	}
+	return quotes, nil
+}
+
+func specificity(rate *entity.ShippingRate) int {
+	score := 0
+	if rate.OriginProvince != "" {
+		score++
+	}
+	if rate.DestinationProvince != "" {
+		score++
+	}
+	return score
+}
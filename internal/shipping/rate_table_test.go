@@ -0,0 +1,96 @@
+package shipping
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestRateTableQuote(t *testing.T) {
+	rates := []entity.ShippingRate{
+		{Service: "regular", MinWeight: 0, MaxWeight: 1000, Cost: 10000},
+		{Service: "regular", MinWeight: 1000, MaxWeight: 5000, Cost: 20000},
+		{Service: "regular", MinWeight: 5000, Cost: 45000},
+		{Service: "regular", DestinationProvince: "Bali", MinWeight: 0, MaxWeight: 1000, Cost: 15000},
+		{Service: "regular", OriginProvince: "Jawa Barat", DestinationProvince: "Bali", MinWeight: 0, MaxWeight: 1000, Cost: 12000},
+		{Service: "express", MinWeight: 0, MaxWeight: 2000, Cost: 30000, Currency: "USD", EstimatedDays: 1},
+	}
+	tests := []struct {
+		name        string
+		origin      string
+		destination string
+		weight      int
+		want        map[string]money.Amount
+	}{
+		{"first band", "Jawa Barat", "Jawa Timur", 999, map[string]money.Amount{"regular": 10000, "express": 30000}},
+		{"band lower bound included", "Jawa Barat", "Jawa Timur", 1000, map[string]money.Amount{"regular": 20000, "express": 30000}},
+		{"band upper bound excluded", "Jawa Barat", "Jawa Timur", 2000, map[string]money.Amount{"regular": 20000}},
+		{"open last band", "Jawa Barat", "Jawa Timur", 50000, map[string]money.Amount{"regular": 45000}},
+		{"destination row beats any province", "Jawa Tengah", "Bali", 500, map[string]money.Amount{"regular": 15000, "express": 30000}},
+		{"both provinces beat one", " Jawa Barat ", "Bali", 500, map[string]money.Amount{"regular": 12000, "express": 30000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewRateTable(newDryRunDB(t), &fakeShippingRateRepository{rates: rates})
+			quotes, err := table.Quote(context.Background(), &Parcel{
+				OriginProvince:      tt.origin,
+				DestinationProvince: tt.destination,
+				Weight:              tt.weight,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make(map[string]money.Amount, len(quotes))
+			for _, quote := range quotes {
+				if quote.Provider != RateTableName {
+					t.Errorf("quote %s from provider %q, want %q", quote.Service, quote.Provider, RateTableName)
+				}
+				got[quote.Service] = quote.Cost
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("quotes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newDryRunDB returns a database that never connects, the rates come from
+// the fake repository.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// fakeShippingRateRepository matches rates the way the WHERE clause of
+// ShippingRateRepository.FindMatchingRates does.
+type fakeShippingRateRepository struct {
+	repo.ShippingRateRepository
+	rates []entity.ShippingRate
+}
+
+func (r *fakeShippingRateRepository) FindMatchingRates(db *gorm.DB, origin, destination string, weight int) ([]entity.ShippingRate, error) {
+	var rates []entity.ShippingRate
+	for _, rate := range r.rates {
+		if rate.OriginProvince != "" && !strings.EqualFold(rate.OriginProvince, origin) {
+			continue
+		}
+		if rate.DestinationProvince != "" && !strings.EqualFold(rate.DestinationProvince, destination) {
+			continue
+		}
+		if rate.MinWeight <= weight && (rate.MaxWeight == 0 || rate.MaxWeight > weight) {
+			rates = append(rates, rate)
+		}
+	}
+	return rates, nil
+}
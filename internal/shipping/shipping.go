@@ -0,0 +1,80 @@
+// Package shipping prices the delivery of parcels.
+//
+// Prices come from providers: the rate table maintained by admins, and
+// couriers that quote their own rates. Every provider implements Provider and
+// is registered when the application starts; an order can ship with any option
+// quoted by any provider.
+package shipping
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+// VolumetricDivisor converts the volume of a parcel in cubic centimetres to
+// its volumetric weight in kilograms, as most couriers do.
+const VolumetricDivisor = 6000
+
+// Item is a line of a parcel: Quantity units of a product weighing Weight
+// grams and measuring Length by Width by Height centimetres.
+type Item struct {
+	Weight   int
+	Length   int
+	Width    int
+	Height   int
+	Quantity int
+}
+
+// Parcel is a shipment from an origin to a destination address. Weight is the
+// billable weight in grams, see BillableWeight.
+type Parcel struct {
+	OriginCity            string
+	OriginProvince        string
+	OriginPostalCode      string
+	DestinationCity       string
+	DestinationProvince   string
+	DestinationPostalCode string
+	Weight                int
+}
+
+// Quote is a shipping option offered for a parcel.
+type Quote struct {
+	Provider      string
+	Service       string
+	Cost          money.Amount
+	Currency      string
+	EstimatedDays int
+}
+
+// Option identifies the quote among the options of a parcel, e.g.
+// "table:regular".
+func (q Quote) Option() string {
+	return q.Provider + ":" + q.Service
+}
+
+// Provider quotes the shipping options of a parcel. A provider that does not
+// serve the parcel returns no quotes and no error.
+type Provider interface {
+	// Name is the provider part of the options it quotes.
+	Name() string
+	Quote(ctx context.Context, parcel *Parcel) ([]Quote, error)
+}
+
+// BillableWeight returns the weight in grams couriers charge for the items:
+// their actual weight or, for bulky items, their volumetric weight, whichever
+// is higher.
+func BillableWeight(items []Item) int {
+	var weight, volume int64
+	for _, item := range items {
+		quantity := int64(item.Quantity)
+		weight += int64(item.Weight) * quantity
+		volume += int64(item.Length) * int64(item.Width) * int64(item.Height) * quantity
+	}
+	// grams = cm³ / VolumetricDivisor * 1000, rounded up
+	volumetric := (volume*1000 + VolumetricDivisor - 1) / VolumetricDivisor
+	if volumetric > weight {
+		return int(volumetric)
+	}
+	return int(weight)
+}
@@ -0,0 +1,27 @@
+package shipping
+
+import "testing"
+
+func TestBillableWeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []Item
+		want  int
+	}{
+		{"no items", nil, 0},
+		{"actual weight", []Item{{Weight: 1200, Length: 10, Width: 10, Height: 10, Quantity: 2}}, 2400},
+		// 40 x 30 x 20 cm is 24000 cm³, 4 kg volumetric
+		{"volumetric weight", []Item{{Weight: 500, Length: 40, Width: 30, Height: 20, Quantity: 1}}, 4000},
+		{"volumetric weight rounded up", []Item{{Weight: 1, Length: 1, Width: 1, Height: 7, Quantity: 1}}, 2},
+		{"summed over items", []Item{
+			{Weight: 300, Length: 20, Width: 15, Height: 10, Quantity: 3},
+			{Weight: 2000, Quantity: 1},
+		}, 2900},
+		{"no dimensions", []Item{{Weight: 750, Quantity: 4}}, 3000},
+	}
+	for _, tt := range tests {
+		if got := BillableWeight(tt.items); got != tt.want {
+			t.Errorf("%s: BillableWeight = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package statemachine holds the allowed status transitions of an order, its
+// payment, its shipping and its return requests.
+//
+// Every status change is checked here, in the HTTP use cases as well as in
+// the Kafka consumers, so a stale or replayed message can never move a record
+// backwards or skip a step. A transition from the empty status is the
+// creation of the record and is only allowed into the initial status.
+package statemachine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Machine names the record whose status changes.
+type Machine string
+
+const (
+	Order    Machine = "order"
+	Payment  Machine = "payment"
+	Shipping Machine = "shipping"
+	Return   Machine = "return"
+)
+
+var (
+	ErrInvalidTransition = errors.New("statemachine: invalid transition")
+	// ErrStaleStatus is returned when the record no longer has the status a
+	// transition started from, because another request changed it first.
+	ErrStaleStatus = errors.New("statemachine: status changed concurrently")
+)
+
+var initial = map[Machine]string{
+	Order:    "pending",
+	Payment:  "pending",
+	Shipping: "pending",
+	Return:   "requested",
+}
+
+var transitions = map[Machine]map[string][]string{
+	Order: {
+		"pending":   {"processed", "cancelled"},
+		"processed": {"shipped"},
+		"shipped":   {"delivered", "disputed"},
+		"delivered": {"completed", "disputed"},
+		"disputed":  {"completed"},
+	},
+	Payment: {
+		"pending":            {"paid", "cancelled"},
+		"paid":               {"partially_refunded", "refunded"},
+		"partially_refunded": {"refunded"},
+	},
+	Shipping: {
+		"pending": {"shipped", "cancelled"},
+		"shipped": {"delivered"},
+	},
+	Return: {
+		"requested": {"approved", "rejected"},
+		"approved":  {"inspected", "rejected"},
+		"inspected": {"refunded"},
+	},
+}
+
+// TransitionError describes a rejected transition.
+type TransitionError struct {
+	Machine Machine
+	From    string
+	To      string
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == "" {
+		return fmt.Sprintf("statemachine: %s cannot be created as %s", e.Machine, e.To)
+	}
+	return fmt.Sprintf("statemachine: %s cannot change from %s to %s", e.Machine, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// Initial returns the status a record of the machine is created with.
+func Initial(machine Machine) string {
+	return initial[machine]
+}
+
+// CanTransition reports whether the machine may move from one status to
+// another.
+func CanTransition(machine Machine, from, to string) bool {
+	if from == "" {
+		return to != "" && to == initial[machine]
+	}
+	for _, next := range transitions[machine][from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns a *TransitionError when the transition is not allowed.
+func Check(machine Machine, from, to string) error {
+	if !CanTransition(machine, from, to) {
+		return &TransitionError{Machine: machine, From: from, To: to}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package statemachine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInitial(t *testing.T) {
+	tests := []struct {
+		machine Machine
+		want    string
+	}{
+		{Order, "pending"},
+		{Payment, "pending"},
+		{Shipping, "pending"},
+		{Return, "requested"},
+		{Machine("invoice"), ""},
+	}
+	for _, tt := range tests {
+		if got := Initial(tt.machine); got != tt.want {
+			t.Errorf("Initial(%s) = %q, want %q", tt.machine, got, tt.want)
+		}
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		machine  Machine
+		from, to string
+		want     bool
+	}{
+		{Order, "", "pending", true},
+		{Order, "", "processed", false},
+		{Order, "", "", false},
+		{Order, "pending", "processed", true},
+		{Order, "pending", "cancelled", true},
+		{Order, "pending", "shipped", false},
+		{Order, "processed", "shipped", true},
+		{Order, "processed", "cancelled", false},
+		{Order, "shipped", "delivered", true},
+		{Order, "shipped", "disputed", true},
+		{Order, "shipped", "processed", false},
+		{Order, "delivered", "completed", true},
+		{Order, "delivered", "disputed", true},
+		{Order, "disputed", "completed", true},
+		{Order, "disputed", "delivered", false},
+		{Order, "completed", "disputed", false},
+		{Order, "cancelled", "pending", false},
+		{Order, "pending", "pending", false},
+
+		{Payment, "", "pending", true},
+		{Payment, "pending", "paid", true},
+		{Payment, "pending", "cancelled", true},
+		{Payment, "pending", "refunded", false},
+		{Payment, "paid", "partially_refunded", true},
+		{Payment, "paid", "refunded", true},
+		{Payment, "paid", "pending", false},
+		{Payment, "partially_refunded", "refunded", true},
+		{Payment, "partially_refunded", "paid", false},
+		{Payment, "refunded", "paid", false},
+
+		{Shipping, "", "pending", true},
+		{Shipping, "pending", "shipped", true},
+		{Shipping, "pending", "cancelled", true},
+		{Shipping, "pending", "delivered", false},
+		{Shipping, "shipped", "delivered", true},
+		{Shipping, "delivered", "shipped", false},
+
+		{Return, "", "requested", true},
+		{Return, "", "pending", false},
+		{Return, "requested", "approved", true},
+		{Return, "requested", "rejected", true},
+		{Return, "requested", "refunded", false},
+		{Return, "approved", "inspected", true},
+		{Return, "approved", "rejected", true},
+		{Return, "inspected", "refunded", true},
+		{Return, "inspected", "rejected", false},
+		{Return, "rejected", "approved", false},
+
+		{Machine("invoice"), "", "pending", false},
+		{Machine("invoice"), "pending", "paid", false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.machine, tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %q, %q) = %v, want %v", tt.machine, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		machine  Machine
+		from, to string
+		wantErr  string
+	}{
+		{Order, "pending", "processed", ""},
+		{Order, "", "pending", ""},
+		{Order, "completed", "pending", "statemachine: order cannot change from completed to pending"},
+		{Order, "", "shipped", "statemachine: order cannot be created as shipped"},
+		{Payment, "refunded", "paid", "statemachine: payment cannot change from refunded to paid"},
+		{Shipping, "unknown", "shipped", "statemachine: shipping cannot change from unknown to shipped"},
+		{Return, "requested", "unknown", "statemachine: return cannot change from requested to unknown"},
+	}
+	for _, tt := range tests {
+		err := Check(tt.machine, tt.from, tt.to)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("Check(%s, %q, %q) = %v, want nil", tt.machine, tt.from, tt.to, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Check(%s, %q, %q) = nil, want %q", tt.machine, tt.from, tt.to, tt.wantErr)
+			continue
+		}
+		if err.Error() != tt.wantErr {
+			t.Errorf("Check(%s, %q, %q) = %q, want %q", tt.machine, tt.from, tt.to, err, tt.wantErr)
+		}
+		if !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("Check(%s, %q, %q) does not wrap ErrInvalidTransition", tt.machine, tt.from, tt.to)
+		}
+		var transitionErr *TransitionError
+		if !errors.As(err, &transitionErr) || transitionErr.From != tt.from || transitionErr.To != tt.to || transitionErr.Machine != tt.machine {
+			t.Errorf("Check(%s, %q, %q) = %#v, want a *TransitionError of the transition", tt.machine, tt.from, tt.to, err)
+		}
+	}
+}
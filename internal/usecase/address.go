@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type AddressUseCase struct {
+	db          *gorm.DB
+	val         *validator.Validate
+	addressRepo repo.AddressRepository
+	uuid        *helper.UUIDHelper
+}
+
+// NewAddressUseCase creates the use case that maintains the address books of
+// buyers.
+func NewAddressUseCase(db *gorm.DB, validate *validator.Validate, addressRepo repo.AddressRepository, uuid *helper.UUIDHelper) interfaces.AddressUseCase {
+	return &AddressUseCase{
+		db:          db,
+		val:         validate,
+		addressRepo: addressRepo,
+		uuid:        uuid,
+	}
+}
+
+// CreateAddress adds an address to the buyer's address book. The first
+// address becomes the default one, and a new default address replaces the
+// previous default.
+func (uc *AddressUseCase) CreateAddress(ctx context.Context, request *model.CreateAddressRequest) (*model.AddressResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.addressRepo.LockAddressBook(tx, request.UserID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	address := &entity.Address{
+		AddressUUID:   uc.uuid.Generate(),
+		UserID:        request.UserID,
+		Label:         request.Label,
+		RecipientName: request.RecipientName,
+		Phone:         request.Phone,
+		Address:       request.Address,
+		Province:      request.Province,
+		City:          request.City,
+		District:      request.District,
+		PostalCode:    request.PostalCode,
+		IsDefault:     request.IsDefault,
+	}
+	if !address.IsDefault {
+		if _, err := uc.addressRepo.FindDefaultAddress(tx, request.UserID); err == model.ErrNotFound {
+			address.IsDefault = true
+		} else if err != nil {
+			return nil, model.ErrInternalServer
+		}
+	} else if err := uc.addressRepo.ClearDefault(tx, request.UserID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := uc.addressRepo.CreateAddress(tx, address); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.AddressToResponse(address), nil
+}
+
+// GetAddresses lists the buyer's address book, the default address first.
+func (uc *AddressUseCase) GetAddresses(ctx context.Context, userID uint) ([]model.AddressResponse, error) {
+	addresses, err := uc.addressRepo.FindAddressesByUser(uc.db.WithContext(ctx), userID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	responses := make([]model.AddressResponse, len(addresses))
+	for i := range addresses {
+		responses[i] = *converter.AddressToResponse(&addresses[i])
+	}
+	return responses, nil
+}
+
+// GetAddress returns an address of the buyer's address book.
+func (uc *AddressUseCase) GetAddress(ctx context.Context, request *model.GetAddressRequest) (*model.AddressResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	address, err := uc.addressRepo.FindAddressByUUID(uc.db.WithContext(ctx), request.UserID, request.AddressUUID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.AddressToResponse(address), nil
+}
+
+// UpdateAddress changes an address of the buyer's address book, and makes it
+// the default address when asked to. Orders already placed keep the address
+// they were shipped to.
+func (uc *AddressUseCase) UpdateAddress(ctx context.Context, request *model.UpdateAddressRequest) (*model.AddressResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.addressRepo.LockAddressBook(tx, request.UserID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	address, err := uc.addressRepo.FindAddressByUUID(tx, request.UserID, request.AddressUUID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Label != "" {
+		address.Label = request.Label
+	}
+	if request.RecipientName != "" {
+		address.RecipientName = request.RecipientName
+	}
+	if request.Phone != "" {
+		address.Phone = request.Phone
+	}
+	if request.Address != "" {
+		address.Address = request.Address
+	}
+	if request.Province != "" {
+		address.Province = request.Province
+	}
+	if request.City != "" {
+		address.City = request.City
+	}
+	if request.District != nil {
+		address.District = *request.District
+	}
+	if request.PostalCode != "" {
+		address.PostalCode = request.PostalCode
+	}
+	if request.IsDefault && !address.IsDefault {
+		if err := uc.addressRepo.ClearDefault(tx, request.UserID); err != nil {
+			return nil, model.ErrInternalServer
+		}
+		address.IsDefault = true
+	}
+	if err := uc.addressRepo.UpdateAddress(tx, address); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.AddressToResponse(address), nil
+}
+
+// DeleteAddress removes an address from the buyer's address book. When it was
+// the default address, the most recently added remaining address becomes the
+// default.
+func (uc *AddressUseCase) DeleteAddress(ctx context.Context, request *model.GetAddressRequest) error {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.addressRepo.LockAddressBook(tx, request.UserID); err != nil {
+		return model.ErrInternalServer
+	}
+	address, err := uc.addressRepo.FindAddressByUUID(tx, request.UserID, request.AddressUUID)
+	if err != nil {
+		return err
+	}
+	if err := uc.addressRepo.DeleteAddress(tx, address); err != nil {
+		return model.ErrInternalServer
+	}
+	if address.IsDefault {
+		remaining, err := uc.addressRepo.FindAddressesByUser(tx, request.UserID)
+		if err != nil {
+			return model.ErrInternalServer
+		}
+		if len(remaining) > 0 {
+			remaining[0].IsDefault = true
+			if err := uc.addressRepo.UpdateAddress(tx, &remaining[0]); err != nil {
+				return model.ErrInternalServer
+			}
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// ResolveShippingAddress fills the shipping address of an order from the
+// buyer's address book: the address named by addressUUID, or the default
+// address when the order carries no inline address either. An order may not
+// name both. Without a default address the inline address is left empty, so
+// validating the order reports it missing.
+//
+// Errors:
+//
+//   - 400 Bad Request: if both an address UUID and an inline address are given.
+//   - 404 Not Found: if the address is not in the buyer's address book.
+func (uc *AddressUseCase) ResolveShippingAddress(ctx context.Context, db *gorm.DB, userID uint, addressUUID string, address *model.ShippingAddressRequest) error {
+	inline := *address != model.ShippingAddressRequest{}
+	var saved *entity.Address
+	var err error
+	switch {
+	case addressUUID != "" && inline:
+		return model.NewApiError(fiber.StatusBadRequest, "Pass either address_id or shipping_address, not both", nil)
+	case addressUUID != "":
+		saved, err = uc.addressRepo.FindAddressByUUID(db, userID, addressUUID)
+		if err == model.ErrNotFound {
+			return model.NewApiError(fiber.StatusNotFound, "Address not found", nil)
+		}
+	case !inline:
+		saved, err = uc.addressRepo.FindDefaultAddress(db, userID)
+		if err == model.ErrNotFound {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		return model.ErrInternalServer
+	}
+	*address = model.ShippingAddressRequest{
+		RecipientName: saved.RecipientName,
+		Phone:         saved.Phone,
+		Address:       saved.Address,
+		Province:      saved.Province,
+		City:          saved.City,
+		District:      saved.District,
+		PostalCode:    saved.PostalCode,
+	}
+	return nil
+}
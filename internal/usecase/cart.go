@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CartUseCase struct {
+	db          *gorm.DB
+	val         *validator.Validate
+	cartRepo    repo.CartRepository
+	productRepo repo.ProductRepository
+	checkout    interfaces.CheckoutUseCase
+	promotion   interfaces.PromotionUseCase
+	uuid        *helper.UUIDHelper
+}
+
+func NewCartUseCase(db *gorm.DB, validate *validator.Validate, cartRepo repo.CartRepository, productRepo repo.ProductRepository, checkout interfaces.CheckoutUseCase, promotion interfaces.PromotionUseCase, uuid *helper.UUIDHelper) interfaces.CartUseCase {
+	return &CartUseCase{
+		db:          db,
+		val:         validate,
+		cartRepo:    cartRepo,
+		productRepo: productRepo,
+		checkout:    checkout,
+		promotion:   promotion,
+		uuid:        uuid,
+	}
+}
+
+// GetCart returns the owner's cart with live prices, stock, warnings and the
+// running promotions. An owner without a cart gets an empty one.
+func (uc *CartUseCase) GetCart(ctx context.Context, request *model.GetCartRequest) (*model.CartResponse, error) {
+	if request.UserID == 0 && request.GuestToken == "" {
+		return converter.CartToResponse(&entity.Cart{}), nil
+	}
+	cart, err := uc.cartRepo.FindCart(uc.db.WithContext(ctx), request.CartOwner)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return converter.CartToResponse(&entity.Cart{}), nil
+		}
+		return nil, model.ErrInternalServer
+	}
+	return uc.cartResponse(ctx, cart)
+}
+
+// AddItem puts a product in the cart, creating the cart on first use. Adding
+// a product that is already in the cart increases its quantity and refreshes
+// the remembered price. A guest without a cart token is issued a new one,
+// returned in CartResponse.GuestToken.
+//
+// Stock is not reserved; a quantity above the available stock is accepted and
+// reported as a warning.
+func (uc *CartUseCase) AddItem(ctx context.Context, request *model.AddCartItemRequest) (*model.CartResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	product, err := uc.productRepo.FindProductByUUID(request.ProductUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Product not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	if request.UserID == 0 && request.GuestToken == "" {
+		request.GuestToken = uc.uuid.Generate()
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	cart, err := uc.findOrCreateCart(tx, request.CartOwner)
+	if err != nil {
+		return nil, err
+	}
+	item := findCartItem(cart, product.ID)
+	if item == nil {
+		item = &entity.CartItem{CartID: cart.ID, ProductID: product.ID}
+	}
+	item.Quantity += request.Quantity
+	item.PriceAtAdd = product.Price
+	item.Currency = product.Currency
+	if err := uc.cartRepo.SaveItem(tx, item); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return uc.GetCart(ctx, &model.GetCartRequest{CartOwner: request.CartOwner})
+}
+
+// UpdateItem sets the quantity of a product that is already in the cart. It
+// returns a 404 error if the cart or the item does not exist.
+func (uc *CartUseCase) UpdateItem(ctx context.Context, request *model.UpdateCartItemRequest) (*model.CartResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	cart, err := uc.cartRepo.FindCart(uc.db.WithContext(ctx), request.CartOwner)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Cart item not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	var item *entity.CartItem
+	for i := range cart.Items {
+		if cart.Items[i].Product.ProductUUID == request.ProductUUID {
+			item = &cart.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, model.NewApiError(fiber.StatusNotFound, "Cart item not found", nil)
+	}
+	item.Quantity = request.Quantity
+	if err := uc.cartRepo.SaveItem(uc.db.WithContext(ctx), item); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return uc.cartResponse(ctx, cart)
+}
+
+// RemoveItem takes a product out of the cart. It returns a 404 error if the
+// product is not in the cart.
+func (uc *CartUseCase) RemoveItem(ctx context.Context, request *model.RemoveCartItemRequest) (*model.CartResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	cart, err := uc.cartRepo.FindCart(uc.db.WithContext(ctx), request.CartOwner)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Cart item not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	product, err := uc.productRepo.FindProductByUUID(request.ProductUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Cart item not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	deleted, err := uc.cartRepo.DeleteItem(uc.db.WithContext(ctx), cart.ID, product.ID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if !deleted {
+		return nil, model.NewApiError(fiber.StatusNotFound, "Cart item not found", nil)
+	}
+	return uc.GetCart(ctx, &model.GetCartRequest{CartOwner: request.CartOwner})
+}
+
+// MergeGuestCart moves the items of a guest cart into the buyer's cart after
+// login. Quantities of products found in both carts are added up. The guest
+// cart is deleted afterwards. An unknown token is ignored.
+func (uc *CartUseCase) MergeGuestCart(ctx context.Context, userID uint, guestToken string) error {
+	if guestToken == "" {
+		return nil
+	}
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	guestCart, err := uc.cartRepo.FindCart(tx, model.CartOwner{GuestToken: guestToken})
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil
+		}
+		return model.ErrInternalServer
+	}
+	cart, err := uc.findOrCreateCart(tx, model.CartOwner{UserID: userID})
+	if err != nil {
+		return err
+	}
+	for _, guestItem := range guestCart.Items {
+		item := findCartItem(cart, guestItem.ProductID)
+		if item == nil {
+			item = &entity.CartItem{
+				CartID:     cart.ID,
+				ProductID:  guestItem.ProductID,
+				PriceAtAdd: guestItem.PriceAtAdd,
+				Currency:   guestItem.Currency,
+			}
+		}
+		item.Quantity += guestItem.Quantity
+		if err := uc.cartRepo.SaveItem(tx, item); err != nil {
+			return model.ErrInternalServer
+		}
+	}
+	if err := uc.cartRepo.DeleteCart(tx, guestCart); err != nil {
+		return model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// Checkout turns the buyer's cart into a checkout through
+// CheckoutUseCase.CreateCheckout, with one order per store and the live price
+// of every product, and empties the cart once the orders are created. An empty cart, or one that still
+// holds products that are no longer sold, returns an error.
+func (uc *CartUseCase) Checkout(ctx context.Context, request *model.CheckoutCartRequest) (*model.CheckoutResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	cart, err := uc.cartRepo.FindCart(uc.db.WithContext(ctx), model.CartOwner{UserID: request.UserID})
+	if err != nil && err != model.ErrNotFound {
+		return nil, model.ErrInternalServer
+	}
+	if cart == nil || len(cart.Items) == 0 {
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Cart is empty", nil)
+	}
+
+	items := make([]model.OrderItemRequest, len(cart.Items))
+	for i, item := range cart.Items {
+		if item.Product.ID == 0 {
+			return nil, model.NewApiError(fiber.StatusConflict, "Cart contains products that are no longer available", nil)
+		}
+		items[i] = model.OrderItemRequest{
+			ProductUUID: item.Product.ProductUUID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	checkout, err := uc.checkout.CreateCheckout(ctx, &model.CreateCheckout{
+		UserID:          request.UserID,
+		Items:           items,
+		AddressID:       request.AddressID,
+		ShippingAddress: request.ShippingAddress,
+		Payments:        request.Payments,
+		Currency:        request.Currency,
+		VoucherCode:     request.VoucherCode,
+		ShippingOption:  request.ShippingOption,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.cartRepo.ClearItems(uc.db.WithContext(ctx), cart.ID); err != nil {
+		logrus.WithError(err).Error("Failed to clear cart after checkout")
+	}
+	return checkout, nil
+}
+
+func (uc *CartUseCase) findOrCreateCart(tx *gorm.DB, owner model.CartOwner) (*entity.Cart, error) {
+	cart, err := uc.cartRepo.FindCart(tx, owner)
+	if err == nil {
+		return cart, nil
+	}
+	if err != model.ErrNotFound {
+		return nil, model.ErrInternalServer
+	}
+	cart = &entity.Cart{CartUUID: uc.uuid.Generate()}
+	if owner.UserID != 0 {
+		cart.UserID = &owner.UserID
+	} else {
+		cart.GuestToken = &owner.GuestToken
+	}
+	if err := uc.cartRepo.CreateCart(tx, cart); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return cart, nil
+}
+
+func findCartItem(cart *entity.Cart, productID uint) *entity.CartItem {
+	for i := range cart.Items {
+		if cart.Items[i].ProductID == productID {
+			return &cart.Items[i]
+		}
+	}
+	return nil
+}
+
+// cartResponse converts a cart to its response and takes the best running
+// promotion of each store off its items, the way PlaceOrder will. Totals are
+// net of the discounts.
+func (uc *CartUseCase) cartResponse(ctx context.Context, cart *entity.Cart) (*model.CartResponse, error) {
+	response := converter.CartToResponse(cart)
+	var storeIDs []uint
+	for _, item := range cart.Items {
+		if item.Product.ID != 0 {
+			storeIDs = append(storeIDs, item.Product.StoreID)
+		}
+	}
+	promotions, err := uc.promotion.ActivePromotions(ctx, uc.db.WithContext(ctx), storeIDs)
+	if err != nil {
+		return nil, err
+	}
+	basket := newPromotionBasket()
+	for _, item := range cart.Items {
+		basket.add(item.ProductID, item.Quantity)
+	}
+	for i := range cart.Items {
+		product := &cart.Items[i].Product
+		if product.ID == 0 {
+			continue
+		}
+		itemResponse := &response.Items[i]
+		promotion, discount := bestPromotion(promotions[product.StoreID], product, itemResponse.Quantity, itemResponse.Subtotal, basket)
+		if promotion == nil {
+			continue
+		}
+		itemResponse.Discount = discount
+		itemResponse.Promotion = converter.AppliedPromotionToResponse(promotion)
+		response.Totals[itemResponse.Currency] = response.Totals[itemResponse.Currency].Sub(discount)
+	}
+	return response, nil
+}
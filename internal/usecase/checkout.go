@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type CheckoutUseCase struct {
+	db           *gorm.DB
+	val          *validator.Validate
+	checkoutRepo repo.CheckoutRepository
+	productRepo  repo.ProductRepository
+	order        interfaces.OrderUseCase
+	voucher      interfaces.VoucherUseCase
+	address      interfaces.AddressUseCase
+	orderEvent   ordereventUC.OrderEventUseCase
+	uuid         *helper.UUIDHelper
+}
+
+func NewCheckoutUseCase(db *gorm.DB, validate *validator.Validate, checkoutRepo repo.CheckoutRepository, productRepo repo.ProductRepository, order interfaces.OrderUseCase, voucher interfaces.VoucherUseCase, address interfaces.AddressUseCase, orderEvent ordereventUC.OrderEventUseCase, uuid *helper.UUIDHelper) interfaces.CheckoutUseCase {
+	return &CheckoutUseCase{
+		db:           db,
+		val:          validate,
+		checkoutRepo: checkoutRepo,
+		productRepo:  productRepo,
+		order:        order,
+		voucher:      voucher,
+		address:      address,
+		orderEvent:   orderEvent,
+		uuid:         uuid,
+	}
+}
+
+// CreateCheckout splits a basket that may span several stores into one order
+// per store, all tied to a single checkout. Every product is locked up front,
+// then each store's order is placed through OrderUseCase.PlaceOrder, in store
+// ID order, inside the same transaction: either every order is created or
+// none is. When no display currency is given, the first store's currency is
+// used for the whole checkout so its total can be shown in one currency. A
+// voucher is applied to a single order: the order of the voucher's store, or
+// for platform vouchers the first order with items the voucher covers.
+func (uc *CheckoutUseCase) CreateCheckout(ctx context.Context, input *model.CreateCheckout) (*model.CheckoutResponse, error) {
+	input.Currency = strings.ToUpper(input.Currency)
+	if err := uc.address.ResolveShippingAddress(ctx, uc.db.WithContext(ctx), input.UserID, input.AddressID, &input.ShippingAddress); err != nil {
+		return nil, err
+	}
+	if err := helper.ValidateStruct(uc.val, input); err != nil {
+		return nil, err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	productUUIDs := make([]string, len(input.Items))
+	for i, item := range input.Items {
+		productUUIDs[i] = item.ProductUUID
+	}
+	products, err := uc.productRepo.FindProductsByUUIDsForUpdate(tx, productUUIDs)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	storeByProduct := make(map[string]uint, len(products))
+	productByUUID := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		storeByProduct[product.ProductUUID] = product.StoreID
+		productByUUID[product.ProductUUID] = product
+	}
+
+	itemsByStore := make(map[uint][]model.OrderItemRequest)
+	for _, item := range input.Items {
+		storeID, ok := storeByProduct[item.ProductUUID]
+		if !ok {
+			return nil, model.NewApiError(fiber.StatusNotFound, "One or more products not found", nil)
+		}
+		itemsByStore[storeID] = append(itemsByStore[storeID], item)
+	}
+	storeIDs := make([]uint, 0, len(itemsByStore))
+	for storeID := range itemsByStore {
+		storeIDs = append(storeIDs, storeID)
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+
+	var voucherStoreID uint
+	if input.VoucherCode != "" {
+		voucher, err := uc.voucher.FindVoucher(ctx, tx, input.VoucherCode)
+		if err != nil {
+			return nil, err
+		}
+	stores:
+		for _, storeID := range storeIDs {
+			if voucher.StoreID != nil && *voucher.StoreID != storeID {
+				continue
+			}
+			for _, item := range itemsByStore[storeID] {
+				product := productByUUID[item.ProductUUID]
+				if voucherCovers(voucher, &product) {
+					voucherStoreID = storeID
+					break stores
+				}
+			}
+		}
+		if voucherStoreID == 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Voucher does not apply to these products", nil)
+		}
+	}
+
+	checkout := &entity.Checkout{
+		CheckoutUUID:  uc.uuid.Generate(),
+		UserID:        input.UserID,
+		Status:        "pending",
+		PaymentMethod: input.Payments.PaymentMethod,
+		Currency:      input.Currency,
+	}
+	if checkout.Currency == "" {
+		checkout.Currency = money.DefaultCurrency
+	}
+	if err := uc.checkoutRepo.CreateCheckout(tx, checkout); err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	currency := input.Currency
+	events := make([]*evententity.OrderEvent, 0, len(storeIDs))
+	for _, storeID := range storeIDs {
+		voucherCode := ""
+		if storeID == voucherStoreID {
+			voucherCode = input.VoucherCode
+		}
+		order, orderEvent, err := uc.order.PlaceOrder(ctx, tx, &model.PlaceOrder{
+			UserID:          input.UserID,
+			StoreID:         storeID,
+			CheckoutID:      &checkout.ID,
+			Items:           itemsByStore[storeID],
+			ShippingAddress: input.ShippingAddress,
+			PaymentMethod:   input.Payments.PaymentMethod,
+			Currency:        currency,
+			VoucherCode:     voucherCode,
+			ShippingOption:  input.ShippingOption,
+		})
+		if err != nil {
+			return nil, err
+		}
+		currency = order.DisplayCurrency
+		checkout.Orders = append(checkout.Orders, *order)
+		events = append(events, orderEvent)
+	}
+
+	if checkout.Currency != currency {
+		checkout.Currency = currency
+		if err := uc.checkoutRepo.UpdateCheckout(tx, checkout); err != nil {
+			return nil, model.ErrInternalServer
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	for _, orderEvent := range events {
+		go uc.orderEvent.ProcessOrderEvent(ctx, orderEvent)
+	}
+
+	orders := make([]model.OrderResponse, len(checkout.Orders))
+	for i := range checkout.Orders {
+		response, err := placedOrderToResponse(&checkout.Orders[i], events[i])
+		if err != nil {
+			return nil, err
+		}
+		orders[i] = *response
+	}
+	return converter.CheckoutToResponse(checkout, orders), nil
+}
+
+// GetCheckout returns one of the buyer's checkouts with its orders.
+func (uc *CheckoutUseCase) GetCheckout(ctx context.Context, request *model.GetCheckoutRequest) (*model.CheckoutResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	checkout, err := uc.checkoutRepo.FindCheckoutByUUID(uc.db.WithContext(ctx), request.UserID, request.CheckoutUUID)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Checkout not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	return converter.CheckoutToResponse(checkout, converter.CheckoutOrdersToResponse(checkout.Orders)), nil
+}
+
+// PayCheckout pays every pending order of the checkout with one payment
+// through OrderUseCase.PayOrder. Orders cancelled in the meantime are skipped
+// and not charged. The checkout row is locked, so a checkout is never paid
+// twice; a paid checkout, or one without pending orders, returns a 409 error.
+func (uc *CheckoutUseCase) PayCheckout(ctx context.Context, request *model.PayCheckoutRequest) (*model.CheckoutResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	checkout, err := uc.checkoutRepo.FindCheckoutByUUIDForUpdate(tx, request.UserID, request.CheckoutUUID)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Checkout not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	if checkout.Status == "paid" {
+		return nil, model.NewApiError(fiber.StatusConflict, "Checkout is already paid", nil)
+	}
+
+	var paid money.Amount
+	var events []*evententity.OrderEvent
+	for i := range checkout.Orders {
+		order := &checkout.Orders[i]
+		if order.Status != "pending" {
+			continue
+		}
+		orderEvent, err := uc.order.PayOrder(ctx, tx, order)
+		if err != nil {
+			return nil, err
+		}
+		paid = paid.Add(order.TotalPrice.Convert(order.ExchangeRate))
+		events = append(events, orderEvent)
+	}
+	if len(events) == 0 {
+		return nil, model.NewApiError(fiber.StatusConflict, "Checkout has no orders awaiting payment", nil)
+	}
+
+	now := time.Now()
+	checkout.Status = "paid"
+	checkout.PaidAmount = paid
+	checkout.PaidAt = &now
+	if err := uc.checkoutRepo.UpdateCheckout(tx, checkout); err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	for _, orderEvent := range events {
+		go uc.orderEvent.CheckoutOrderEvent(ctx, orderEvent)
+	}
+	for i := range checkout.Orders {
+		if checkout.Orders[i].Status == "processed" && checkout.Orders[i].Payment != nil {
+			checkout.Orders[i].Payment.Status = "paid"
+		}
+	}
+	return converter.CheckoutToResponse(checkout, converter.CheckoutOrdersToResponse(checkout.Orders)), nil
+}
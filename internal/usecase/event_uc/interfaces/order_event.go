@@ -12,4 +12,6 @@ type OrderEventUseCase interface {
 	CancelOrderEvent(ctx context.Context, event *evententity.OrderEvent) error
 	CheckoutOrderEvent(ctx context.Context, event *evententity.OrderEvent) error
 	ChangeOrderStatusUC(ctx context.Context,event *evententity.OrderEvent) error
+	RefundPaymentEvent(ctx context.Context, event *evententity.OrderEvent) error
+	OrderUpdatedEvent(ctx context.Context, event *evententity.OrderEvent) error
 }
\ No newline at end of file
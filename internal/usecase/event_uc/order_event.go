@@ -55,6 +55,9 @@ func (uc *OrderEventUseCase) ProcessOrderEvent(ctx context.Context, event *event
 		PaymentUUID: paymentData.PaymentUUID,
 		OrderID:     event.OrderID,
 		Amount:      paymentData.Amount,
+		Currency:    paymentData.Currency,
+		DisplayCurrency: paymentData.DisplayCurrency,
+		ExchangeRate: paymentData.ExchangeRate,
 		Method:      paymentData.Method,
 		Status:      paymentData.Status,
 	}
@@ -73,11 +76,19 @@ func (uc *OrderEventUseCase) ProcessOrderEvent(ctx context.Context, event *event
 	shippingMessage := &eventmodel.ShippingMessage{
 		ShippingUUID: shippingData.ShippingUUID,
 		OrderID:      event.OrderID,
+		RecipientName: shippingData.RecipientName,
+		Phone:        shippingData.Phone,
 		Address:      shippingData.Address,
 		City:         shippingData.City,
+		District:     shippingData.District,
 		Province:     shippingData.Province,
 		PostalCode:   shippingData.PostalCode,
 		Status:       shippingData.Status,
+		Provider:     shippingData.Provider,
+		Service:      shippingData.Service,
+		Cost:         shippingData.Cost,
+		Weight:       shippingData.Weight,
+		EstimatedDays: shippingData.EstimatedDays,
 	}
 
 	err = retry.Do(func() error {
@@ -96,8 +107,9 @@ func (uc *OrderEventUseCase) ProcessOrderEvent(ctx context.Context, event *event
 }
 
 // RetryFailedEvents retrieves all order events with a "failed" status from the past 24 hours
-// and attempts to reprocess each event concurrently. For each event, it calls the
-// ProcessOrderEvent function. If an error occurs during processing, it logs the error
+// and attempts to reprocess each event concurrently. Each event is sent again by the function
+// that published it first, chosen by its event type. An event of a type nothing publishes is
+// left failed with an error naming the type. If an error occurs during processing, it logs the error
 // message. The function returns an error if the retrieval of failed events fails.
 
 func (uc *OrderEventUseCase) RetryFailedEvents(ctx context.Context) error {
@@ -108,7 +120,7 @@ func (uc *OrderEventUseCase) RetryFailedEvents(ctx context.Context) error {
 
 	for _, event := range events {
 		go func(e evententity.OrderEvent) {
-			if err := uc.ProcessOrderEvent(ctx, &e); err != nil {
+			if err := uc.retryEvent(ctx, &e); err != nil {
 				log.Println(err)
 			}
 		}(event)
@@ -116,6 +128,29 @@ func (uc *OrderEventUseCase) RetryFailedEvents(ctx context.Context) error {
 	return nil
 }
 
+func (uc *OrderEventUseCase) retryEvent(ctx context.Context, event *evententity.OrderEvent) error {
+	switch event.EventType {
+	case "order_created":
+		return uc.ProcessOrderEvent(ctx, event)
+	case "order_cancelled":
+		return uc.CancelOrderEvent(ctx, event)
+	case "payment_processed":
+		return uc.CheckoutOrderEvent(ctx, event)
+	case "shipping_processed", "order_delivered":
+		return uc.ChangeOrderStatusUC(ctx, event)
+	case "refund_issued":
+		return uc.RefundPaymentEvent(ctx, event)
+	case "order_updated":
+		return uc.OrderUpdatedEvent(ctx, event)
+	}
+	event.Status = "failed"
+	event.Error = fmt.Sprintf("Cannot retry event of type %s", event.EventType)
+	if err := uc.eventRepo.UpdateOrderEvent(event); err != nil {
+		return err
+	}
+	return fmt.Errorf("order event %s: unknown event type %s", event.EventUUID, event.EventType)
+}
+
 // CancelOrderEvent takes a context and an event entity as arguments.
 // It attempts to cancel the payment and shipping message by sending the message to kafka topic.
 // If the message sending process fails, it will retry up to 3 times with 2 seconds delay between each attempt.
@@ -217,6 +252,9 @@ func (uc *OrderEventUseCase) ChangeOrderStatusUC(ctx context.Context,event *even
 	orderMessage := &eventmodel.OrderMessage{
 		OrderID:     event.OrderID,
 		Status:      orderStatus.Status,
+		ActorType:   orderStatus.ActorType,
+		ActorID:     orderStatus.ActorID,
+		Reason:      orderStatus.Reason,
 	}
 
 	err := retry.Do(func() error {
@@ -232,4 +270,67 @@ func (uc *OrderEventUseCase) ChangeOrderStatusUC(ctx context.Context,event *even
 
 	event.Status = "completed"
 	return uc.eventRepo.UpdateOrderEvent(event)
-}
\ No newline at end of file
+}
+
+// RefundPaymentEvent takes a context and an event entity as arguments.
+// It attempts to send the refund carried by the event to kafka topic, so the payment consumer books it on the payment.
+// If the message sending process fails, it will retry up to 3 times with 2 seconds delay between each attempt.
+// If the retry also fails, it will update the status of the event entity to "failed" and return an error.
+// If the message sending process is successful, it will update the status of the event entity to "completed".
+// The function will return an error if there is an error when updating the event entity.
+func (uc *OrderEventUseCase) RefundPaymentEvent(ctx context.Context, event *evententity.OrderEvent) error {
+	var refund eventmodel.PaymentMessage
+	if err := json.Unmarshal(event.PaymentData, &refund); err != nil {
+		return err
+	}
+
+	paymentMessage := &eventmodel.PaymentMessage{
+		PaymentUUID: refund.PaymentUUID,
+		OrderID:     event.OrderID,
+		Amount:      refund.Amount,
+		Currency:    refund.Currency,
+		RefundUUID:  refund.RefundUUID,
+	}
+
+	err := retry.Do(func() error {
+		return uc.kafka.SendMessage(ctx, paymentMessage, "refund_payment_topic")
+	}, retry.Attempts(3), retry.Delay(2*time.Second))
+
+	if err != nil {
+		event.Status = "failed"
+		event.Error = fmt.Sprintf("Refund processing failed: %v", err)
+		uc.eventRepo.UpdateOrderEvent(event)
+		return err
+	}
+
+	event.Status = "completed"
+	return uc.eventRepo.UpdateOrderEvent(event)
+}
+
+// OrderUpdatedEvent takes a context and an event entity as arguments.
+// It attempts to publish the new state of an order whose items changed, such as its total price, to kafka topic.
+// If the message sending process fails, it will retry up to 3 times with 2 seconds delay between each attempt.
+// If the retry also fails, it will update the status of the event entity to "failed" and return an error.
+// If the message sending process is successful, it will update the status of the event entity to "completed".
+// The function will return an error if there is an error when updating the event entity.
+func (uc *OrderEventUseCase) OrderUpdatedEvent(ctx context.Context, event *evententity.OrderEvent) error {
+	var orderMessage eventmodel.OrderMessage
+	if err := json.Unmarshal(event.OrderData, &orderMessage); err != nil {
+		return err
+	}
+	orderMessage.OrderID = event.OrderID
+
+	err := retry.Do(func() error {
+		return uc.kafka.SendMessage(ctx, &orderMessage, "order_updated_topic")
+	}, retry.Attempts(3), retry.Delay(2*time.Second))
+
+	if err != nil {
+		event.Status = "failed"
+		event.Error = fmt.Sprintf("Order update failed: %v", err)
+		uc.eventRepo.UpdateOrderEvent(event)
+		return err
+	}
+
+	event.Status = "completed"
+	return uc.eventRepo.UpdateOrderEvent(event)
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type ExchangeRateUseCase struct {
+	db               *gorm.DB
+	val              *validator.Validate
+	exchangeRateRepo repo.ExchangeRateRepository
+}
+
+func NewExchangeRateUseCase(db *gorm.DB, validate *validator.Validate, exchangeRateRepo repo.ExchangeRateRepository) interfaces.ExchangeRateUseCase {
+	return &ExchangeRateUseCase{
+		db:               db,
+		val:              validate,
+		exchangeRateRepo: exchangeRateRepo,
+	}
+}
+
+// ImportRates stores a batch of exchange rates, replacing the current rate of
+// pairs that already exist. The whole batch is written in one transaction.
+//
+// Parameters:
+//   - ctx: context.Context - Context for the request.
+//   - request: *model.ImportExchangeRatesRequest - The rates to store.
+//
+// Returns:
+//   - []model.ExchangeRateResponse: The stored rates.
+//   - error: Validation error, or internal server error if the rates cannot be stored.
+func (uc *ExchangeRateUseCase) ImportRates(ctx context.Context, request *model.ImportExchangeRatesRequest) ([]model.ExchangeRateResponse, error) {
+	for i := range request.Rates {
+		request.Rates[i].BaseCurrency = strings.ToUpper(request.Rates[i].BaseCurrency)
+		request.Rates[i].QuoteCurrency = strings.ToUpper(request.Rates[i].QuoteCurrency)
+	}
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	rates := make([]entity.ExchangeRate, len(request.Rates))
+	for i, rate := range request.Rates {
+		rates[i] = entity.ExchangeRate{
+			BaseCurrency:  rate.BaseCurrency,
+			QuoteCurrency: rate.QuoteCurrency,
+			Rate:          rate.Rate,
+		}
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.exchangeRateRepo.UpsertRates(tx, rates); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	responses := make([]model.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = *converter.ExchangeRateToResponse(&rate)
+	}
+	return responses, nil
+}
+
+// GetRates lists the stored exchange rates, optionally limited to one base
+// currency.
+func (uc *ExchangeRateUseCase) GetRates(ctx context.Context, request *model.GetExchangeRatesRequest) ([]model.ExchangeRateResponse, error) {
+	request.BaseCurrency = strings.ToUpper(request.BaseCurrency)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	rates, err := uc.exchangeRateRepo.GetRates(request)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	responses := make([]model.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = *converter.ExchangeRateToResponse(&rate)
+	}
+	return responses, nil
+}
+
+// GetRate returns how many units of currency to one unit of currency from
+// buys. The same currency always converts at 1. A pair that is only stored in
+// the opposite direction is inverted. When no rate is known, a 422 error is
+// returned.
+func (uc *ExchangeRateUseCase) GetRate(ctx context.Context, db *gorm.DB, from, to string) (money.Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return money.RateOne, nil
+	}
+	rate, err := uc.exchangeRateRepo.FindRate(db.WithContext(ctx), from, to)
+	if err != nil {
+		if err == model.ErrNotFound {
+			return 0, model.NewApiError(fiber.StatusUnprocessableEntity, fmt.Sprintf("Exchange rate from %s to %s is not available", from, to), nil)
+		}
+		return 0, model.ErrInternalServer
+	}
+	if rate.BaseCurrency != from {
+		return rate.Rate.Inverse(), nil
+	}
+	return rate.Rate, nil
+}
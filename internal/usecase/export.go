@@ -0,0 +1,543 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/xlsx"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// exportBatchSize is the number of orders loaded at a time while an export is
+// written.
+const exportBatchSize = 200
+
+// exportStaleAfter is how long an export may run before it is considered
+// abandoned by a stopped worker.
+const exportStaleAfter = time.Hour
+
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportColumns are the columns of an order export, which has one row per
+// order item.
+var exportColumns = []string{
+	"Order UUID", "Order Date", "Order Status", "Buyer", "Order Item UUID", "Product", "SKU",
+	"Item Status", "Quantity", "Unit Price", "Item Tax", "Item Total", "Shipping Cost", "Order Total", "Currency",
+	"Payment Method", "Payment Status", "Refunded Amount", "Shipping Status",
+	"City", "Province", "Postal Code",
+}
+
+// ExportConfig holds the limits of order exports. Zero values fall back to
+// the defaults of NewExportUseCase.
+type ExportConfig struct {
+	// SyncLimit is the largest number of orders exported within the request;
+	// larger exports run as background jobs.
+	SyncLimit int
+	// TTL is how long the file of a background export can be downloaded.
+	TTL time.Duration
+	// Dir is the directory the files of background exports are written to.
+	Dir string
+}
+
+type ExportUseCase struct {
+	db           *gorm.DB
+	val          *validator.Validate
+	exportRepo   repo.ExportRepository
+	orderRepo    repo.OrderRepository
+	storeRepo    repo.StoreRepository
+	notification interfaces.NotificationUseCase
+	uuid         *helper.UUIDHelper
+	config       ExportConfig
+}
+
+func NewExportUseCase(db *gorm.DB, validate *validator.Validate, exportRepo repo.ExportRepository, orderRepo repo.OrderRepository, storeRepo repo.StoreRepository, notification interfaces.NotificationUseCase, uuid *helper.UUIDHelper, config ExportConfig) interfaces.ExportUseCase {
+	if config.SyncLimit <= 0 {
+		config.SyncLimit = 500
+	}
+	if config.TTL <= 0 {
+		config.TTL = 7 * 24 * time.Hour
+	}
+	if config.Dir == "" {
+		config.Dir = filepath.Join("storage", "exports")
+	}
+	return &ExportUseCase{
+		db:           db,
+		val:          validate,
+		exportRepo:   exportRepo,
+		orderRepo:    orderRepo,
+		storeRepo:    storeRepo,
+		notification: notification,
+		uuid:         uuid,
+		config:       config,
+	}
+}
+
+// ExportOrders exports the orders of the seller's store matching the filters
+// of the seller order list, one row per order item. Exports of up to
+// SyncLimit orders are returned as a file that is written while it is sent,
+// loading the orders batch by batch; larger ones are queued as a background
+// job, which is returned instead of the file. Orders are sorted like the
+// seller order list, newest first unless SortDate is "asc", and only orders
+// placed before the export started are included.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the format or a filter is invalid.
+//   - 404 Not Found: if the seller has no store.
+func (uc *ExportUseCase) ExportOrders(ctx context.Context, request *model.ExportOrdersRequest) (*model.ExportFile, *model.ExportResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, nil, err
+	}
+	if err := validateOrderFilter(&request.OrderFilter); err != nil {
+		return nil, nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.Currency == "" {
+		request.Currency = store.Currency
+	}
+
+	search := &model.SearchOrderRequestBySeller{
+		Status:      request.Status,
+		SortDate:    request.SortDate,
+		OrderFilter: request.OrderFilter,
+		StoreID:     store.ID,
+	}
+	orders, total, err := uc.firstExportBatch(search, time.Now())
+	if err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	if total <= int64(uc.config.SyncLimit) {
+		return &model.ExportFile{
+			FileName:    exportFileName(store.ID, request.Format, time.Now()),
+			ContentType: exportContentTypes[request.Format],
+			Write: func(w io.Writer) error {
+				_, err := uc.writeExport(w, request.Format, search, orders)
+				return err
+			},
+		}, nil, nil
+	}
+
+	export := &entity.OrderExport{
+		ExportUUID:    uc.uuid.Generate(),
+		StoreID:       store.ID,
+		UserID:        request.UserID,
+		Format:        request.Format,
+		Status:        "pending",
+		OrderStatus:   request.Status,
+		SortDate:      request.SortDate,
+		StartDate:     request.StartDate,
+		EndDate:       request.EndDate,
+		Search:        request.Search,
+		MinTotal:      request.MinTotal,
+		MaxTotal:      request.MaxTotal,
+		Currency:      request.Currency,
+		PaymentMethod: request.PaymentMethod,
+	}
+	if err := uc.exportRepo.CreateExport(export); err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	go func() {
+		if err := uc.RunPendingExports(context.Background()); err != nil {
+			logrus.WithError(err).Error("Failed to run order exports")
+		}
+	}()
+	return nil, converter.ExportToResponse(export), nil
+}
+
+// GetExports lists the background exports of the seller's store, newest
+// first.
+func (uc *ExportUseCase) GetExports(ctx context.Context, request *model.SearchExportRequest) ([]model.ExportResponse, int64, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, 0, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	exports, total, err := uc.exportRepo.GetExports(store.ID, request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.ExportResponse, len(exports))
+	for i, export := range exports {
+		responses[i] = *converter.ExportToResponse(&export)
+	}
+	return responses, total, nil
+}
+
+// GetExport returns a background export of the seller's store.
+func (uc *ExportUseCase) GetExport(ctx context.Context, request *model.GetExportRequest) (*model.ExportResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	export, err := uc.exportRepo.FindExport(store.ID, request.ExportUUID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.ExportToResponse(export), nil
+}
+
+// DownloadExport returns the file of a completed background export, which is
+// read from the export directory while it is sent.
+//
+// Errors:
+//
+//   - 404 Not Found: if the export is not found in the seller's store.
+//   - 409 Conflict: if the export is still running or has failed.
+//   - 410 Gone: if the file has expired or is no longer there.
+func (uc *ExportUseCase) DownloadExport(ctx context.Context, request *model.GetExportRequest) (*model.ExportFile, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	export, err := uc.exportRepo.FindExport(store.ID, request.ExportUUID)
+	if err != nil {
+		return nil, err
+	}
+	switch export.Status {
+	case "completed":
+	case "expired":
+		return nil, model.NewApiError(fiber.StatusGone, "Export has expired, please request it again", nil)
+	case "failed":
+		return nil, model.NewApiError(fiber.StatusConflict, "Export has failed, please request it again", nil)
+	default:
+		return nil, model.NewApiError(fiber.StatusConflict, "Export is not ready yet", nil)
+	}
+	if _, err := os.Stat(export.FilePath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, model.NewApiError(fiber.StatusGone, "Export file is no longer available, please request it again", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	return &model.ExportFile{
+		FileName:    export.FileName,
+		ContentType: exportContentTypes[export.Format],
+		Write: func(w io.Writer) error {
+			file, err := os.Open(export.FilePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(w, file)
+			return err
+		},
+	}, nil
+}
+
+// RunPendingExports runs the queued background exports one after another
+// until none is left. It is called periodically by a scheduler and whenever
+// an export is queued; exports are claimed before they run, so concurrent
+// runs never write the same export. Exports whose worker stopped are failed
+// and the files of expired exports are dropped.
+func (uc *ExportUseCase) RunPendingExports(ctx context.Context) error {
+	now := time.Now()
+	if _, err := uc.exportRepo.FailStaleExports(now.Add(-exportStaleAfter), "Export was interrupted"); err != nil {
+		return err
+	}
+	if err := uc.expireExports(now); err != nil {
+		return err
+	}
+	for {
+		export, err := uc.exportRepo.ClaimPendingExport(time.Now())
+		if err == model.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := uc.runExport(ctx, export); err != nil {
+			logrus.WithError(err).WithField("export_uuid", export.ExportUUID).Error("Failed to export orders")
+			export.Status = "failed"
+			export.Error = "Export failed"
+			if err := uc.exportRepo.UpdateExport(export); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// expireExports deletes the files of completed exports past their expiry.
+func (uc *ExportUseCase) expireExports(now time.Time) error {
+	exports, err := uc.exportRepo.FindExpiredExports(now)
+	if err != nil {
+		return err
+	}
+	for i := range exports {
+		export := &exports[i]
+		if err := os.Remove(export.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		export.Status = "expired"
+		export.FilePath = ""
+		if err := uc.exportRepo.UpdateExport(export); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runExport writes the file of a claimed export to the export directory and
+// notifies the seller. Only the path of the file is stored on the export.
+func (uc *ExportUseCase) runExport(ctx context.Context, export *entity.OrderExport) error {
+	search := &model.SearchOrderRequestBySeller{
+		Status:   export.OrderStatus,
+		SortDate: export.SortDate,
+		OrderFilter: model.OrderFilter{
+			StartDate:     export.StartDate,
+			EndDate:       export.EndDate,
+			Search:        export.Search,
+			MinTotal:      export.MinTotal,
+			MaxTotal:      export.MaxTotal,
+			Currency:      export.Currency,
+			PaymentMethod: export.PaymentMethod,
+		},
+		StoreID: export.StoreID,
+	}
+	orders, _, err := uc.firstExportBatch(search, export.CreatedAt)
+	if err != nil {
+		return err
+	}
+	path, rows, err := uc.writeExportFile(export, search, orders)
+	if err != nil {
+		return err
+	}
+	// the file is only kept once the export is stored as completed
+	completed := false
+	defer func() {
+		if !completed {
+			os.Remove(path)
+		}
+	}()
+
+	completedAt := time.Now()
+	expiresAt := completedAt.Add(uc.config.TTL)
+	export.Status = "completed"
+	export.FilePath = path
+	export.RowCount = rows
+	export.FileName = exportFileName(export.StoreID, export.Format, completedAt)
+	export.CompletedAt = &completedAt
+	export.ExpiresAt = &expiresAt
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := tx.Omit(clause.Associations).Save(export).Error; err != nil {
+		return err
+	}
+	if err := uc.notification.Notify(ctx, tx, entity.Notification{
+		UserID:        export.UserID,
+		Type:          "export_ready",
+		Title:         "Order export ready",
+		Message:       fmt.Sprintf("Your export of %d order items is ready to download until %s.", rows, expiresAt.Format("2006-01-02 15:04")),
+		ReferenceType: "export",
+		ReferenceID:   export.ExportUUID,
+	}); err != nil {
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	completed = true
+	return nil
+}
+
+// writeExportFile writes the file of an export to the export directory. The
+// file is written under a temporary name and renamed once complete, so a
+// stopped worker never leaves a truncated file behind under the final name.
+// It returns the path of the file and the number of item rows.
+func (uc *ExportUseCase) writeExportFile(export *entity.OrderExport, search *model.SearchOrderRequestBySeller, orders []entity.Order) (string, int, error) {
+	if err := os.MkdirAll(uc.config.Dir, 0o750); err != nil {
+		return "", 0, err
+	}
+	file, err := os.CreateTemp(uc.config.Dir, export.ExportUUID+"-*.tmp")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	rows, err := uc.writeExport(writer, export.Format, search, orders)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := writer.Flush(); err != nil {
+		return "", 0, err
+	}
+	if err := file.Close(); err != nil {
+		return "", 0, err
+	}
+	path := filepath.Join(uc.config.Dir, export.ExportUUID+"."+export.Format)
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", 0, err
+	}
+	return path, rows, nil
+}
+
+// firstExportBatch loads the first orders of an export together with the
+// number of matching orders. Orders placed after startedAt are left out, so
+// new orders cannot shift the batches while the export pages through them.
+func (uc *ExportUseCase) firstExportBatch(search *model.SearchOrderRequestBySeller, startedAt time.Time) ([]entity.Order, int64, error) {
+	search.CreatedBefore = &startedAt
+	search.Page = 1
+	search.Limit = exportBatchSize
+	return uc.orderRepo.GetOrdersBySeller(search)
+}
+
+// writeExport writes the export file to w, starting with the orders already
+// loaded and loading the next batches until the last one, so only one batch
+// is held in memory. Each batch continues behind the last order of the one
+// before, so orders changing status meanwhile are neither skipped nor written
+// twice. It returns the number of item rows.
+func (uc *ExportUseCase) writeExport(w io.Writer, format string, search *model.SearchOrderRequestBySeller, orders []entity.Order) (int, error) {
+	var writeRow func(cells []xlsx.Cell) error
+	var finish func() error
+	if format == "xlsx" {
+		sheet, err := xlsx.NewWriter(w, "Orders")
+		if err != nil {
+			return 0, err
+		}
+		writeRow = func(cells []xlsx.Cell) error { return sheet.WriteRow(cells...) }
+		finish = sheet.Close
+	} else {
+		// the byte order mark makes spreadsheet applications read the file as UTF-8
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return 0, err
+		}
+		writer := csv.NewWriter(w)
+		writeRow = func(cells []xlsx.Cell) error {
+			record := make([]string, len(cells))
+			for i, cell := range cells {
+				record[i] = cell.Value
+				if !cell.Number {
+					record[i] = csvSafe(cell.Value)
+				}
+			}
+			return writer.Write(record)
+		}
+		finish = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	}
+
+	header := make([]xlsx.Cell, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = xlsx.Cell{Value: column, Bold: true}
+	}
+	if err := writeRow(header); err != nil {
+		return 0, err
+	}
+	rows := 0
+	for {
+		for i := range orders {
+			for j := range orders[i].Items {
+				if err := writeRow(exportRow(&orders[i], &orders[i].Items[j])); err != nil {
+					return 0, err
+				}
+				rows++
+			}
+		}
+		if len(orders) < search.Limit {
+			break
+		}
+		last := &orders[len(orders)-1]
+		search.After = &model.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		var err error
+		if orders, _, err = uc.orderRepo.GetOrdersBySeller(search); err != nil {
+			return 0, err
+		}
+	}
+	if err := finish(); err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
+
+// exportRow returns the cells of one order item, see exportColumns.
+func exportRow(order *entity.Order, item *entity.OrderItem) []xlsx.Cell {
+	var paymentMethod, paymentStatus, refunded, shippingStatus, city, province, postalCode string
+	if order.Payment != nil {
+		paymentMethod = order.Payment.Method
+		paymentStatus = order.Payment.Status
+		refunded = order.Payment.RefundedAmount.String()
+	}
+	if order.Shipping != nil {
+		shippingStatus = order.Shipping.Status
+		city = order.Shipping.City
+		province = order.Shipping.Province
+		postalCode = order.Shipping.PostalCode
+	}
+	itemStatus := item.Status
+	if itemStatus == "" {
+		itemStatus = "active"
+	}
+	return []xlsx.Cell{
+		xlsx.String(order.OrderUUID),
+		xlsx.String(order.CreatedAt.Format("2006-01-02 15:04:05")),
+		xlsx.String(order.Status),
+		xlsx.String(order.User.Name),
+		xlsx.String(item.OrderItemUUID),
+		xlsx.String(item.ProductName),
+		xlsx.String(item.SKU),
+		xlsx.String(itemStatus),
+		xlsx.Number(fmt.Sprint(item.Quantity)),
+		xlsx.Number(item.UnitPrice.String()),
+		xlsx.Number(item.TaxAmount.String()),
+		xlsx.Number(item.TotalPrice.String()),
+		xlsx.Number(order.ShippingCost.String()),
+		xlsx.Number(order.TotalPrice.String()),
+		xlsx.String(order.Currency),
+		xlsx.String(paymentMethod),
+		xlsx.String(paymentStatus),
+		xlsx.Number(refunded),
+		xlsx.String(shippingStatus),
+		xlsx.String(city),
+		xlsx.String(province),
+		xlsx.String(postalCode),
+	}
+}
+
+// csvSafe keeps spreadsheet applications from running text that starts like
+// a formula.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func exportFileName(storeID uint, format string, at time.Time) string {
+	return fmt.Sprintf("orders-%d-%s.%s", storeID, at.Format("20060102-150405"), format)
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultIdempotencyTTL is how long a key is remembered when no TTL is
+// configured.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyLease is how long a request may hold its key while
+// processing when no lease is configured.
+const DefaultIdempotencyLease = time.Minute
+
+type IdempotencyUseCase struct {
+	db              *gorm.DB
+	val             *validator.Validate
+	idempotencyRepo repo.IdempotencyRepository
+	uuid            *helper.UUIDHelper
+	ttl             time.Duration
+	lease           time.Duration
+}
+
+// NewIdempotencyUseCase remembers keys for ttl. A request holds its key for
+// lease while it runs; the lease must outlast the slowest request.
+func NewIdempotencyUseCase(db *gorm.DB, validate *validator.Validate, idempotencyRepo repo.IdempotencyRepository, uuid *helper.UUIDHelper, ttl, lease time.Duration) interfaces.IdempotencyUseCase {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	if lease <= 0 {
+		lease = DefaultIdempotencyLease
+	}
+	return &IdempotencyUseCase{
+		db:              db,
+		val:             validate,
+		idempotencyRepo: idempotencyRepo,
+		uuid:            uuid,
+		ttl:             ttl,
+		lease:           lease,
+	}
+}
+
+// Begin claims the key for a request. It returns nil when the request should
+// run, or the stored response when the key was already used for the same
+// request. A key reused with a different payload, or one whose first request
+// is still running, returns a 409 error. Expired keys are reclaimed, and a
+// key still processing after the lease, whose request most likely died with
+// its process, is taken over by the retry. A request that claims the key
+// gets a new claim token in request.ClaimToken, which Complete and Release
+// need.
+func (uc *IdempotencyUseCase) Begin(ctx context.Context, request *model.IdempotencyRequest) (*model.IdempotentResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	db := uc.db.WithContext(ctx)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		claimToken := uc.uuid.Generate()
+		inserted, err := uc.idempotencyRepo.CreateKey(db, &entity.IdempotencyKey{
+			UserID:      request.UserID,
+			Key:         request.Key,
+			RequestHash: request.RequestHash,
+			Status:      "processing",
+			LockedAt:    &now,
+			ClaimToken:  claimToken,
+			ExpiresAt:   now.Add(uc.ttl),
+		})
+		if err != nil {
+			return nil, model.ErrInternalServer
+		}
+		if inserted {
+			request.ClaimToken = claimToken
+			return nil, nil
+		}
+
+		record, err := uc.idempotencyRepo.FindKey(db, request.UserID, request.Key)
+		if err != nil {
+			if err == model.ErrNotFound {
+				// released or purged in the meantime, claim it again
+				continue
+			}
+			return nil, model.ErrInternalServer
+		}
+		if !record.ExpiresAt.After(now) {
+			// a conflict means another request reclaimed it first
+			if err := uc.idempotencyRepo.DeleteKey(db, request.UserID, request.Key, record.ClaimToken); err != nil && err != model.ErrConflict {
+				return nil, model.ErrInternalServer
+			}
+			continue
+		}
+		if record.RequestHash != request.RequestHash {
+			return nil, model.NewApiError(fiber.StatusConflict, "Idempotency-Key has already been used with a different request", nil)
+		}
+		if record.Status != "completed" {
+			if record.LockedAt == nil || !record.LockedAt.After(now.Add(-uc.lease)) {
+				taken, err := uc.idempotencyRepo.TakeOverKey(db, request.UserID, request.Key, claimToken, now, now.Add(-uc.lease))
+				if err != nil {
+					return nil, model.ErrInternalServer
+				}
+				if taken {
+					request.ClaimToken = claimToken
+					logrus.WithField("user_id", request.UserID).Warn("Took over idempotency key past its processing lease")
+					return nil, nil
+				}
+			}
+			return nil, model.NewApiError(fiber.StatusConflict, "A request with this Idempotency-Key is still being processed", nil)
+		}
+		return &model.IdempotentResponse{
+			StatusCode:  record.ResponseStatus,
+			ContentType: record.ContentType,
+			Body:        record.ResponseBody,
+		}, nil
+	}
+	return nil, model.NewApiError(fiber.StatusConflict, "A request with this Idempotency-Key is still being processed", nil)
+}
+
+// Complete stores the response of a request that ran under the key. It
+// returns model.ErrConflict when the request lost its claim, because it
+// outlived its lease and a retry took the key over; nothing is stored then.
+func (uc *IdempotencyUseCase) Complete(ctx context.Context, request *model.IdempotencyRequest, response *model.IdempotentResponse) error {
+	err := uc.idempotencyRepo.CompleteKey(uc.db.WithContext(ctx), &entity.IdempotencyKey{
+		UserID:         request.UserID,
+		Key:            request.Key,
+		ClaimToken:     request.ClaimToken,
+		ResponseStatus: response.StatusCode,
+		ContentType:    response.ContentType,
+		ResponseBody:   response.Body,
+	})
+	if err != nil {
+		if err == model.ErrConflict {
+			return err
+		}
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// Release forgets the key of a request that failed, so the client can retry
+// it with the same key. It returns model.ErrConflict and leaves the key alone
+// when the request lost its claim.
+func (uc *IdempotencyUseCase) Release(ctx context.Context, request *model.IdempotencyRequest) error {
+	if err := uc.idempotencyRepo.DeleteKey(uc.db.WithContext(ctx), request.UserID, request.Key, request.ClaimToken); err != nil {
+		if err == model.ErrConflict {
+			return err
+		}
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// PurgeExpired deletes every expired key.
+func (uc *IdempotencyUseCase) PurgeExpired(ctx context.Context) error {
+	deleted, err := uc.idempotencyRepo.DeleteExpiredKeys(uc.db.WithContext(ctx), time.Now())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logrus.WithField("count", deleted).Info("Purged expired idempotency keys")
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	hash := strings.Repeat("a", 64)
+	now := time.Now()
+	fresh := now.Add(-10 * time.Second)
+	stale := now.Add(-2 * time.Minute)
+	tests := []struct {
+		name       string
+		existing   *entity.IdempotencyKey
+		wantStatus int
+		wantReplay bool
+		wantClaim  bool
+	}{
+		{name: "new key", wantClaim: true},
+		{
+			name:       "completed key replays the response",
+			existing:   &entity.IdempotencyKey{RequestHash: hash, Status: "completed", ClaimToken: "old", ResponseStatus: fiber.StatusCreated, ContentType: "application/json", ResponseBody: []byte(`{"id":1}`), ExpiresAt: now.Add(time.Hour)},
+			wantReplay: true,
+		},
+		{
+			name:       "different request",
+			existing:   &entity.IdempotencyKey{RequestHash: strings.Repeat("b", 64), Status: "completed", ClaimToken: "old", ExpiresAt: now.Add(time.Hour)},
+			wantStatus: fiber.StatusConflict,
+		},
+		{
+			name:       "still processing within the lease",
+			existing:   &entity.IdempotencyKey{RequestHash: hash, Status: "processing", LockedAt: &fresh, ClaimToken: "old", ExpiresAt: now.Add(time.Hour)},
+			wantStatus: fiber.StatusConflict,
+		},
+		{
+			name:      "processing past the lease is taken over",
+			existing:  &entity.IdempotencyKey{RequestHash: hash, Status: "processing", LockedAt: &stale, ClaimToken: "old", ExpiresAt: now.Add(time.Hour)},
+			wantClaim: true,
+		},
+		{
+			name:      "processing without a lock is taken over",
+			existing:  &entity.IdempotencyKey{RequestHash: hash, Status: "processing", ClaimToken: "old", ExpiresAt: now.Add(time.Hour)},
+			wantClaim: true,
+		},
+		{
+			name:      "expired key is reclaimed",
+			existing:  &entity.IdempotencyKey{RequestHash: strings.Repeat("b", 64), Status: "completed", ClaimToken: "old", ExpiresAt: now.Add(-time.Minute)},
+			wantClaim: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := &fakeIdempotencyRepository{}
+			if tt.existing != nil {
+				existing := *tt.existing
+				existing.UserID, existing.Key = 1, "order-1"
+				keys.keys = []entity.IdempotencyKey{existing}
+			}
+			uc := newTestIdempotencyUseCase(t, keys)
+			request := &model.IdempotencyRequest{UserID: 1, Key: "order-1", RequestHash: hash}
+
+			response, err := uc.Begin(context.Background(), request)
+			if tt.wantStatus != 0 {
+				var apiErr *model.ApiError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+				}
+				if request.ClaimToken != "" || keys.keys[0].ClaimToken != "old" {
+					t.Errorf("refused request claimed the key: request %q, key %q", request.ClaimToken, keys.keys[0].ClaimToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantReplay {
+				if response == nil || response.StatusCode != fiber.StatusCreated || response.ContentType != "application/json" || string(response.Body) != `{"id":1}` {
+					t.Errorf("response = %+v, want the stored response", response)
+				}
+				if request.ClaimToken != "" {
+					t.Errorf("replayed request claimed the key with %q", request.ClaimToken)
+				}
+				return
+			}
+			if response != nil {
+				t.Fatalf("response = %+v, want the request to run", response)
+			}
+			if len(keys.keys) != 1 {
+				t.Fatalf("stored keys %v, want one", keys.keys)
+			}
+			key := keys.keys[0]
+			if request.ClaimToken == "" || request.ClaimToken == "old" || key.ClaimToken != request.ClaimToken {
+				t.Errorf("claim token of the request %q, of the key %q, want a new shared token", request.ClaimToken, key.ClaimToken)
+			}
+			if key.Status != "processing" || key.LockedAt == nil || key.LockedAt.Before(now) || key.RequestHash != hash {
+				t.Errorf("key = %+v, want it processing and locked now for the request", key)
+			}
+		})
+	}
+}
+
+// TestIdempotencyClaim takes a key over from a request past its lease and
+// checks that only the new holder can store or release the outcome.
+func TestIdempotencyClaim(t *testing.T) {
+	stale := time.Now().Add(-2 * time.Minute)
+	hash := strings.Repeat("a", 64)
+	keys := &fakeIdempotencyRepository{keys: []entity.IdempotencyKey{
+		{UserID: 1, Key: "order-1", RequestHash: hash, Status: "processing", LockedAt: &stale, ClaimToken: "old", ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	uc := newTestIdempotencyUseCase(t, keys)
+	ctx := context.Background()
+
+	retry := &model.IdempotencyRequest{UserID: 1, Key: "order-1", RequestHash: hash}
+	if _, err := uc.Begin(ctx, retry); err != nil || retry.ClaimToken == "" {
+		t.Fatalf("retry did not take the key over: token %q, error %v", retry.ClaimToken, err)
+	}
+	first := &model.IdempotencyRequest{UserID: 1, Key: "order-1", RequestHash: hash, ClaimToken: "old"}
+	response := &model.IdempotentResponse{StatusCode: fiber.StatusCreated, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+
+	if err := uc.Complete(ctx, first, response); err != model.ErrConflict {
+		t.Errorf("Complete by the first request = %v, want ErrConflict", err)
+	}
+	if err := uc.Release(ctx, first); err != model.ErrConflict {
+		t.Errorf("Release by the first request = %v, want ErrConflict", err)
+	}
+	if len(keys.keys) != 1 || keys.keys[0].Status != "processing" {
+		t.Fatalf("keys = %+v, want the retry's claim untouched", keys.keys)
+	}
+
+	if err := uc.Complete(ctx, retry, response); err != nil {
+		t.Fatalf("Complete by the retry: %v", err)
+	}
+	key := keys.keys[0]
+	if key.Status != "completed" || key.ResponseStatus != fiber.StatusCreated || string(key.ResponseBody) != `{"id":1}` {
+		t.Errorf("key = %+v, want the retry's response stored", key)
+	}
+
+	replay, err := uc.Begin(ctx, &model.IdempotencyRequest{UserID: 1, Key: "order-1", RequestHash: hash})
+	if err != nil || replay == nil || replay.StatusCode != fiber.StatusCreated {
+		t.Errorf("replay = %+v, %v, want the stored response", replay, err)
+	}
+
+	if err := uc.Release(ctx, retry); err != nil || len(keys.keys) != 0 {
+		t.Errorf("Release by the retry = %v, keys %v, want the key deleted", err, keys.keys)
+	}
+}
+
+func newTestIdempotencyUseCase(t *testing.T, keys *fakeIdempotencyRepository) *IdempotencyUseCase {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewIdempotencyUseCase(db, validator.New(), keys, helper.NewUUIDHelper(), time.Hour, time.Minute).(*IdempotencyUseCase)
+}
+
+// fakeIdempotencyRepository keeps keys in memory and matches them the way
+// the WHERE clauses of IdempotencyRepository do.
+type fakeIdempotencyRepository struct {
+	repo.IdempotencyRepository
+	keys []entity.IdempotencyKey
+}
+
+func (r *fakeIdempotencyRepository) find(userID uint, key string) int {
+	for i := range r.keys {
+		if r.keys[i].UserID == userID && r.keys[i].Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *fakeIdempotencyRepository) CreateKey(db *gorm.DB, key *entity.IdempotencyKey) (bool, error) {
+	if r.find(key.UserID, key.Key) >= 0 {
+		return false, nil
+	}
+	r.keys = append(r.keys, *key)
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepository) FindKey(db *gorm.DB, userID uint, key string) (*entity.IdempotencyKey, error) {
+	i := r.find(userID, key)
+	if i < 0 {
+		return nil, model.ErrNotFound
+	}
+	record := r.keys[i]
+	return &record, nil
+}
+
+func (r *fakeIdempotencyRepository) TakeOverKey(db *gorm.DB, userID uint, key string, claimToken string, lockedAt, staleBefore time.Time) (bool, error) {
+	i := r.find(userID, key)
+	if i < 0 || r.keys[i].Status != "processing" || (r.keys[i].LockedAt != nil && r.keys[i].LockedAt.After(staleBefore)) {
+		return false, nil
+	}
+	r.keys[i].LockedAt = &lockedAt
+	r.keys[i].ClaimToken = claimToken
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepository) CompleteKey(db *gorm.DB, key *entity.IdempotencyKey) error {
+	i := r.find(key.UserID, key.Key)
+	if i < 0 || r.keys[i].ClaimToken != key.ClaimToken {
+		return model.ErrConflict
+	}
+	r.keys[i].Status = "completed"
+	r.keys[i].ResponseStatus = key.ResponseStatus
+	r.keys[i].ContentType = key.ContentType
+	r.keys[i].ResponseBody = key.ResponseBody
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) DeleteKey(db *gorm.DB, userID uint, key string, claimToken string) error {
+	i := r.find(userID, key)
+	if i < 0 || r.keys[i].ClaimToken != claimToken {
+		return model.ErrConflict
+	}
+	r.keys = append(r.keys[:i], r.keys[i+1:]...)
+	return nil
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type AddressUseCase interface {
+	CreateAddress(ctx context.Context, request *model.CreateAddressRequest) (*model.AddressResponse, error)
+	GetAddresses(ctx context.Context, userID uint) ([]model.AddressResponse, error)
+	GetAddress(ctx context.Context, request *model.GetAddressRequest) (*model.AddressResponse, error)
+	UpdateAddress(ctx context.Context, request *model.UpdateAddressRequest) (*model.AddressResponse, error)
+	DeleteAddress(ctx context.Context, request *model.GetAddressRequest) error
+	ResolveShippingAddress(ctx context.Context, db *gorm.DB, userID uint, addressUUID string, address *model.ShippingAddressRequest) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type CartUseCase interface {
+	GetCart(ctx context.Context, request *model.GetCartRequest) (*model.CartResponse, error)
+	AddItem(ctx context.Context, request *model.AddCartItemRequest) (*model.CartResponse, error)
+	UpdateItem(ctx context.Context, request *model.UpdateCartItemRequest) (*model.CartResponse, error)
+	RemoveItem(ctx context.Context, request *model.RemoveCartItemRequest) (*model.CartResponse, error)
+	MergeGuestCart(ctx context.Context, userID uint, guestToken string) error
+	Checkout(ctx context.Context, request *model.CheckoutCartRequest) (*model.CheckoutResponse, error)
+}
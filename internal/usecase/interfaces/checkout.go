@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type CheckoutUseCase interface {
+	CreateCheckout(ctx context.Context, input *model.CreateCheckout) (*model.CheckoutResponse, error)
+	GetCheckout(ctx context.Context, request *model.GetCheckoutRequest) (*model.CheckoutResponse, error)
+	PayCheckout(ctx context.Context, request *model.PayCheckoutRequest) (*model.CheckoutResponse, error)
+}
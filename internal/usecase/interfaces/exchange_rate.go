@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+type ExchangeRateUseCase interface {
+	ImportRates(ctx context.Context, request *model.ImportExchangeRatesRequest) ([]model.ExchangeRateResponse, error)
+	GetRates(ctx context.Context, request *model.GetExchangeRatesRequest) ([]model.ExchangeRateResponse, error)
+	GetRate(ctx context.Context, db *gorm.DB, from, to string) (money.Rate, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type ExportUseCase interface {
+	ExportOrders(ctx context.Context, request *model.ExportOrdersRequest) (*model.ExportFile, *model.ExportResponse, error)
+	GetExports(ctx context.Context, request *model.SearchExportRequest) ([]model.ExportResponse, int64, error)
+	GetExport(ctx context.Context, request *model.GetExportRequest) (*model.ExportResponse, error)
+	DownloadExport(ctx context.Context, request *model.GetExportRequest) (*model.ExportFile, error)
+	RunPendingExports(ctx context.Context) error
+}
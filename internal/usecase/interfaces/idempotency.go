@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type IdempotencyUseCase interface {
+	Begin(ctx context.Context, request *model.IdempotencyRequest) (*model.IdempotentResponse, error)
+	Complete(ctx context.Context, request *model.IdempotencyRequest, response *model.IdempotentResponse) error
+	Release(ctx context.Context, request *model.IdempotencyRequest) error
+	PurgeExpired(ctx context.Context) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type InventoryUseCase interface {
+	ApplyMovement(ctx context.Context, tx *gorm.DB, movement *entity.InventoryMovement) error
+	AdjustStock(ctx context.Context, request *model.AdjustStockRequest) (*model.InventoryMovementResponse, error)
+	GetStockHistory(ctx context.Context, request *model.GetStockHistoryRequest) ([]model.InventoryMovementResponse, int64, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type MessageUseCase interface {
+	StartThread(ctx context.Context, request *model.StartThreadRequest) (*model.ThreadResponse, error)
+	SendMessage(ctx context.Context, request *model.SendMessageRequest) (*model.MessageResponse, error)
+	GetThreads(ctx context.Context, request *model.SearchThreadRequest) ([]model.ThreadResponse, int64, error)
+	GetThread(ctx context.Context, request *model.GetThreadRequest) (*model.ThreadResponse, int64, error)
+	MarkThreadRead(ctx context.Context, request *model.GetThreadRequest) (*model.ThreadResponse, error)
+	GetUnreadCount(ctx context.Context, request *model.SearchThreadRequest) (*model.UnreadCountResponse, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type NotificationUseCase interface {
+	Notify(ctx context.Context, tx *gorm.DB, notifications ...entity.Notification) error
+	GetNotifications(ctx context.Context, request *model.GetNotificationsRequest) ([]model.NotificationResponse, int64, error)
+	MarkAsRead(ctx context.Context, request *model.ReadNotificationRequest) (*model.NotificationResponse, error)
+}
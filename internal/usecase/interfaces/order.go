@@ -3,15 +3,28 @@ package interfaces
 import (
 	"context"
 
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
 )
 
 type OrderUseCase interface {
 	CreateOrder(ctx context.Context, input *model.CreateOrder) (*model.OrderResponse, error)
+	GetShippingOptions(ctx context.Context, request *model.ShippingOptionsRequest) (*model.ShippingOptionsResponse, error)
+	PlaceOrder(ctx context.Context, tx *gorm.DB, input *model.PlaceOrder) (*entity.Order, *evententity.OrderEvent, error)
+	PayOrder(ctx context.Context, tx *gorm.DB, order *entity.Order) (*evententity.OrderEvent, error)
 	GetOrdersByBuyer(ctx context.Context, request *model.SearchOrderRequest) ([]model.ListOrderResponse, int64, error)
 	GetOrderByIdByBuyer(ctx context.Context, request *model.GetOrderDetails) (*model.OrderResponse, error)
 	CancelOrder(ctx context.Context, request *model.CancelOrderRequest) (*model.OrderResponse, error)
+	CancelOrderItemByBuyer(ctx context.Context, request *model.CancelOrderItemRequest) (*model.OrderResponse, error)
+	CancelOrderItemBySeller(ctx context.Context, request *model.CancelOrderItemRequest) (*model.OrderResponse, error)
+	CancelPendingOrder(ctx context.Context, tx *gorm.DB, order *entity.Order, actorType string, actorID *uint, reason string) (*evententity.OrderEvent, error)
 	CheckoutOrder(ctx context.Context, request *model.CheckoutOrderRequest) (*model.OrderResponse, error)
+	ConfirmOrderReceipt(ctx context.Context, request *model.ConfirmOrderRequest) (*model.OrderResponse, error)
+	ReportOrderProblem(ctx context.Context, request *model.ReportOrderProblemRequest) (*model.OrderResponse, error)
 	GetOrdersBySeller(ctx context.Context, request *model.SearchOrderRequestBySeller) ([]model.OrdersResponseForSeller, int64, error)
 	GetOrderBySeller(ctx context.Context, request *model.GetOrderDetails) (*model.OrderResponse, error) 
+	GetOrderTimelineByBuyer(ctx context.Context, request *model.GetOrderDetails) (*model.OrderTimelineResponse, error)
+	GetOrderTimelineBySeller(ctx context.Context, request *model.GetOrderDetails) (*model.OrderTimelineResponse, error)
 }
\ No newline at end of file
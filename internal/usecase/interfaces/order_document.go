@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type OrderDocumentUseCase interface {
+	GetDocumentByBuyer(ctx context.Context, request *model.GetOrderDocumentRequest) (*model.OrderDocumentFile, error)
+	GetDocumentBySeller(ctx context.Context, request *model.GetOrderDocumentRequest) (*model.OrderDocumentFile, error)
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type OrderPolicyUseCase interface {
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type PromotionUseCase interface {
+	CreatePromotion(ctx context.Context, request *model.CreatePromotionRequest) (*model.PromotionResponse, error)
+	GetPromotions(ctx context.Context, request *model.SearchPromotionRequest) ([]model.PromotionResponse, int64, error)
+	GetPromotion(ctx context.Context, request *model.GetPromotionRequest) (*model.PromotionResponse, error)
+	UpdatePromotion(ctx context.Context, request *model.UpdatePromotionRequest) (*model.PromotionResponse, error)
+	PreviewOrder(ctx context.Context, request *model.PreviewOrderRequest) (*model.PreviewOrderResponse, error)
+	ActivePromotions(ctx context.Context, db *gorm.DB, storeIDs []uint) (map[uint][]entity.Promotion, error)
+	ApplyPromotions(ctx context.Context, tx *gorm.DB, order *entity.Order, products map[uint]entity.Product) error
+}
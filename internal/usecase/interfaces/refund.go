@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type RefundUseCase interface {
+	IssueRefund(ctx context.Context, tx *gorm.DB, order *entity.Order, request *model.IssueRefund) (*entity.Refund, *evententity.OrderEvent, error)
+}
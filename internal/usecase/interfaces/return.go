@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type ReturnUseCase interface {
+	RequestReturn(ctx context.Context, request *model.CreateReturnRequest) (*model.ReturnResponse, error)
+	GetReturnsByBuyer(ctx context.Context, request *model.SearchReturnRequest) ([]model.ReturnResponse, int64, error)
+	GetReturnByBuyer(ctx context.Context, request *model.GetReturnRequest) (*model.ReturnResponse, error)
+	GetReturnsBySeller(ctx context.Context, request *model.SearchReturnRequest) ([]model.ReturnResponse, int64, error)
+	GetReturnBySeller(ctx context.Context, request *model.GetReturnRequest) (*model.ReturnResponse, error)
+	ApproveReturn(ctx context.Context, request *model.ApproveReturnRequest) (*model.ReturnResponse, error)
+	RejectReturn(ctx context.Context, request *model.RejectReturnRequest) (*model.ReturnResponse, error)
+	InspectReturn(ctx context.Context, request *model.InspectReturnRequest) (*model.ReturnResponse, error)
+}
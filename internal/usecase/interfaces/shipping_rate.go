@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
+	"gorm.io/gorm"
+)
+
+type ShippingRateUseCase interface {
+	CreateRates(ctx context.Context, request *model.CreateShippingRatesRequest) ([]model.ShippingRateResponse, error)
+	GetRates(ctx context.Context, request *model.SearchShippingRateRequest) ([]model.ShippingRateResponse, int64, error)
+	UpdateRate(ctx context.Context, request *model.UpdateShippingRateRequest) (*model.ShippingRateResponse, error)
+	DeleteRate(ctx context.Context, request *model.DeleteShippingRateRequest) error
+	Quote(ctx context.Context, db *gorm.DB, parcel *shipping.Parcel, currency string) ([]shipping.Quote, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+)
+
+type StockSubscriptionUseCase interface {
+	Subscribe(ctx context.Context, request *model.StockSubscriptionRequest) error
+	Unsubscribe(ctx context.Context, request *model.StockSubscriptionRequest) error
+}
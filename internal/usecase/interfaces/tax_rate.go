@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type TaxRateUseCase interface {
+	CreateRate(ctx context.Context, request *model.CreateTaxRateRequest) (*model.TaxRateResponse, error)
+	GetRates(ctx context.Context, request *model.SearchTaxRateRequest) ([]model.TaxRateResponse, int64, error)
+	UpdateRate(ctx context.Context, request *model.UpdateTaxRateRequest) (*model.TaxRateResponse, error)
+	ApplyTaxes(ctx context.Context, tx *gorm.DB, store *entity.Store, order *entity.Order, products map[uint]entity.Product) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type VoucherUseCase interface {
+	CreateVoucher(ctx context.Context, request *model.CreateVoucherRequest) (*model.VoucherResponse, error)
+	GetVouchers(ctx context.Context, request *model.SearchVoucherRequest) ([]model.VoucherResponse, int64, error)
+	GetVoucher(ctx context.Context, request *model.GetVoucherRequest) (*model.VoucherResponse, error)
+	UpdateVoucher(ctx context.Context, request *model.UpdateVoucherRequest) (*model.VoucherResponse, error)
+	FindVoucher(ctx context.Context, tx *gorm.DB, code string) (*entity.Voucher, error)
+	ApplyVoucher(ctx context.Context, tx *gorm.DB, voucher *entity.Voucher, order *entity.Order, products map[uint]entity.Product) error
+	RedeemVoucher(ctx context.Context, tx *gorm.DB, voucher *entity.Voucher, order *entity.Order) error
+	ReleaseVoucher(ctx context.Context, tx *gorm.DB, order *entity.Order) error
+	RecheckVoucher(ctx context.Context, tx *gorm.DB, order *entity.Order) ([]entity.OrderItem, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"gorm.io/gorm"
+)
+
+type WarehouseUseCase interface {
+	CreateWarehouse(ctx context.Context, request *model.RegisterWarehouse) (*model.WarehouseResponse, error)
+	GetWarehouses(ctx context.Context, userID uint) ([]model.WarehouseResponse, error)
+	UpdateWarehouse(ctx context.Context, request *model.UpdateWarehouse) (*model.WarehouseResponse, error)
+	GetProductStock(ctx context.Context, request *model.GetProductRequest) (*model.ProductStockResponse, error)
+	Allocate(ctx context.Context, tx *gorm.DB, storeID uint, province string, quantities map[uint]int, products map[uint]entity.Product) (*entity.Warehouse, error)
+}
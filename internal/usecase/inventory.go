@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type InventoryUseCase struct {
+	db               *gorm.DB
+	val              *validator.Validate
+	inventoryRepo    repo.InventoryRepository
+	productRepo      repo.ProductRepository
+	warehouseRepo    repo.WarehouseRepository
+	subscriptionRepo repo.StockSubscriptionRepository
+	notification     interfaces.NotificationUseCase
+	uuid             *helper.UUIDHelper
+}
+
+func NewInventoryUseCase(db *gorm.DB, validate *validator.Validate, inventoryRepo repo.InventoryRepository, productRepo repo.ProductRepository, warehouseRepo repo.WarehouseRepository, subscriptionRepo repo.StockSubscriptionRepository, notification interfaces.NotificationUseCase, uuid *helper.UUIDHelper) interfaces.InventoryUseCase {
+	return &InventoryUseCase{
+		db:               db,
+		val:              validate,
+		inventoryRepo:    inventoryRepo,
+		productRepo:      productRepo,
+		warehouseRepo:    warehouseRepo,
+		subscriptionRepo: subscriptionRepo,
+		notification:     notification,
+		uuid:             uuid,
+	}
+}
+
+// ApplyMovement is the single path through which product stock changes.
+//
+// It applies the signed movement quantity to the product inside the given
+// transaction and appends the movement to the inventory ledger together with
+// the resulting stock level. Decrements are conditional, so it returns
+// model.ErrInsufficientStock instead of letting stock go negative. A movement
+// with a zero quantity is ignored. When the movement names a warehouse, the
+// stock of that warehouse moves together with the product total.
+//
+// When the movement drops the stock below the product's low-stock threshold
+// the store owner is notified, and when it brings an out-of-stock product
+// back in stock every pending back-in-stock subscriber is notified.
+func (uc *InventoryUseCase) ApplyMovement(ctx context.Context, tx *gorm.DB, movement *entity.InventoryMovement) error {
+	if movement.Quantity != 0 && movement.WarehouseID != nil {
+		if err := uc.warehouseRepo.ChangeStock(tx, *movement.WarehouseID, movement.ProductID, movement.Quantity); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case movement.Quantity < 0:
+		if err := uc.productRepo.DecreaseStock(tx, movement.ProductID, -movement.Quantity); err != nil {
+			return err
+		}
+	case movement.Quantity > 0:
+		if err := uc.productRepo.IncreaseStock(tx, movement.ProductID, movement.Quantity); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	product, err := uc.productRepo.FindProductWithStore(tx, movement.ProductID)
+	if err != nil {
+		return err
+	}
+
+	movement.MovementUUID = uc.uuid.Generate()
+	movement.StockAfter = product.Stock
+	if err := uc.inventoryRepo.CreateMovement(tx, movement); err != nil {
+		return err
+	}
+	return uc.notifyStockChange(ctx, tx, product, product.Stock-movement.Quantity)
+}
+
+func (uc *InventoryUseCase) notifyStockChange(ctx context.Context, tx *gorm.DB, product *entity.Product, before int) error {
+	after := product.Stock
+	var notifications []entity.Notification
+
+	if threshold := product.LowStockThreshold; threshold > 0 && before >= threshold && after < threshold {
+		notifications = append(notifications, entity.Notification{
+			UserID:        product.Store.UserID,
+			Type:          "low_stock",
+			Title:         "Low stock",
+			Message:       fmt.Sprintf("%s is running low: %d left (threshold %d)", product.ProductName, after, threshold),
+			ReferenceType: "product",
+			ReferenceID:   product.ProductUUID,
+		})
+	}
+
+	var notifiedIDs []uint
+	if before <= 0 && after > 0 {
+		subscriptions, err := uc.subscriptionRepo.FindPendingByProduct(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		for _, subscription := range subscriptions {
+			notifiedIDs = append(notifiedIDs, subscription.ID)
+			notifications = append(notifications, entity.Notification{
+				UserID:        subscription.UserID,
+				Type:          "back_in_stock",
+				Title:         "Back in stock",
+				Message:       fmt.Sprintf("%s is back in stock", product.ProductName),
+				ReferenceType: "product",
+				ReferenceID:   product.ProductUUID,
+			})
+		}
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := uc.notification.Notify(ctx, tx, notifications...); err != nil {
+		return err
+	}
+	return uc.subscriptionRepo.MarkNotified(tx, notifiedIDs)
+}
+
+// AdjustStock records a manual stock movement made by the seller who owns the product.
+//
+// A positive quantity adds stock and a negative quantity removes it. The reason
+// must be one of "adjust", "return" or "import"; "sale" and "cancel" movements
+// are reserved for the order flow. When a warehouse is given the movement is
+// booked against that warehouse of the seller's store.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid.
+//   - 404 Not Found: if the product does not belong to the seller's store.
+//   - 409 Conflict: if the adjustment would make the stock negative.
+func (uc *InventoryUseCase) AdjustStock(ctx context.Context, request *model.AdjustStockRequest) (*model.InventoryMovementResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	product, err := uc.productRepo.GetProductById(request.UserID, request.ProductUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warehouse *entity.Warehouse
+	if request.WarehouseUUID != "" {
+		warehouse, err = uc.warehouseRepo.FindWarehouseByUUID(product.StoreID, request.WarehouseUUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	userID := request.UserID
+	movement := &entity.InventoryMovement{
+		ProductID:     product.ID,
+		Warehouse:     warehouse,
+		Quantity:      request.Quantity,
+		Reason:        request.Reason,
+		ReferenceType: "manual",
+		ReferenceID:   request.ReferenceID,
+		UserID:        &userID,
+		Note:          request.Note,
+	}
+	if warehouse != nil {
+		movement.WarehouseID = &warehouse.ID
+	}
+	if err := uc.ApplyMovement(ctx, tx, movement); err != nil {
+		if err == model.ErrInsufficientStock {
+			return nil, err
+		}
+		return nil, model.ErrInternalServer
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.InventoryMovementToResponse(movement), nil
+}
+
+// GetStockHistory returns the inventory ledger of a product owned by the seller,
+// newest movement first. The history can be filtered by reason.
+func (uc *InventoryUseCase) GetStockHistory(ctx context.Context, request *model.GetStockHistoryRequest) ([]model.InventoryMovementResponse, int64, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, 0, err
+	}
+	product, err := uc.productRepo.GetProductById(request.UserID, request.ProductUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	movements, total, err := uc.inventoryRepo.GetMovementsByProduct(product.ID, request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.InventoryMovementResponse, len(movements))
+	for i, movement := range movements {
+		responses[i] = *converter.InventoryMovementToResponse(&movement)
+	}
+	return responses, total, nil
+}
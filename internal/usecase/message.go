@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type MessageUseCase struct {
+	db           *gorm.DB
+	val          *validator.Validate
+	messageRepo  repo.MessageRepository
+	orderRepo    repo.OrderRepository
+	productRepo  repo.ProductRepository
+	storeRepo    repo.StoreRepository
+	userRepo     repo.UserRepository
+	notification interfaces.NotificationUseCase
+	uuid         *helper.UUIDHelper
+}
+
+func NewMessageUseCase(db *gorm.DB, validate *validator.Validate, messageRepo repo.MessageRepository, orderRepo repo.OrderRepository, productRepo repo.ProductRepository, storeRepo repo.StoreRepository, userRepo repo.UserRepository, notification interfaces.NotificationUseCase, uuid *helper.UUIDHelper) interfaces.MessageUseCase {
+	return &MessageUseCase{
+		db:           db,
+		val:          validate,
+		messageRepo:  messageRepo,
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		storeRepo:    storeRepo,
+		userRepo:     userRepo,
+		notification: notification,
+		uuid:         uuid,
+	}
+}
+
+// StartThread sends a message about an order or, before a purchase, about a
+// product. The first message opens the thread; later calls for the same order
+// or product append to it. Order threads can be started by the order's buyer
+// and by the seller, pre-sale threads only by buyers.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid or does not name exactly one of order_uuid and product_uuid.
+//   - 403 Forbidden: if an admin starts a thread or a seller starts a pre-sale thread.
+//   - 404 Not Found: if the order is not found for the user or the product is not found.
+func (uc *MessageUseCase) StartThread(ctx context.Context, request *model.StartThreadRequest) (*model.ThreadResponse, error) {
+	request.Body = strings.TrimSpace(request.Body)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	if (request.OrderUUID == "") == (request.ProductUUID == "") {
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Either order_uuid or product_uuid is required", nil)
+	}
+
+	thread, err := uc.newThread(request)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.messageRepo.FindOrCreateThread(tx, thread); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	thread, err = uc.messageRepo.FindThreadByUUID(tx, thread.ThreadUUID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	message, err := uc.appendMessage(ctx, tx, thread, request.UserID, request.Role, request.Body, request.Attachments)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	response := converter.ThreadToResponse(thread, 0)
+	response.LastMessageAt = message.CreatedAt.Format("2006-01-02 15:04:05")
+	response.Messages = []model.MessageResponse{*converter.MessageToResponse(message, request.UserID, 0)}
+	return response, nil
+}
+
+// SendMessage appends a message to a thread the user can read.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid.
+//   - 404 Not Found: if the thread is not found or the user is not one of its participants.
+func (uc *MessageUseCase) SendMessage(ctx context.Context, request *model.SendMessageRequest) (*model.MessageResponse, error) {
+	request.Body = strings.TrimSpace(request.Body)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	thread, err := uc.findThread(tx, request.UserID, request.Role, request.ThreadUUID)
+	if err != nil {
+		return nil, err
+	}
+	message, err := uc.appendMessage(ctx, tx, thread, request.UserID, request.Role, request.Body, request.Attachments)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.MessageToResponse(message, request.UserID, 0), nil
+}
+
+// GetThreads lists the threads of the user, most recently active first, with
+// the number of messages the user has not read in each. Buyers see their own
+// threads, sellers the threads of their store and admins every thread.
+func (uc *MessageUseCase) GetThreads(ctx context.Context, request *model.SearchThreadRequest) ([]model.ThreadResponse, int64, error) {
+	if err := uc.scopeRequest(request); err != nil {
+		return nil, 0, err
+	}
+	threads, total, err := uc.messageRepo.GetThreads(request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	threadIDs := make([]uint, len(threads))
+	for i, thread := range threads {
+		threadIDs[i] = thread.ID
+	}
+	unread, err := uc.messageRepo.UnreadCounts(request, threadIDs)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.ThreadResponse, len(threads))
+	for i, thread := range threads {
+		responses[i] = *converter.ThreadToResponse(&thread, unread[thread.ID])
+	}
+	return responses, total, nil
+}
+
+// GetThread returns a thread with a page of its messages, newest first, and
+// the total number of messages. A message is marked read once the other side
+// of the conversation has read it: the store owner for messages of the buyer,
+// the buyer for messages of the seller or an admin.
+//
+// Errors:
+//
+//   - 404 Not Found: if the thread is not found or the user is not one of its participants.
+func (uc *MessageUseCase) GetThread(ctx context.Context, request *model.GetThreadRequest) (*model.ThreadResponse, int64, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, 0, err
+	}
+	thread, err := uc.findThread(uc.db, request.UserID, request.Role, request.ThreadUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	messages, total, err := uc.messageRepo.GetMessages(thread.ID, request.Page, request.Limit)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	receipts, err := uc.messageRepo.GetReadReceipts(thread.ID)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+
+	unread, err := uc.messageRepo.UnreadCounts(&model.SearchThreadRequest{
+		UserID:  request.UserID,
+		Role:    request.Role,
+		StoreID: thread.StoreID,
+	}, []uint{thread.ID})
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+
+	response := converter.ThreadToResponse(thread, unread[thread.ID])
+	response.Messages = make([]model.MessageResponse, len(messages))
+	for i, message := range messages {
+		recipientID := thread.BuyerID
+		if message.SenderRole == "buyer" {
+			recipientID = thread.Store.UserID
+		}
+		response.Messages[i] = *converter.MessageToResponse(&message, request.UserID, receipts[recipientID])
+	}
+	return response, total, nil
+}
+
+// MarkThreadRead moves the user's read receipt to the latest message of the
+// thread.
+//
+// Errors:
+//
+//   - 404 Not Found: if the thread is not found or the user is not one of its participants.
+func (uc *MessageUseCase) MarkThreadRead(ctx context.Context, request *model.GetThreadRequest) (*model.ThreadResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	thread, err := uc.findThread(tx, request.UserID, request.Role, request.ThreadUUID)
+	if err != nil {
+		return nil, err
+	}
+	latestID, err := uc.messageRepo.LatestMessageID(tx, thread.ID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := uc.messageRepo.MarkRead(tx, &entity.MessageThreadRead{
+		ThreadID:          thread.ID,
+		UserID:            request.UserID,
+		LastReadMessageID: latestID,
+		ReadAt:            time.Now(),
+	}); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.ThreadToResponse(thread, 0), nil
+}
+
+// GetUnreadCount returns how many threads have unread messages for the user
+// and how many unread messages there are in total.
+func (uc *MessageUseCase) GetUnreadCount(ctx context.Context, request *model.SearchThreadRequest) (*model.UnreadCountResponse, error) {
+	if err := uc.scopeRequest(request); err != nil {
+		return nil, err
+	}
+	threads, messages, err := uc.messageRepo.CountUnread(request)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return &model.UnreadCountResponse{UnreadThreads: threads, UnreadMessages: messages}, nil
+}
+
+// newThread builds the thread a StartThread request is about, after checking
+// that the user may start it.
+func (uc *MessageUseCase) newThread(request *model.StartThreadRequest) (*entity.MessageThread, error) {
+	thread := &entity.MessageThread{
+		ThreadUUID:    uc.uuid.Generate(),
+		LastMessageAt: time.Now(),
+	}
+	if request.ProductUUID != "" {
+		if request.Role != "buyer" {
+			return nil, model.NewApiError(fiber.StatusForbidden, "Only buyers can ask about a product", nil)
+		}
+		product, err := uc.productRepo.FindProductByUUID(request.ProductUUID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, model.ErrNotFound
+			}
+			return nil, model.ErrInternalServer
+		}
+		thread.ProductID = &product.ID
+		thread.BuyerID = request.UserID
+		thread.StoreID = product.StoreID
+		return thread, nil
+	}
+
+	var order *entity.Order
+	var err error
+	switch request.Role {
+	case "buyer":
+		order, err = uc.orderRepo.GetOrderByIdByBuyer(&model.GetOrderDetails{
+			OrderUUID: request.OrderUUID,
+			UserID:    request.UserID,
+		})
+	case "seller":
+		var store entity.Store
+		store, err = uc.storeRepo.FindStoreByUserID(request.UserID)
+		if err == nil {
+			order, err = uc.orderRepo.GetOrderBySeller(request.OrderUUID, store.ID)
+		}
+	default:
+		return nil, model.NewApiError(fiber.StatusForbidden, "Admins can only reply to existing threads", nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	thread.OrderID = &order.ID
+	thread.BuyerID = order.UserID
+	thread.StoreID = order.StoreID
+	return thread, nil
+}
+
+// findThread loads a thread the user can read: its buyer, the owner of its
+// store, or an admin. Anyone else gets a 404, so the threads of others cannot
+// be told apart from threads that do not exist.
+func (uc *MessageUseCase) findThread(db *gorm.DB, userID uint, role string, threadUUID string) (*entity.MessageThread, error) {
+	thread, err := uc.messageRepo.FindThreadByUUID(db, threadUUID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case role == "admin":
+	case role == "buyer" && thread.BuyerID == userID:
+	case role == "seller" && thread.Store.UserID == userID:
+	default:
+		return nil, model.ErrNotFound
+	}
+	return thread, nil
+}
+
+// appendMessage stores a message of the user in the thread. The sender has
+// read their own message, so the sender's read receipt moves along; the
+// other participants are notified.
+func (uc *MessageUseCase) appendMessage(ctx context.Context, tx *gorm.DB, thread *entity.MessageThread, userID uint, role string, body string, attachments []model.AttachmentRequest) (*entity.Message, error) {
+	sender, err := uc.userRepo.FindUserByID(userID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	message := &entity.Message{
+		MessageUUID: uc.uuid.Generate(),
+		ThreadID:    thread.ID,
+		SenderID:    userID,
+		SenderRole:  role,
+		Body:        body,
+		CreatedAt:   time.Now(),
+		Attachments: make([]entity.MessageAttachment, len(attachments)),
+	}
+	for i, attachment := range attachments {
+		message.Attachments[i] = entity.MessageAttachment{
+			URL:         attachment.URL,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+		}
+	}
+	if err := uc.messageRepo.CreateMessage(tx, message); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	message.Sender = *sender
+	if err := uc.messageRepo.TouchThread(tx, thread.ID, message.CreatedAt); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := uc.messageRepo.MarkRead(tx, &entity.MessageThreadRead{
+		ThreadID:          thread.ID,
+		UserID:            userID,
+		LastReadMessageID: message.ID,
+		ReadAt:            message.CreatedAt,
+	}); err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	senderName := thread.Buyer.Name
+	switch role {
+	case "seller":
+		senderName = thread.Store.StoreName
+	case "admin":
+		senderName = "an admin"
+	}
+	subject := "your question"
+	if thread.Order != nil {
+		subject = "order " + thread.Order.OrderUUID
+	} else if thread.Product != nil {
+		subject = thread.Product.ProductName
+	}
+	var notifications []entity.Notification
+	for _, recipientID := range []uint{thread.BuyerID, thread.Store.UserID} {
+		if recipientID == userID {
+			continue
+		}
+		notifications = append(notifications, entity.Notification{
+			UserID:        recipientID,
+			Type:          "new_message",
+			Title:         "New message",
+			Message:       fmt.Sprintf("New message from %s about %s.", senderName, subject),
+			ReferenceType: "message_thread",
+			ReferenceID:   thread.ThreadUUID,
+		})
+	}
+	if err := uc.notification.Notify(ctx, tx, notifications...); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return message, nil
+}
+
+// scopeRequest resolves the store of a seller, whose threads are the threads
+// of the store.
+func (uc *MessageUseCase) scopeRequest(request *model.SearchThreadRequest) error {
+	if request.Role != "seller" {
+		return nil
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return err
+	}
+	request.StoreID = store.ID
+	return nil
+}
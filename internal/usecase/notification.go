@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type NotificationUseCase struct {
+	db               *gorm.DB
+	val              *validator.Validate
+	notificationRepo repo.NotificationRepository
+	uuid             *helper.UUIDHelper
+}
+
+func NewNotificationUseCase(db *gorm.DB, validate *validator.Validate, notificationRepo repo.NotificationRepository, uuid *helper.UUIDHelper) interfaces.NotificationUseCase {
+	return &NotificationUseCase{
+		db:               db,
+		val:              validate,
+		notificationRepo: notificationRepo,
+		uuid:             uuid,
+	}
+}
+
+// Notify stores in-app notifications inside the given transaction, so they are
+// only delivered when the change that triggered them is committed.
+func (uc *NotificationUseCase) Notify(ctx context.Context, tx *gorm.DB, notifications ...entity.Notification) error {
+	for i := range notifications {
+		notifications[i].NotificationUUID = uc.uuid.Generate()
+	}
+	return uc.notificationRepo.CreateNotifications(tx, notifications)
+}
+
+// GetNotifications returns the notifications of the authenticated user, newest
+// first. When Unread is set only notifications that were not read yet are returned.
+func (uc *NotificationUseCase) GetNotifications(ctx context.Context, request *model.GetNotificationsRequest) ([]model.NotificationResponse, int64, error) {
+	notifications, total, err := uc.notificationRepo.GetNotifications(request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		responses[i] = *converter.NotificationToResponse(&notification)
+	}
+	return responses, total, nil
+}
+
+// MarkAsRead marks a notification of the authenticated user as read.
+//
+// If the notification is not found, it returns a 404 error. Marking an already
+// read notification keeps its original read time.
+func (uc *NotificationUseCase) MarkAsRead(ctx context.Context, request *model.ReadNotificationRequest) (*model.NotificationResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	notification, err := uc.notificationRepo.FindNotificationByUUID(request.UserID, request.NotificationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := uc.notificationRepo.UpdateNotification(notification); err != nil {
+			return nil, model.ErrInternalServer
+		}
+	}
+	return converter.NotificationToResponse(notification), nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
@@ -11,7 +14,11 @@ import (
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
 	eventmodel "github.com/abdisetiakawan/go-ecommerce/internal/model/event_model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	eventrepo "github.com/abdisetiakawan/go-ecommerce/internal/repository/event_repository/interfaces"
 	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
 	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/go-playground/validator/v10"
@@ -25,33 +32,62 @@ type OrderUseCase struct {
 	orderRepo repo.OrderRepository
     productRepo repo.ProductRepository
     storeRepo repo.StoreRepository
+	inventory interfaces.InventoryUseCase
+	warehouse interfaces.WarehouseUseCase
+	exchangeRate interfaces.ExchangeRateUseCase
 	orderEvent ordereventUC.OrderEventUseCase
+	statusRepo repo.OrderStatusRepository
+	eventRepo eventrepo.OrderEventRepository
+	notification interfaces.NotificationUseCase
+	refund    interfaces.RefundUseCase
+	voucher   interfaces.VoucherUseCase
+	promotion interfaces.PromotionUseCase
+	shippingRate interfaces.ShippingRateUseCase
+	taxRate   interfaces.TaxRateUseCase
+	address   interfaces.AddressUseCase
 	uuid      *helper.UUIDHelper
 }
 
-func NewOrderUseCase(db *gorm.DB, validate *validator.Validate, orderRepo repo.OrderRepository, productRepo repo.ProductRepository, storeRepo repo.StoreRepository, uuid *helper.UUIDHelper, orderEvent ordereventUC.OrderEventUseCase) interfaces.OrderUseCase {
+func NewOrderUseCase(db *gorm.DB, validate *validator.Validate, orderRepo repo.OrderRepository, productRepo repo.ProductRepository, storeRepo repo.StoreRepository, inventory interfaces.InventoryUseCase, warehouse interfaces.WarehouseUseCase, exchangeRate interfaces.ExchangeRateUseCase, statusRepo repo.OrderStatusRepository, eventRepo eventrepo.OrderEventRepository, notification interfaces.NotificationUseCase, refund interfaces.RefundUseCase, voucher interfaces.VoucherUseCase, promotion interfaces.PromotionUseCase, shippingRate interfaces.ShippingRateUseCase, taxRate interfaces.TaxRateUseCase, address interfaces.AddressUseCase, uuid *helper.UUIDHelper, orderEvent ordereventUC.OrderEventUseCase) interfaces.OrderUseCase {
 	return &OrderUseCase{
 		db:        db,
 		val:       validate,
 		orderRepo: orderRepo,
         productRepo: productRepo,
         storeRepo: storeRepo,
+		inventory: inventory,
+		warehouse: warehouse,
+		exchangeRate: exchangeRate,
+		statusRepo: statusRepo,
+		eventRepo: eventRepo,
+		notification: notification,
+		refund:    refund,
+		voucher:   voucher,
+		promotion: promotion,
+		shippingRate: shippingRate,
+		taxRate:   taxRate,
+		address:   address,
 		uuid:      uuid,
 		orderEvent: orderEvent,
 	}
 }
 
-// CreateOrder validates the input, verifies product availability and stock,
-// and creates an order with associated order items. It ensures all products
-// belong to the same store and reduces stock for each product. The method
-// then creates an order event with payment and shipping data and commits the
-// transaction. If any step fails, the transaction is rolled back, and an
+// CreateOrder validates the input and places a single order for products that
+// all belong to the same store, see PlaceOrder. The whole order is created in
+// one transaction; if any step fails, the transaction is rolled back and an
 // appropriate error is returned. The function launches an asynchronous
-// process to handle the order event and returns the order response upon success.
+// process to handle the order event and returns the order response upon
+// success. Baskets spanning several stores go through CheckoutUseCase.
+// The order ships to its inline address, or to an address of the buyer's
+// address book, see AddressUseCase.ResolveShippingAddress.
 
 func (uc *OrderUseCase) CreateOrder(ctx context.Context, input *model.CreateOrder) (*model.OrderResponse, error) {
-	tx := uc.db.Begin()
+	tx := uc.db.WithContext(ctx).Begin()
     defer tx.Rollback()
+	input.Currency = strings.ToUpper(input.Currency)
+	if err := uc.address.ResolveShippingAddress(ctx, tx, input.UserID, input.AddressID, &input.ShippingAddress); err != nil {
+		return nil, err
+	}
 	if err := helper.ValidateStruct(uc.val, input); err != nil {
 		return nil, err
 	}
@@ -62,7 +98,7 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, input *model.CreateOrde
 	}
 
 	// val that all products belong to the same store
-	_, err := uc.orderRepo.FindStoreByProductUUIDs(productUUIDs)
+	storeID, err := uc.orderRepo.FindStoreByProductUUIDs(productUUIDs)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, model.NewApiError(fiber.StatusNotFound, "One or more products not found", nil)
@@ -73,68 +109,250 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, input *model.CreateOrde
 		return nil, model.ErrInternalServer
 	}
 
-	var totalPrice float64
-	var orderItems []entity.OrderItem
+	order, orderEvent, err := uc.PlaceOrder(ctx, tx, &model.PlaceOrder{
+		UserID:          input.UserID,
+		StoreID:         storeID,
+		Items:           input.Items,
+		ShippingAddress: input.ShippingAddress,
+		PaymentMethod:   input.Payments.PaymentMethod,
+		Currency:        input.Currency,
+		VoucherCode:     input.VoucherCode,
+		ShippingOption:  input.ShippingOption,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+	go uc.orderEvent.ProcessOrderEvent(ctx, orderEvent)
+
+	return placedOrderToResponse(order, orderEvent)
+}
+
+// PlaceOrder creates one store's order inside the caller's transaction. It
+// verifies product availability and stock, creates the order with its items
+// and reduces stock for each product. Items keep a snapshot of the product's
+// name, SKU, image and unit price, which order reads show from then on, and
+// the order keeps the store it was placed with. Products are loaded in a
+// single query and locked for the duration of the transaction, and every stock
+// decrement is a conditional update, so concurrent orders can never oversell a
+// product. Each decrement is booked as a "sale" movement in the inventory
+// ledger. When the store runs warehouses, a single warehouse that can ship
+// every item is allocated, preferring one in the province of the shipping
+// address, and the stock is taken from it. The order is settled in the store's
+// base currency: prices in other currencies are converted, and the rate to the
+// buyer's display currency is locked on the order and its payment. The running
+// promotions of the store are taken off the items, see
+// PromotionUseCase.ApplyPromotions, then a voucher code is checked and its
+// discount taken off the order and counted, see VoucherUseCase.ApplyVoucher.
+// Tax is charged on the discounted items, see TaxRateUseCase.ApplyTaxes. The
+// parcel is quoted from the allocated warehouse, or the store's origin, to the
+// shipping address; the chosen shipping option, or the cheapest one, is added
+// to the total paid. Finally an "order_created" event with the payment and
+// shipping data is stored.
+//
+// The caller commits the transaction and then hands the returned event to
+// OrderEventUseCase.ProcessOrderEvent.
+func (uc *OrderUseCase) PlaceOrder(ctx context.Context, tx *gorm.DB, input *model.PlaceOrder) (*entity.Order, *evententity.OrderEvent, error) {
+	// the order is settled in the store's base currency, prices in other
+	// currencies are converted at the rate known now
+	store, err := uc.storeRepo.FindStoreByID(tx, input.StoreID)
+	if err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	displayCurrency := input.Currency
+	if displayCurrency == "" {
+		displayCurrency = store.Currency
+	}
+	displayRate, err := uc.exchangeRate.GetRate(ctx, tx, store.Currency, displayCurrency)
+	if err != nil {
+		return nil, nil, err
+	}
+	priceRates := make(map[string]money.Rate)
+
+	productUUIDs := make([]string, len(input.Items))
+	for i, item := range input.Items {
+		productUUIDs[i] = item.ProductUUID
+	}
+	products, err := uc.productRepo.FindProductsByUUIDsForUpdate(tx, productUUIDs)
+	if err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	productByUUID := make(map[string]entity.Product, len(products))
+	productByID := make(map[uint]entity.Product, len(products))
+	for _, product := range products {
+		productByUUID[product.ProductUUID] = product
+		productByID[product.ID] = product
+	}
+
+	quantities := make(map[uint]int, len(products))
 	for _, item := range input.Items {
-		product, err := uc.productRepo.FindProductByUUID(item.ProductUUID)
-		if err != nil {
-			return nil, err
+		product, ok := productByUUID[item.ProductUUID]
+		if !ok {
+			return nil, nil, model.NewApiError(fiber.StatusNotFound, "One or more products not found", nil)
 		}
+		quantities[product.ID] += item.Quantity
+	}
+	warehouse, err := uc.warehouse.Allocate(ctx, tx, input.StoreID, input.ShippingAddress.Province, quantities, productByID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var warehouseID *uint
+	if warehouse != nil {
+		warehouseID = &warehouse.ID
+	}
 
-		if product.Stock < item.Quantity {
-			return nil, model.NewApiError(fiber.StatusConflict, fmt.Sprintf("Product %s has insufficient stock", product.ProductName), nil)
+	orderUUID := uc.uuid.Generate()
+	var totalPrice money.Amount
+	var orderItems []entity.OrderItem
+	for _, item := range input.Items {
+		product, ok := productByUUID[item.ProductUUID]
+		if !ok {
+			return nil, nil, model.NewApiError(fiber.StatusNotFound, "One or more products not found", nil)
 		}
 
-		product.Stock -= item.Quantity
-        if err := uc.productRepo.UpdateProduct(&product); err != nil {
-            return nil, model.ErrInternalServer
-        }
+		if err := uc.inventory.ApplyMovement(ctx, tx, &entity.InventoryMovement{
+			ProductID:     product.ID,
+			WarehouseID:   warehouseID,
+			Quantity:      -item.Quantity,
+			Reason:        "sale",
+			ReferenceType: "order",
+			ReferenceID:   orderUUID,
+			UserID:        &input.UserID,
+		}); err != nil {
+			if err == model.ErrInsufficientStock {
+				return nil, nil, model.NewApiError(fiber.StatusConflict, fmt.Sprintf("Product %s has insufficient stock", product.ProductName), nil)
+			}
+			return nil, nil, model.ErrInternalServer
+		}
 
-		itemTotal := float64(item.Quantity) * product.Price
-		totalPrice += itemTotal
+		rate, ok := priceRates[product.Currency]
+		if !ok {
+			rate, err = uc.exchangeRate.GetRate(ctx, tx, product.Currency, store.Currency)
+			if err != nil {
+				return nil, nil, err
+			}
+			priceRates[product.Currency] = rate
+		}
+		unitPrice := product.Price.Convert(rate)
+		itemTotal := unitPrice.Mul(item.Quantity)
+		totalPrice = totalPrice.Add(itemTotal)
 
 		orderItems = append(orderItems, entity.OrderItem{
 			OrderItemUUID: uc.uuid.Generate(),
 			ProductID:    product.ID,
 			Quantity:     item.Quantity,
+			ProductUUID:  product.ProductUUID,
+			ProductName:  product.ProductName,
+			SKU:          product.SKU,
+			VariantAttributes: product.Attributes,
+			ImageURL:     product.ImageURL,
+			UnitPrice:    unitPrice,
 			TotalPrice:   itemTotal,
+			Status:       "active",
 		})
 	}
 
 	order := &entity.Order{
-		OrderUUID:  uc.uuid.Generate(),
+		OrderUUID:  orderUUID,
 		UserID:     input.UserID,
+		StoreID:    store.ID,
 		Status:     "pending",
 		TotalPrice: totalPrice,
+		Currency:   store.Currency,
+		DisplayCurrency: displayCurrency,
+		ExchangeRate: displayRate,
+		WarehouseID: warehouseID,
+		CheckoutID: input.CheckoutID,
 		Items:      orderItems,
 	}
 
-	if err := uc.orderRepo.CreateOrder(order); err != nil {
-		return nil, model.ErrInternalServer
+	if err := uc.promotion.ApplyPromotions(ctx, tx, order, productByID); err != nil {
+		return nil, nil, err
+	}
+
+	var voucher *entity.Voucher
+	if input.VoucherCode != "" {
+		voucher, err = uc.voucher.FindVoucher(ctx, tx, input.VoucherCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := uc.voucher.ApplyVoucher(ctx, tx, voucher, order, productByID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := uc.taxRate.ApplyTaxes(ctx, tx, store, order, productByID); err != nil {
+		return nil, nil, err
+	}
+
+	parcel := shippingParcel(store, warehouse, &input.ShippingAddress, productByID, quantities)
+	quotes, err := uc.shippingRate.Quote(ctx, tx, parcel, store.Currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	quote, err := chooseShippingOption(quotes, input.ShippingOption)
+	if err != nil {
+		return nil, nil, err
+	}
+	order.ShippingCost = quote.Cost
+	order.TotalPrice = order.TotalPrice.Add(quote.Cost)
+
+	if err := uc.orderRepo.CreateOrder(tx, order); err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	if voucher != nil {
+		if err := uc.voucher.RedeemVoucher(ctx, tx, voucher, order); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+		OrderID:   order.ID,
+		Machine:   string(statemachine.Order),
+		ToStatus:  order.Status,
+		ActorType: "buyer",
+		ActorID:   &input.UserID,
+		Reason:    "Order placed",
+	}); err != nil {
+		return nil, nil, transitionError(err)
 	}
 
 	paymentData, err := json.Marshal(eventmodel.PaymentMessage{
 		OrderID:     order.ID,
 		PaymentUUID: uc.uuid.Generate(),
-		Amount:      totalPrice,
-		Method:      input.Payments.PaymentMethod,
+		Amount:      order.TotalPrice,
+		Currency:    order.Currency,
+		DisplayCurrency: order.DisplayCurrency,
+		ExchangeRate: order.ExchangeRate,
+		Method:      input.PaymentMethod,
 		Status:      "pending",
 	})
 	if err != nil {
-		return nil, model.ErrInternalServer
+		return nil, nil, model.ErrInternalServer
 	}
 	
 	shippingData, err := json.Marshal(eventmodel.ShippingMessage{
 		ShippingUUID: uc.uuid.Generate(),
 		OrderID:       order.ID,
+		RecipientName: input.ShippingAddress.RecipientName,
+		Phone:       input.ShippingAddress.Phone,
 		Address:      input.ShippingAddress.Address,
 		City:        input.ShippingAddress.City,
+		District:    input.ShippingAddress.District,
 		Province:    input.ShippingAddress.Province,
 		PostalCode:  input.ShippingAddress.PostalCode,
 		Status:      "pending",
+		Provider:    quote.Provider,
+		Service:     quote.Service,
+		Cost:        quote.Cost,
+		Weight:      parcel.Weight,
+		EstimatedDays: quote.EstimatedDays,
 	})
 	if err != nil {
-		return nil, model.ErrInternalServer
+		return nil, nil, model.ErrInternalServer
 	}
 	
 	orderEvent := &evententity.OrderEvent{
@@ -148,22 +366,136 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, input *model.CreateOrde
 	
 
     if err := tx.Create(orderEvent).Error; err != nil {
-        return nil, model.ErrInternalServer
+        return nil, nil, model.ErrInternalServer
     }
 
-    if err := tx.Commit().Error; err != nil {
-        return nil, model.ErrInternalServer
-    }
+	return order, orderEvent, nil
+}
 
-	go uc.orderEvent.ProcessOrderEvent(ctx, orderEvent)
+// GetShippingOptions quotes the shipping options of every store's part of a
+// purchase, cheapest first, the way PlaceOrder ships it: from the warehouse
+// that would be allocated now, or the store's origin. Nothing is reserved.
+//
+// Errors:
+//
+//	* 400 Bad Request: if the request is invalid.
+//	* 404 Not Found: if a product does not exist.
+//	* 409 Conflict: if no warehouse of a store can fulfil its items.
+//	* 422 Unprocessable Entity: if a store's items cannot be shipped to the address.
+func (uc *OrderUseCase) GetShippingOptions(ctx context.Context, request *model.ShippingOptionsRequest) (*model.ShippingOptionsResponse, error) {
+	if err := uc.address.ResolveShippingAddress(ctx, uc.db.WithContext(ctx), request.UserID, request.AddressID, &request.ShippingAddress); err != nil {
+		return nil, err
+	}
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	// the transaction only scopes the stock locks taken by the allocation, it
+	// is rolled back once the options are known
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	productUUIDs := make([]string, len(request.Items))
+	for i, item := range request.Items {
+		productUUIDs[i] = item.ProductUUID
+	}
+	products, err := uc.productRepo.FindProductsByUUIDs(tx, productUUIDs)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	productByUUID := make(map[string]entity.Product, len(products))
+	productByID := make(map[uint]entity.Product, len(products))
+	for _, product := range products {
+		productByUUID[product.ProductUUID] = product
+		productByID[product.ID] = product
+	}
+
+	var storeIDs []uint
+	stores := make(map[uint]entity.Store)
+	quantitiesByStore := make(map[uint]map[uint]int)
+	for _, item := range request.Items {
+		product, ok := productByUUID[item.ProductUUID]
+		if !ok {
+			return nil, model.NewApiError(fiber.StatusNotFound, "One or more products not found", nil)
+		}
+		if quantitiesByStore[product.StoreID] == nil {
+			storeIDs = append(storeIDs, product.StoreID)
+			stores[product.StoreID] = product.Store
+			quantitiesByStore[product.StoreID] = make(map[uint]int)
+		}
+		quantitiesByStore[product.StoreID][product.ID] += item.Quantity
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
 
+	response := &model.ShippingOptionsResponse{Stores: make([]model.StoreShippingOptions, 0, len(storeIDs))}
+	for _, storeID := range storeIDs {
+		store := stores[storeID]
+		quantities := quantitiesByStore[storeID]
+		warehouse, err := uc.warehouse.Allocate(ctx, tx, storeID, request.ShippingAddress.Province, quantities, productByID)
+		if err != nil {
+			return nil, err
+		}
+		parcel := shippingParcel(&store, warehouse, &request.ShippingAddress, productByID, quantities)
+		quotes, err := uc.shippingRate.Quote(ctx, tx, parcel, store.Currency)
+		if err != nil {
+			return nil, err
+		}
+		options := make([]model.ShippingOptionResponse, len(quotes))
+		for i := range quotes {
+			options[i] = *converter.ShippingQuoteToResponse(&quotes[i])
+		}
+		response.Stores = append(response.Stores, model.StoreShippingOptions{
+			StoreName: store.StoreName,
+			Weight:    parcel.Weight,
+			Options:   options,
+		})
+	}
+	return response, nil
+}
+
+// shippingParcel describes the parcel of an order: its items, shipped from
+// the allocated warehouse, or from the store's origin when there is none, to
+// the shipping address.
+func shippingParcel(store *entity.Store, warehouse *entity.Warehouse, address *model.ShippingAddressRequest, products map[uint]entity.Product, quantities map[uint]int) *shipping.Parcel {
+	items := make([]shipping.Item, 0, len(quantities))
+	for productID, quantity := range quantities {
+		product := products[productID]
+		items = append(items, shipping.Item{
+			Weight:   product.Weight,
+			Length:   product.Length,
+			Width:    product.Width,
+			Height:   product.Height,
+			Quantity: quantity,
+		})
+	}
+	parcel := &shipping.Parcel{
+		OriginCity:            store.OriginCity,
+		OriginProvince:        store.OriginProvince,
+		OriginPostalCode:      store.OriginPostalCode,
+		DestinationCity:       address.City,
+		DestinationProvince:   address.Province,
+		DestinationPostalCode: address.PostalCode,
+		Weight:                shipping.BillableWeight(items),
+	}
+	if warehouse != nil {
+		parcel.OriginCity = warehouse.City
+		parcel.OriginProvince = warehouse.Province
+		parcel.OriginPostalCode = warehouse.PostalCode
+	}
+	return parcel
+}
+
+// placedOrderToResponse builds the response of a freshly placed order from the
+// payment and shipping data carried by its event, as the payment and shipping
+// rows are only created later by the consumers.
+func placedOrderToResponse(order *entity.Order, orderEvent *evententity.OrderEvent) (*model.OrderResponse, error) {
 	var paymentMessage eventmodel.PaymentMessage
-	if err := json.Unmarshal(paymentData, &paymentMessage); err != nil {
+	if err := json.Unmarshal(orderEvent.PaymentData, &paymentMessage); err != nil {
 		return nil, model.ErrInternalServer
 	}
 
 	var shippingMessage eventmodel.ShippingMessage
-	if err := json.Unmarshal(shippingData, &shippingMessage); err != nil {
+	if err := json.Unmarshal(orderEvent.ShippingData, &shippingMessage); err != nil {
 		return nil, model.ErrInternalServer
 	}
 
@@ -173,7 +505,10 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, input *model.CreateOrde
 
 // GetOrdersByBuyer returns a list of orders made by a buyer, given a search request.
 //
-// The search request can filter by status, start date, end date, page, and limit.
+// The search request can filter by status, start and end date, total range,
+// payment method and free text (order UUID, buyer name, product name), see
+// model.OrderFilter, and is paged by page and limit. The orders of a buyer come
+// from stores with different currencies, so a total range needs a currency.
 //
 // The response is a list of ListOrderResponse, which only contains the basic information of the order.
 //
@@ -182,6 +517,12 @@ func (uc *OrderUseCase) GetOrdersByBuyer(ctx context.Context, request *model.Sea
     if err := helper.ValidateStruct(uc.val, request); err != nil {
         return nil, 0, err
     }
+    if err := validateOrderFilter(&request.OrderFilter); err != nil {
+        return nil, 0, err
+    }
+    if (request.MinTotal != nil || request.MaxTotal != nil) && request.Currency == "" {
+        return nil, 0, model.NewApiError(fiber.StatusBadRequest, "currency is required with min_total or max_total", nil)
+    }
     tasks, total, err := uc.orderRepo.GetOrdersByBuyer(request)
     if err != nil {
         return nil, 0, model.ErrInternalServer
@@ -193,6 +534,21 @@ func (uc *OrderUseCase) GetOrdersByBuyer(ctx context.Context, request *model.Sea
     return responses, total, nil
 }
 
+// validateOrderFilter checks that the ranges of an order list filter are not
+// reversed and that totals are not negative.
+func validateOrderFilter(filter *model.OrderFilter) error {
+    if filter.StartDate != "" && filter.EndDate != "" && filter.EndDate < filter.StartDate {
+        return model.NewApiError(fiber.StatusBadRequest, "end_date must not be before start_date", nil)
+    }
+    if (filter.MinTotal != nil && *filter.MinTotal < 0) || (filter.MaxTotal != nil && *filter.MaxTotal < 0) {
+        return model.NewApiError(fiber.StatusBadRequest, "min_total and max_total must not be negative", nil)
+    }
+    if filter.MinTotal != nil && filter.MaxTotal != nil && *filter.MaxTotal < *filter.MinTotal {
+        return model.NewApiError(fiber.StatusBadRequest, "max_total must not be less than min_total", nil)
+    }
+    return nil
+}
+
 // GetOrderByIdByBuyer returns a single order by order UUID and user ID.
 //
 // The response is a OrderResponse, which contains the detailed information of the order.
@@ -213,7 +569,8 @@ func(uc *OrderUseCase) GetOrderByIdByBuyer(ctx context.Context, request *model.G
     //
     // If the order is not found, it returns a 404 error.
     //
-    // If the order status is not "pending", it returns a 409 error.
+    // If the order state machine does not allow the order to be cancelled (only "pending" orders can be), it returns a 409 error.
+    // The change is recorded in the order status history with the buyer as actor.
     //
     // The function will return an error if there is an error when updating the order in the database.
     //
@@ -238,15 +595,389 @@ func (uc *OrderUseCase) CancelOrder(ctx context.Context, request *model.CancelOr
         return nil, model.NewApiError(fiber.StatusNotFound, "Order not found", nil)
     }
 
-    if order.Status == "completed" || order.Status == "cancelled" || order.Status == "shipped" || order.Status == "processed" {
-        return nil, model.NewApiError(fiber.StatusConflict, fmt.Sprintf("Order with ID %s cannot be cancelled, current status is %s", request.OrderUUID, order.Status), nil)
+    orderEvent, err := uc.CancelPendingOrder(ctx, tx, order, "buyer", &request.UserID, "Cancelled by buyer")
+    if err != nil {
+        return nil, err
     }
-    
-    order.Status = "cancelled"
-    if err := tx.Model(&entity.Order{}).Where("id = ?", order.ID).Update("status", "cancelled").Error; err != nil {
+
+    if err := tx.Commit().Error; err != nil {
         return nil, model.ErrInternalServer
     }
 
+    go uc.orderEvent.CancelOrderEvent(ctx, orderEvent)
+	order.Shipping.Status = "cancelled"
+	order.Payment.Status = "cancelled"
+    return converter.OrderToResponse(order), nil
+}
+
+// ConfirmOrderReceipt lets the buyer confirm that a shipped or delivered
+// order has arrived, which completes it. A seller's "delivered" is only a
+// delivery claim; the order stays delivered until the buyer confirms or
+// OrderPolicyUseCase completes it after the timeout. Confirming an order the
+// seller has not marked delivered yet records the delivery first, and a
+// disputed order is completed once the buyer confirms the problem is solved.
+// The seller is notified of the completion.
+func (uc *OrderUseCase) ConfirmOrderReceipt(ctx context.Context, request *model.ConfirmOrderRequest) (*model.OrderResponse, error) {
+    tx := uc.db.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    if err := helper.ValidateStruct(uc.val, request); err != nil {
+        return nil, err
+    }
+
+    order, err := uc.orderRepo.GetOrderByIdByBuyer(&model.GetOrderDetails{
+        OrderUUID: request.OrderUUID,
+        UserID:    request.UserID,
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    const reason = "Receipt confirmed by buyer"
+    if order.Status == "shipped" {
+        if order.Shipping == nil {
+            return nil, model.NewApiError(fiber.StatusConflict, "Order has no shipping", nil)
+        }
+        if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+            OrderID:    order.ID,
+            Machine:    string(statemachine.Shipping),
+            FromStatus: order.Shipping.Status,
+            ToStatus:   "delivered",
+            ActorType:  "buyer",
+            ActorID:    &request.UserID,
+            Reason:     reason,
+        }); err != nil {
+            return nil, transitionError(err)
+        }
+        if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+            OrderID:    order.ID,
+            Machine:    string(statemachine.Order),
+            FromStatus: order.Status,
+            ToStatus:   "delivered",
+            ActorType:  "buyer",
+            ActorID:    &request.UserID,
+            Reason:     reason,
+        }); err != nil {
+            return nil, transitionError(err)
+        }
+        order.Shipping.Status = "delivered"
+        order.Status = "delivered"
+    }
+
+    if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+        OrderID:    order.ID,
+        Machine:    string(statemachine.Order),
+        FromStatus: order.Status,
+        ToStatus:   "completed",
+        ActorType:  "buyer",
+        ActorID:    &request.UserID,
+        Reason:     reason,
+    }); err != nil {
+        return nil, transitionError(err)
+    }
+    order.Status = "completed"
+
+    if err := uc.notifySeller(ctx, tx, order, entity.Notification{
+        Type:    "order_completed",
+        Title:   "Order completed",
+        Message: fmt.Sprintf("The buyer confirmed receipt of order %s.", order.OrderUUID),
+    }); err != nil {
+        return nil, err
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+    return converter.OrderToResponse(order), nil
+}
+
+// ReportOrderProblem lets the buyer report a problem with a shipped or
+// delivered order instead of confirming receipt. The order becomes
+// "disputed", which stops OrderPolicyUseCase from completing it, and the
+// seller is notified with the buyer's reason.
+func (uc *OrderUseCase) ReportOrderProblem(ctx context.Context, request *model.ReportOrderProblemRequest) (*model.OrderResponse, error) {
+    tx := uc.db.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    request.Reason = strings.TrimSpace(request.Reason)
+    if err := helper.ValidateStruct(uc.val, request); err != nil {
+        return nil, err
+    }
+
+    order, err := uc.orderRepo.GetOrderByIdByBuyer(&model.GetOrderDetails{
+        OrderUUID: request.OrderUUID,
+        UserID:    request.UserID,
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+        OrderID:    order.ID,
+        Machine:    string(statemachine.Order),
+        FromStatus: order.Status,
+        ToStatus:   "disputed",
+        ActorType:  "buyer",
+        ActorID:    &request.UserID,
+        Reason:     "Problem reported by buyer: " + request.Reason,
+    }); err != nil {
+        return nil, transitionError(err)
+    }
+    order.Status = "disputed"
+
+    if err := uc.notifySeller(ctx, tx, order, entity.Notification{
+        Type:    "order_disputed",
+        Title:   "Problem reported",
+        Message: fmt.Sprintf("The buyer reported a problem with order %s: %s", order.OrderUUID, request.Reason),
+    }); err != nil {
+        return nil, err
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+    return converter.OrderToResponse(order), nil
+}
+
+// notifySeller sends a notification about the order to the owner of the
+// store it was placed with.
+func (uc *OrderUseCase) notifySeller(ctx context.Context, tx *gorm.DB, order *entity.Order, notification entity.Notification) error {
+    store, err := uc.storeRepo.FindStoreByID(tx, order.StoreID)
+    if err != nil {
+        return err
+    }
+    notification.UserID = store.UserID
+    notification.ReferenceType = "order"
+    notification.ReferenceID = order.OrderUUID
+    return uc.notification.Notify(ctx, tx, notification)
+}
+
+// CancelOrderItemByBuyer cancels one item of the buyer's order, see
+// cancelOrderItem. The seller is notified.
+func (uc *OrderUseCase) CancelOrderItemByBuyer(ctx context.Context, request *model.CancelOrderItemRequest) (*model.OrderResponse, error) {
+    if err := helper.ValidateStruct(uc.val, request); err != nil {
+        return nil, err
+    }
+    return uc.cancelOrderItem(ctx, request, "buyer", func() (*entity.Order, error) {
+        return uc.orderRepo.GetOrderByIdByBuyer(&model.GetOrderDetails{
+            OrderUUID: request.OrderUUID,
+            UserID:    request.UserID,
+        })
+    })
+}
+
+// CancelOrderItemBySeller cancels one item of an order of the seller's store,
+// see cancelOrderItem. The buyer is notified.
+func (uc *OrderUseCase) CancelOrderItemBySeller(ctx context.Context, request *model.CancelOrderItemRequest) (*model.OrderResponse, error) {
+    if err := helper.ValidateStruct(uc.val, request); err != nil {
+        return nil, err
+    }
+    store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+    if err != nil {
+        return nil, err
+    }
+    return uc.cancelOrderItem(ctx, request, "seller", func() (*entity.Order, error) {
+        return uc.orderRepo.GetOrderBySeller(request.OrderUUID, store.ID)
+    })
+}
+
+// cancelOrderItem drops one item from a pending or processed order. The
+// item's price and discount are taken off the order and its stock is put
+// back. When the remaining items no longer reach the minimum spend of the
+// order's voucher, the voucher is dropped and its discount added back to
+// them, see VoucherUseCase.RecheckVoucher. The payment status decides how
+// the buyer gets the difference: a pending payment of a pending order follows
+// the total, while a "paid" or "partially_refunded" payment keeps the amount
+// that was charged and the difference is refunded, see
+// RefundUseCase.IssueRefund. A processed order whose payment has not settled
+// yet is refused until it has. An "order_updated" event is published for the
+// new total. The last remaining item cannot be cancelled, the whole order has
+// to be cancelled instead.
+//
+// Errors:
+//
+//	* 404 Not Found: if the order or the item is not found.
+//	* 409 Conflict: if the order has been shipped, the item is already cancelled or is the last one, the payment is not created or settled yet, or dropping the voucher of a paid order would cost more than the item.
+func (uc *OrderUseCase) cancelOrderItem(ctx context.Context, request *model.CancelOrderItemRequest, actorType string, findOrder func() (*entity.Order, error)) (*model.OrderResponse, error) {
+    tx := uc.db.WithContext(ctx).Begin()
+    defer tx.Rollback()
+
+    order, err := findOrder()
+    if err != nil {
+        return nil, err
+    }
+    // reload once the order is locked, so concurrent cancellations see each
+    // other's changes
+    if err := uc.orderRepo.LockOrder(tx, order.ID); err != nil {
+        return nil, model.ErrInternalServer
+    }
+    if order, err = findOrder(); err != nil {
+        return nil, err
+    }
+
+    if order.Status != "pending" && order.Status != "processed" {
+        return nil, model.NewApiError(fiber.StatusConflict,
+            fmt.Sprintf("Cannot cancel items of a %s order", order.Status), nil)
+    }
+    if order.Payment == nil {
+        return nil, model.NewApiError(fiber.StatusConflict, "Order payment is still being created, please retry", nil)
+    }
+    // a paid order is processed before its payment is marked paid, the
+    // difference can neither be taken off nor refunded in between
+    paymentPending := order.Status == "pending" && order.Payment.Status == "pending"
+    paymentSettled := order.Payment.Status == "paid" || order.Payment.Status == "partially_refunded"
+    if !paymentPending && !paymentSettled {
+        return nil, model.NewApiError(fiber.StatusConflict, "Order payment has not settled yet, please retry", nil)
+    }
+
+    var item *entity.OrderItem
+    active := 0
+    for i := range order.Items {
+        if order.Items[i].OrderItemUUID == request.OrderItemUUID {
+            item = &order.Items[i]
+        }
+        if order.Items[i].Status != "cancelled" {
+            active++
+        }
+    }
+    if item == nil {
+        return nil, model.NewApiError(fiber.StatusNotFound, "Order item not found", nil)
+    }
+    if item.Status == "cancelled" {
+        return nil, model.NewApiError(fiber.StatusConflict, "Order item is already cancelled", nil)
+    }
+    if active == 1 {
+        return nil, model.NewApiError(fiber.StatusConflict, "Cannot cancel the last item of an order, cancel the order instead", nil)
+    }
+
+    reason := fmt.Sprintf("Item %s cancelled by %s", item.OrderItemUUID, actorType)
+    if note := strings.TrimSpace(request.Reason); note != "" {
+        reason += ": " + note
+    }
+
+    now := time.Now()
+    item.Status = "cancelled"
+    item.CancelledAt = &now
+    order.TotalPrice = order.TotalPrice.Sub(item.TotalPrice)
+    order.TaxAmount = order.TaxAmount.Sub(item.TaxAmount)
+    order.DiscountAmount = order.DiscountAmount.Sub(item.DiscountAmount)
+    totalBefore := order.TotalPrice
+    repriced, err := uc.voucher.RecheckVoucher(ctx, tx, order)
+    if err != nil {
+        return nil, err
+    }
+    // refund is what the buyer gets back: the item's price less the voucher
+    // discount the other items lost
+    refund := item.TotalPrice.Sub(order.TotalPrice.Sub(totalBefore))
+    if refund < 0 && paymentSettled {
+        return nil, model.NewApiError(fiber.StatusConflict, "Cancelling this item drops the order below the voucher's minimum spend, cancel the order instead", nil)
+    }
+    if paymentPending {
+        order.Payment.Amount = order.Payment.Amount.Sub(refund)
+    }
+    if err := uc.orderRepo.CancelOrderItem(tx, order, item, repriced); err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+    if err := uc.inventory.ApplyMovement(ctx, tx, &entity.InventoryMovement{
+        ProductID:     item.ProductID,
+        WarehouseID:   order.WarehouseID,
+        Quantity:      item.Quantity,
+        Reason:        "cancel",
+        ReferenceType: "order_item",
+        ReferenceID:   item.OrderItemUUID,
+        UserID:        &request.UserID,
+    }); err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+    var refundEvent *evententity.OrderEvent
+    if paymentSettled && refund > 0 {
+        if _, refundEvent, err = uc.refund.IssueRefund(ctx, tx, order, &model.IssueRefund{
+            Amount:        refund,
+            Reason:        reason,
+            ReferenceType: "order_item",
+            ReferenceID:   item.OrderItemUUID,
+        }); err != nil {
+            return nil, err
+        }
+    }
+
+    orderData, err := json.Marshal(eventmodel.OrderMessage{
+        OrderID:    order.ID,
+        OrderUUID:  order.OrderUUID,
+        UserID:     order.UserID,
+        Status:     order.Status,
+        TotalPrice: order.TotalPrice,
+        ActorType:  actorType,
+        ActorID:    &request.UserID,
+        Reason:     reason,
+    })
+    if err != nil {
+        return nil, model.ErrInternalServer
+    }
+    orderEvent := &evententity.OrderEvent{
+        EventUUID: uc.uuid.Generate(),
+        OrderID:   order.ID,
+        EventType: "order_updated",
+        Status:    "pending",
+        OrderData: orderData,
+    }
+    if err := tx.Create(orderEvent).Error; err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+    notification := entity.Notification{
+        Type:    "order_updated",
+        Title:   "Order item cancelled",
+        Message: fmt.Sprintf("%s was removed from order %s. The new total is %s %s.", item.ProductName, order.OrderUUID, order.TotalPrice, order.Currency),
+    }
+    if actorType == "buyer" {
+        err = uc.notifySeller(ctx, tx, order, notification)
+    } else {
+        notification.UserID = order.UserID
+        notification.ReferenceType = "order"
+        notification.ReferenceID = order.OrderUUID
+        err = uc.notification.Notify(ctx, tx, notification)
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    if err := tx.Commit().Error; err != nil {
+        return nil, model.ErrInternalServer
+    }
+
+    go uc.orderEvent.OrderUpdatedEvent(ctx, orderEvent)
+    if refundEvent != nil {
+        go uc.orderEvent.RefundPaymentEvent(ctx, refundEvent)
+    }
+    return converter.OrderToResponse(order), nil
+}
+
+// CancelPendingOrder cancels a pending order inside the caller's transaction:
+// the change is checked against the order state machine and recorded with the
+// given actor and reason, the stock of every item that was not cancelled on
+// its own is put back, a voucher use is given back and an "order_cancelled"
+// event is stored. The caller
+// commits the transaction and then hands the returned event to
+// OrderEventUseCase.CancelOrderEvent.
+func (uc *OrderUseCase) CancelPendingOrder(ctx context.Context, tx *gorm.DB, order *entity.Order, actorType string, actorID *uint, reason string) (*evententity.OrderEvent, error) {
+    if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+        OrderID:    order.ID,
+        Machine:    string(statemachine.Order),
+        FromStatus: order.Status,
+        ToStatus:   "cancelled",
+        ActorType:  actorType,
+        ActorID:    actorID,
+        Reason:     reason,
+    }); err != nil {
+        return nil, transitionError(err)
+    }
+    order.Status = "cancelled"
+
     paymentStatus, err := json.Marshal(eventmodel.PaymentMessage{
         OrderID: order.ID,
         Status:  "cancelled",
@@ -277,22 +1008,29 @@ func (uc *OrderUseCase) CancelOrder(ctx context.Context, request *model.CancelOr
     }
 
     for _, item := range order.Items {
-        if err := tx.Exec("UPDATE products SET stock = stock + ? WHERE id = ?", item.Quantity, item.ProductID).Error; err != nil {
+        if item.Status == "cancelled" {
+            continue
+        }
+        if err := uc.inventory.ApplyMovement(ctx, tx, &entity.InventoryMovement{
+            ProductID:     item.ProductID,
+            WarehouseID:   order.WarehouseID,
+            Quantity:      item.Quantity,
+            Reason:        "cancel",
+            ReferenceType: "order",
+            ReferenceID:   order.OrderUUID,
+            UserID:        actorID,
+        }); err != nil {
             return nil, model.ErrInternalServer
         }
     }
-
-    if err := tx.Commit().Error; err != nil {
-        return nil, model.ErrInternalServer
+    if err := uc.voucher.ReleaseVoucher(ctx, tx, order); err != nil {
+        return nil, err
     }
-
-    go uc.orderEvent.CancelOrderEvent(ctx, orderEvent)
-	order.Shipping.Status = "cancelled"
-	order.Payment.Status = "cancelled"
-    return converter.OrderToResponse(order), nil
+    return orderEvent, nil
 }
 
     // CheckoutOrder checks out an order. It will only work if the order status is "pending". If the order status is not "pending", it will return an error.
+    // Orders that belong to a multi-store checkout are paid through the checkout and return a 409 error here.
     // It will update the order status to "processed" and create an order event with type "payment_processed" and status "pending".
     // It will then commit the transaction and send the order event to kafka topic to be processed.
     // If there is an error when committing the transaction or sending the order event, it will rollback the transaction and return an error.
@@ -312,13 +1050,42 @@ func (uc *OrderUseCase) CheckoutOrder(ctx context.Context, request *model.Checko
         return nil, err
     }
 
-    if order.Status != "pending" {
-        return nil, model.NewApiError(fiber.StatusConflict, fmt.Sprintf("Order with ID %s cannot be checked out, current status is %s", request.OrderUUID, order.Status), nil)
+    if order.CheckoutID != nil {
+        return nil, model.NewApiError(fiber.StatusConflict, "Order is part of a checkout, pay the checkout instead", nil)
     }
-    order.Status = "processed"
-    if err := uc.orderRepo.UpdateOrder(order); err != nil {
+
+    orderEvent, err := uc.PayOrder(ctx, tx, order)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := tx.Commit().Error; err != nil {
         return nil, model.ErrInternalServer
     }
+
+	go uc.orderEvent.CheckoutOrderEvent(ctx, orderEvent)
+	order.Payment.Status = "paid"
+    return converter.OrderToResponse(order), nil
+}
+
+// PayOrder marks a pending order as paid inside the caller's transaction: the
+// order moves to "processed", the change is recorded in the status history and
+// a "payment_processed" event is stored. Orders that are not pending return a
+// 409 error. The caller commits the transaction
+// and then hands the returned event to OrderEventUseCase.CheckoutOrderEvent.
+func (uc *OrderUseCase) PayOrder(ctx context.Context, tx *gorm.DB, order *entity.Order) (*evententity.OrderEvent, error) {
+    if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+        OrderID:    order.ID,
+        Machine:    string(statemachine.Order),
+        FromStatus: order.Status,
+        ToStatus:   "processed",
+        ActorType:  "buyer",
+        ActorID:    &order.UserID,
+        Reason:     "Payment submitted",
+    }); err != nil {
+        return nil, transitionError(err)
+    }
+    order.Status = "processed"
 	paymentStatus, err := json.Marshal(eventmodel.PaymentMessage{
 		OrderID: order.ID,
 		Status:  "paid",
@@ -336,14 +1103,7 @@ func (uc *OrderUseCase) CheckoutOrder(ctx context.Context, request *model.Checko
 	if err := tx.Create(orderEvent).Error; err != nil {
 		return nil, model.ErrInternalServer
 	}
-
-    if err := tx.Commit().Error; err != nil {
-        return nil, model.ErrInternalServer
-    }
-
-	go uc.orderEvent.CheckoutOrderEvent(ctx, orderEvent)
-	order.Payment.Status = "paid"
-    return converter.OrderToResponse(order), nil
+	return orderEvent, nil
 }
 
 // GetOrdersBySeller retrieves a list of orders for a specific seller based on the provided search request.
@@ -361,11 +1121,18 @@ func (u *OrderUseCase) GetOrdersBySeller(ctx context.Context, request *model.Sea
 	if err := helper.ValidateStruct(u.val, request); err != nil {
 		return nil, 0, err
 	}
+	if err := validateOrderFilter(&request.OrderFilter); err != nil {
+		return nil, 0, err
+	}
 	store, err := u.storeRepo.FindStoreByUserID(request.UserID)
     if err != nil {
         return nil, 0, err
     }
 	request.StoreID = store.ID
+	// totals without a currency are those of the store currency
+	if request.Currency == "" {
+		request.Currency = store.Currency
+	}
 	orders, total, err := u.orderRepo.GetOrdersBySeller(request)
 	if err != nil {
 		return nil, 0, err
@@ -383,7 +1150,7 @@ func (u *OrderUseCase) GetOrdersBySeller(ctx context.Context, request *model.Sea
 // with the seller's user ID and fetches the order linked to that store.
 // 
 // Returns:
-// - A OrderResponse containing the details of the order.
+// - A OrderResponse containing the details of the order, including the warehouse allocated to fulfil it.
 // - An error, if any issue occurs during the process.
 func (u *OrderUseCase) GetOrderBySeller(ctx context.Context, request *model.GetOrderDetails) (*model.OrderResponse, error) {
 	if err := helper.ValidateStruct(u.val, request); err != nil {
@@ -397,5 +1164,55 @@ func (u *OrderUseCase) GetOrderBySeller(ctx context.Context, request *model.GetO
 	if err != nil {
 		return nil, err
 	}
-	return converter.OrderToResponse(order), nil
-}
\ No newline at end of file
+	response := converter.OrderToResponse(order)
+	response.Warehouse = converter.WarehouseToSummary(order.Warehouse)
+	return response, nil
+}
+
+// GetOrderTimelineByBuyer returns the lifecycle of one of the buyer's orders,
+// see orderTimeline.
+func (u *OrderUseCase) GetOrderTimelineByBuyer(ctx context.Context, request *model.GetOrderDetails) (*model.OrderTimelineResponse, error) {
+	if err := helper.ValidateStruct(u.val, request); err != nil {
+		return nil, err
+	}
+	order, err := u.orderRepo.GetOrderByIdByBuyer(request)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, model.NewApiError(fiber.StatusNotFound, "Order not found", nil)
+	}
+	return u.orderTimeline(ctx, order)
+}
+
+// GetOrderTimelineBySeller returns the lifecycle of an order of the seller's
+// store, see orderTimeline.
+func (u *OrderUseCase) GetOrderTimelineBySeller(ctx context.Context, request *model.GetOrderDetails) (*model.OrderTimelineResponse, error) {
+	if err := helper.ValidateStruct(u.val, request); err != nil {
+		return nil, err
+	}
+	store, err := u.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := u.orderRepo.GetOrderBySeller(request.OrderUUID, store.ID)
+	if err != nil {
+		return nil, err
+	}
+	return u.orderTimeline(ctx, order)
+}
+
+// orderTimeline builds the ordered list of lifecycle events of an order from
+// its status history. Orders placed before the history was recorded fall
+// back to their order events.
+func (u *OrderUseCase) orderTimeline(ctx context.Context, order *entity.Order) (*model.OrderTimelineResponse, error) {
+	history, err := u.statusRepo.GetHistoryByOrderID(u.db.WithContext(ctx), order.ID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	events, err := u.eventRepo.GetEventsByOrderID(order.ID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.OrderTimelineToResponse(order, history, events), nil
+}
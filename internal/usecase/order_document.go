@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"github.com/abdisetiakawan/go-ecommerce/internal/pdf"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// documentTypes holds the number prefix and the printed name of each
+// document type.
+var documentTypes = map[string]struct{ prefix, name string }{
+	"invoice": {prefix: "INV", name: "Invoice"},
+	"receipt": {prefix: "RCP", name: "Receipt"},
+}
+
+type OrderDocumentUseCase struct {
+	db           *gorm.DB
+	val          *validator.Validate
+	documentRepo repo.OrderDocumentRepository
+	orderRepo    repo.OrderRepository
+	storeRepo    repo.StoreRepository
+	userRepo     repo.UserRepository
+	profileRepo  repo.ProfileRepository
+	statusRepo   repo.OrderStatusRepository
+	uuid         *helper.UUIDHelper
+}
+
+func NewOrderDocumentUseCase(db *gorm.DB, validate *validator.Validate, documentRepo repo.OrderDocumentRepository, orderRepo repo.OrderRepository, storeRepo repo.StoreRepository, userRepo repo.UserRepository, profileRepo repo.ProfileRepository, statusRepo repo.OrderStatusRepository, uuid *helper.UUIDHelper) interfaces.OrderDocumentUseCase {
+	return &OrderDocumentUseCase{
+		db:           db,
+		val:          validate,
+		documentRepo: documentRepo,
+		orderRepo:    orderRepo,
+		storeRepo:    storeRepo,
+		userRepo:     userRepo,
+		profileRepo:  profileRepo,
+		statusRepo:   statusRepo,
+		uuid:         uuid,
+	}
+}
+
+// GetDocumentByBuyer returns the invoice or receipt of one of the buyer's
+// orders, see issueDocument.
+func (uc *OrderDocumentUseCase) GetDocumentByBuyer(ctx context.Context, request *model.GetOrderDocumentRequest) (*model.OrderDocumentFile, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	return uc.issueDocument(ctx, request.Type, func() (*entity.Order, error) {
+		return uc.orderRepo.GetOrderByIdByBuyer(&model.GetOrderDetails{
+			OrderUUID: request.OrderUUID,
+			UserID:    request.UserID,
+		})
+	})
+}
+
+// GetDocumentBySeller returns the invoice or receipt of an order of the
+// seller's store, see issueDocument.
+func (uc *OrderDocumentUseCase) GetDocumentBySeller(ctx context.Context, request *model.GetOrderDocumentRequest) (*model.OrderDocumentFile, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.issueDocument(ctx, request.Type, func() (*entity.Order, error) {
+		return uc.orderRepo.GetOrderBySeller(request.OrderUUID, store.ID)
+	})
+}
+
+// issueDocument returns the stored document of the order. The first request
+// renders the PDF from the order as it is at that moment, gives it the next
+// number of the store and stores it; every later request returns the same
+// bytes. An invoice can be issued for any order that was not cancelled, a
+// receipt once the order has been paid.
+//
+// Errors:
+//
+//   - 404 Not Found: if the order is not found.
+//   - 409 Conflict: if an invoice is requested for a cancelled order or a receipt for an unpaid order.
+func (uc *OrderDocumentUseCase) issueDocument(ctx context.Context, documentType string, findOrder func() (*entity.Order, error)) (*model.OrderDocumentFile, error) {
+	order, err := findOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := uc.documentRepo.FindDocument(uc.db.WithContext(ctx), order.ID, documentType)
+	if err == nil {
+		return documentFile(document), nil
+	}
+	if err != model.ErrNotFound {
+		return nil, model.ErrInternalServer
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// reload once the order is locked, so the document matches the order a
+	// concurrent item cancellation leaves behind and is issued only once: a
+	// concurrent first request waits for the lock and then finds the
+	// document, which only a locking read sees inside this transaction
+	if err := uc.orderRepo.LockOrder(tx, order.ID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if order, err = findOrder(); err != nil {
+		return nil, err
+	}
+	document, err = uc.documentRepo.FindDocumentForUpdate(tx, order.ID, documentType)
+	if err == nil {
+		return documentFile(document), nil
+	}
+	if err != model.ErrNotFound {
+		return nil, model.ErrInternalServer
+	}
+
+	var paidAt time.Time
+	switch documentType {
+	case "invoice":
+		if order.Status == "cancelled" {
+			return nil, model.NewApiError(fiber.StatusConflict, "Cannot issue an invoice for a cancelled order", nil)
+		}
+	case "receipt":
+		if order.Payment == nil || order.Payment.Status == "pending" || order.Payment.Status == "cancelled" {
+			return nil, model.NewApiError(fiber.StatusConflict, "A receipt is issued once the order is paid", nil)
+		}
+		if paidAt, err = uc.paidAt(tx, order); err != nil {
+			return nil, err
+		}
+	}
+	if len(order.Items) == 0 {
+		return nil, model.ErrNotFound
+	}
+
+	content := orderDocumentContent{order: order, documentType: documentType, paidAt: paidAt}
+	if content.store, err = uc.storeRepo.FindStoreByID(tx, order.StoreID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if content.owner, err = uc.userRepo.FindUserByID(content.store.UserID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if content.buyer, err = uc.userRepo.FindUserByID(order.UserID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	// the store owner's contact details come from their profile, if any
+	if content.ownerProfile, err = uc.profileRepo.GetProfileByUserID(content.store.UserID); err != nil && err != model.ErrNotFound {
+		return nil, model.ErrInternalServer
+	}
+
+	sequence, err := uc.documentRepo.NextSequence(tx, content.store.ID, documentType)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	content.number = fmt.Sprintf("%s/%d/%06d", documentTypes[documentType].prefix, content.store.ID, sequence)
+	content.issuedAt = time.Now()
+	rendered := content.render()
+	checksum := sha256.Sum256(rendered)
+
+	document = &entity.OrderDocument{
+		DocumentUUID: uc.uuid.Generate(),
+		OrderID:      order.ID,
+		StoreID:      content.store.ID,
+		Type:         documentType,
+		Sequence:     sequence,
+		Number:       content.number,
+		Content:      rendered,
+		Checksum:     hex.EncodeToString(checksum[:]),
+		CreatedAt:    content.issuedAt,
+	}
+	if err := uc.documentRepo.CreateDocument(tx, document); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return documentFile(document), nil
+}
+
+// paidAt returns when the payment of the order was marked paid.
+func (uc *OrderDocumentUseCase) paidAt(db *gorm.DB, order *entity.Order) (time.Time, error) {
+	history, err := uc.statusRepo.GetHistoryByOrderID(db, order.ID)
+	if err != nil {
+		return time.Time{}, model.ErrInternalServer
+	}
+	for _, change := range history {
+		if change.Machine == string(statemachine.Payment) && change.ToStatus == "paid" {
+			return change.CreatedAt, nil
+		}
+	}
+	// payments settled before the status history was recorded
+	return order.Payment.UpdatedAt, nil
+}
+
+func documentFile(document *entity.OrderDocument) *model.OrderDocumentFile {
+	return &model.OrderDocumentFile{
+		Number:   document.Number,
+		FileName: strings.ReplaceAll(document.Number, "/", "-") + ".pdf",
+		Checksum: document.Checksum,
+		Content:  document.Content,
+	}
+}
+
+// orderDocumentContent holds everything printed on an invoice or a receipt.
+type orderDocumentContent struct {
+	documentType string
+	number       string
+	issuedAt     time.Time
+	paidAt       time.Time
+	order        *entity.Order
+	store        *entity.Store
+	owner        *entity.User
+	ownerProfile *entity.Profile
+	buyer        *entity.User
+}
+
+const (
+	documentMargin = 50.0
+	documentRight  = pdf.PageWidth - documentMargin
+	documentBottom = pdf.PageHeight - 80
+)
+
+// render lays the document out on A4 pages: the store and the document
+// number, the buyer and the order, the active order items, and the totals.
+// Items continue on further pages when they do not fit.
+func (c *orderDocumentContent) render() []byte {
+	name := documentTypes[c.documentType].name
+	doc := pdf.New(fmt.Sprintf("%s %s", name, c.number))
+	order := c.order
+
+	doc.Text(documentMargin, 70, pdf.Bold, 22, strings.ToUpper(name))
+	doc.Text(documentMargin, 90, pdf.Regular, 10, "No. "+c.number)
+	doc.Text(documentMargin, 104, pdf.Regular, 10, "Issued "+c.issuedAt.Format("2006-01-02 15:04:05"))
+
+	storeLines := []string{c.owner.Name, c.owner.Email}
+	if c.ownerProfile != nil {
+		storeLines = append(storeLines, c.ownerProfile.PhoneNumber, c.ownerProfile.Address)
+	}
+	doc.TextRight(documentRight, 70, pdf.Bold, 12, c.store.StoreName)
+	y := 86.0
+	for _, line := range storeLines {
+		if line == "" {
+			continue
+		}
+		doc.TextRight(documentRight, y, pdf.Regular, 9, pdf.Truncate(line, pdf.Regular, 9, 250))
+		y += 12
+	}
+
+	y = 150
+	doc.Text(documentMargin, y, pdf.Bold, 10, "Billed to")
+	buyerLines := []string{c.buyer.Name, c.buyer.Email}
+	if order.Shipping != nil {
+		if order.Shipping.RecipientName != "" {
+			buyerLines = append(buyerLines, strings.TrimSpace("Ship to "+order.Shipping.RecipientName+" "+order.Shipping.Phone))
+		}
+		city := order.Shipping.City
+		if order.Shipping.District != "" {
+			city = order.Shipping.District + ", " + city
+		}
+		buyerLines = append(buyerLines,
+			order.Shipping.Address,
+			fmt.Sprintf("%s, %s %s", city, order.Shipping.Province, order.Shipping.PostalCode))
+	}
+	for i, line := range buyerLines {
+		doc.Text(documentMargin, y+14+float64(i)*12, pdf.Regular, 9, pdf.Truncate(line, pdf.Regular, 9, 240))
+	}
+
+	details := [][2]string{
+		{"Order", order.OrderUUID},
+		{"Order date", order.CreatedAt.Format("2006-01-02 15:04:05")},
+	}
+	if order.Payment != nil {
+		details = append(details, [2]string{"Payment method", order.Payment.Method})
+	}
+	if c.documentType == "receipt" {
+		details = append(details, [2]string{"Paid on", c.paidAt.Format("2006-01-02 15:04:05")})
+	}
+	doc.Text(320, y, pdf.Bold, 10, "Order details")
+	for i, detail := range details {
+		doc.Text(320, y+14+float64(i)*12, pdf.Regular, 9, detail[0])
+		doc.TextRight(documentRight, y+14+float64(i)*12, pdf.Regular, 9, detail[1])
+	}
+
+	y = 250
+	itemsHeader := func() {
+		doc.Text(documentMargin, y, pdf.Bold, 9, "No.")
+		doc.Text(documentMargin+30, y, pdf.Bold, 9, "Item")
+		doc.TextRight(350, y, pdf.Bold, 9, "Qty")
+		doc.TextRight(445, y, pdf.Bold, 9, "Unit price")
+		doc.TextRight(documentRight, y, pdf.Bold, 9, "Amount")
+		doc.Line(documentMargin, y+6, documentRight, y+6)
+		y += 20
+	}
+	itemsHeader()
+	var subtotal money.Amount
+	number := 0
+	for _, item := range order.Items {
+		if item.Status == "cancelled" {
+			continue
+		}
+		if y > documentBottom {
+			doc.AddPage()
+			y = 70
+			itemsHeader()
+		}
+		number++
+		// lines are shown before tax unless the store's prices include it
+		amount := item.TotalPrice
+		if !order.TaxInclusive {
+			amount = amount.Sub(item.TaxAmount)
+		}
+		doc.Text(documentMargin, y, pdf.Regular, 9, fmt.Sprintf("%d", number))
+		doc.Text(documentMargin+30, y, pdf.Regular, 9, pdf.Truncate(item.ProductName, pdf.Regular, 9, 220))
+		doc.TextRight(350, y, pdf.Regular, 9, fmt.Sprintf("%d", item.Quantity))
+		doc.TextRight(445, y, pdf.Regular, 9, formatDocumentAmount(amount.MulRatio(1, int64(item.Quantity))))
+		doc.TextRight(documentRight, y, pdf.Regular, 9, formatDocumentAmount(amount))
+		subtotal = subtotal.Add(amount)
+		y += 16
+	}
+	doc.Line(documentMargin, y-8, documentRight, y-8)
+
+	totals := [][2]string{{"Subtotal", formatDocumentAmount(subtotal)}}
+	if !order.TaxAmount.IsZero() {
+		label := "Tax"
+		if order.TaxInclusive {
+			label = "Tax (included)"
+		}
+		totals = append(totals, [2]string{label, formatDocumentAmount(order.TaxAmount)})
+	}
+	if !order.ShippingCost.IsZero() {
+		label := "Shipping"
+		if order.Shipping != nil && order.Shipping.Service != "" {
+			label += " (" + order.Shipping.Service + ")"
+		}
+		totals = append(totals, [2]string{label, formatDocumentAmount(order.ShippingCost)})
+	}
+	totals = append(totals, [2]string{"Total (" + order.Currency + ")", formatDocumentAmount(order.TotalPrice)})
+	if order.DisplayCurrency != "" && order.DisplayCurrency != order.Currency {
+		totals = append(totals, [2]string{
+			fmt.Sprintf("Total in %s at %s", order.DisplayCurrency, order.ExchangeRate.String()),
+			formatDocumentAmount(order.TotalPrice.Convert(order.ExchangeRate)),
+		})
+	}
+	if c.documentType == "receipt" {
+		totals = append(totals, [2]string{"Amount paid (" + order.Payment.Currency + ")", formatDocumentAmount(order.Payment.Amount)})
+		if !order.Payment.RefundedAmount.IsZero() {
+			totals = append(totals, [2]string{"Refunded", formatDocumentAmount(order.Payment.RefundedAmount)})
+		}
+	}
+	y += 6
+	for i, total := range totals {
+		if y > documentBottom {
+			doc.AddPage()
+			y = 70
+		}
+		font := pdf.Regular
+		if i == 1 {
+			font = pdf.Bold
+		}
+		doc.TextRight(445, y, font, 10, total[0])
+		doc.TextRight(documentRight, y, font, 10, total[1])
+		y += 16
+	}
+
+	doc.Text(documentMargin, pdf.PageHeight-40, pdf.Regular, 8,
+		fmt.Sprintf("%s %s was issued electronically by %s and is valid without a signature.", name, c.number, c.store.StoreName))
+	return doc.Bytes()
+}
+
+// formatDocumentAmount formats an amount with thousands separators, e.g.
+// 1,250,000.00.
+func formatDocumentAmount(amount money.Amount) string {
+	value := amount.String()
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", value[1:]
+	}
+	whole, fraction, _ := strings.Cut(value, ".")
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String() + "." + fraction
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestCancelOrderItemPayment cancels one of two items and checks that the
+// payment status alone decides whether the payment amount is lowered or the
+// item is refunded.
+func TestCancelOrderItemPayment(t *testing.T) {
+	const orderUUID = "0b8f3c1e-6a2d-4f5b-9c7e-1d2e3f4a5b6c"
+	const itemUUID = "5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e8f"
+	tests := []struct {
+		name          string
+		orderStatus   string
+		paymentStatus string
+		wantStatus    int
+		wantAmount    money.Amount
+		wantRefund    money.Amount
+	}{
+		{name: "unpaid order", orderStatus: "pending", paymentStatus: "pending", wantAmount: 3000},
+		{name: "paid order", orderStatus: "processed", paymentStatus: "paid", wantAmount: 5000, wantRefund: 2000},
+		{name: "partially refunded order", orderStatus: "processed", paymentStatus: "partially_refunded", wantAmount: 5000, wantRefund: 2000},
+		{name: "paid order before the payment settled", orderStatus: "processed", paymentStatus: "pending", wantStatus: fiber.StatusConflict},
+		{name: "pending order with a cancelled payment", orderStatus: "pending", paymentStatus: "cancelled", wantStatus: fiber.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := &fakeItemOrderRepository{order: entity.Order{
+				Model:      gorm.Model{ID: 9},
+				OrderUUID:  orderUUID,
+				UserID:     1,
+				StoreID:    1,
+				Status:     tt.orderStatus,
+				Currency:   "IDR",
+				TotalPrice: 5000,
+				Items: []entity.OrderItem{
+					{Model: gorm.Model{ID: 1}, OrderItemUUID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", ProductID: 1, Quantity: 1, Status: "active", TotalPrice: 3000},
+					{Model: gorm.Model{ID: 2}, OrderItemUUID: itemUUID, ProductID: 2, Quantity: 2, Status: "active", TotalPrice: 2000},
+				},
+				ExchangeRate: money.RateOne,
+				Payment:      &entity.Payment{Status: tt.paymentStatus, Amount: 5000, Currency: "IDR"},
+				Shipping:     &entity.Shipping{Status: "pending"},
+			}}
+			inventory := &fakeItemInventoryUseCase{}
+			refunds := &fakeRefundUseCase{}
+			uc := newTestCancelItemUseCase(t, orders, inventory, refunds)
+
+			response, err := uc.CancelOrderItemByBuyer(context.Background(), &model.CancelOrderItemRequest{
+				UserID:        1,
+				OrderUUID:     orderUUID,
+				OrderItemUUID: itemUUID,
+			})
+			if tt.wantStatus != 0 {
+				var apiErr *model.ApiError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+				}
+				if orders.cancelled != nil || len(inventory.movements) != 0 || len(refunds.requests) != 0 {
+					t.Errorf("refused cancellation changed the order: cancelled %v, movements %v, refunds %v", orders.cancelled, inventory.movements, refunds.requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if response.TotalPrice != 3000 || orders.cancelled == nil || orders.cancelled.TotalPrice != 3000 {
+				t.Errorf("order total %s, want 3000.00 left", response.TotalPrice)
+			}
+			if got := orders.cancelled.Payment.Amount; got != tt.wantAmount {
+				t.Errorf("payment amount = %s, want %s", got, tt.wantAmount)
+			}
+			var refunded money.Amount
+			for _, request := range refunds.requests {
+				refunded = refunded.Add(request.Amount)
+				if request.ReferenceType != "order_item" || request.ReferenceID != itemUUID {
+					t.Errorf("refund references %s %s, want the cancelled item", request.ReferenceType, request.ReferenceID)
+				}
+			}
+			if refunded != tt.wantRefund || len(refunds.requests) > 1 {
+				t.Errorf("refunds %v, want %s refunded once", refunds.requests, tt.wantRefund)
+			}
+			if len(inventory.movements) != 1 || inventory.movements[0].ProductID != 2 || inventory.movements[0].Quantity != 2 {
+				t.Errorf("movements %v, want the 2 units of the item put back", inventory.movements)
+			}
+		})
+	}
+}
+
+func newTestCancelItemUseCase(t *testing.T, orders *fakeItemOrderRepository, inventory *fakeItemInventoryUseCase, refunds *fakeRefundUseCase) *OrderUseCase {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(newStockDB()),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &OrderUseCase{
+		db:           db,
+		val:          validator.New(),
+		orderRepo:    orders,
+		storeRepo:    fakeStoreRepository{},
+		inventory:    inventory,
+		orderEvent:   fakeItemOrderEventUseCase{},
+		notification: fakeNotificationUseCase{},
+		refund:       refunds,
+		voucher:      &VoucherUseCase{},
+		uuid:         helper.NewUUIDHelper(),
+	}
+}
+
+// fakeItemOrderRepository hands out a copy of one order, as every read of
+// the real repository does, and keeps the order it was asked to save.
+type fakeItemOrderRepository struct {
+	repo.OrderRepository
+	order     entity.Order
+	cancelled *entity.Order
+}
+
+func (r *fakeItemOrderRepository) GetOrderByIdByBuyer(request *model.GetOrderDetails) (*entity.Order, error) {
+	order := r.order
+	order.Items = append([]entity.OrderItem(nil), r.order.Items...)
+	payment := *r.order.Payment
+	order.Payment = &payment
+	return &order, nil
+}
+
+func (r *fakeItemOrderRepository) LockOrder(db *gorm.DB, orderID uint) error {
+	return nil
+}
+
+func (r *fakeItemOrderRepository) CancelOrderItem(db *gorm.DB, order *entity.Order, item *entity.OrderItem, repriced []entity.OrderItem) error {
+	r.cancelled = order
+	return nil
+}
+
+type fakeItemInventoryUseCase struct {
+	interfaces.InventoryUseCase
+	movements []entity.InventoryMovement
+}
+
+func (uc *fakeItemInventoryUseCase) ApplyMovement(ctx context.Context, tx *gorm.DB, movement *entity.InventoryMovement) error {
+	uc.movements = append(uc.movements, *movement)
+	return nil
+}
+
+type fakeRefundUseCase struct {
+	interfaces.RefundUseCase
+	requests []model.IssueRefund
+}
+
+func (uc *fakeRefundUseCase) IssueRefund(ctx context.Context, tx *gorm.DB, order *entity.Order, request *model.IssueRefund) (*entity.Refund, *evententity.OrderEvent, error) {
+	uc.requests = append(uc.requests, *request)
+	return &entity.Refund{Amount: request.Amount}, &evententity.OrderEvent{EventType: "refund_issued"}, nil
+}
+
+type fakeNotificationUseCase struct {
+	interfaces.NotificationUseCase
+}
+
+func (fakeNotificationUseCase) Notify(ctx context.Context, tx *gorm.DB, notifications ...entity.Notification) error {
+	return nil
+}
+
+type fakeItemOrderEventUseCase struct {
+	ordereventUC.OrderEventUseCase
+}
+
+func (fakeItemOrderEventUseCase) OrderUpdatedEvent(ctx context.Context, event *evententity.OrderEvent) error {
+	return nil
+}
+
+func (fakeItemOrderEventUseCase) RefundPaymentEvent(ctx context.Context, event *evententity.OrderEvent) error {
+	return nil
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
+	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// orderPolicyBatchSize caps the orders handled by each step of a run, the
+// rest is picked up by the next run.
+const orderPolicyBatchSize = 100
+
+// OrderPolicyConfig holds the deadlines of the order policies. Zero values
+// fall back to the defaults of NewOrderPolicyUseCase.
+type OrderPolicyConfig struct {
+	// PaymentWindow is how long a pending order waits for payment before it
+	// is cancelled.
+	PaymentWindow time.Duration
+	// PaymentReminder is how long before the payment deadline the buyer is
+	// reminded to pay.
+	PaymentReminder time.Duration
+	// CompletionDelay is how long after delivery an order is completed.
+	CompletionDelay time.Duration
+	// CompletionReminder is how long before the completion deadline the buyer
+	// is told the order is about to be completed.
+	CompletionReminder time.Duration
+}
+
+type OrderPolicyUseCase struct {
+	db           *gorm.DB
+	orderRepo    repo.OrderRepository
+	statusRepo   repo.OrderStatusRepository
+	order        interfaces.OrderUseCase
+	notification interfaces.NotificationUseCase
+	orderEvent   ordereventUC.OrderEventUseCase
+	config       OrderPolicyConfig
+}
+
+func NewOrderPolicyUseCase(db *gorm.DB, orderRepo repo.OrderRepository, statusRepo repo.OrderStatusRepository, order interfaces.OrderUseCase, notification interfaces.NotificationUseCase, orderEvent ordereventUC.OrderEventUseCase, config OrderPolicyConfig) interfaces.OrderPolicyUseCase {
+	if config.PaymentWindow <= 0 {
+		config.PaymentWindow = 24 * time.Hour
+	}
+	if config.PaymentReminder <= 0 {
+		config.PaymentReminder = 6 * time.Hour
+	}
+	if config.CompletionDelay <= 0 {
+		config.CompletionDelay = 7 * 24 * time.Hour
+	}
+	if config.CompletionReminder <= 0 {
+		config.CompletionReminder = 24 * time.Hour
+	}
+	return &OrderPolicyUseCase{
+		db:           db,
+		orderRepo:    orderRepo,
+		statusRepo:   statusRepo,
+		order:        order,
+		notification: notification,
+		orderEvent:   orderEvent,
+		config:       config,
+	}
+}
+
+// Run applies every order policy once. It is called periodically by a
+// scheduler:
+//
+//   - buyers of pending orders are reminded to pay PaymentReminder before the
+//     payment deadline;
+//   - pending orders older than PaymentWindow are cancelled and their stock is
+//     put back, like a cancellation by the buyer;
+//   - buyers of delivered orders are told CompletionReminder before the
+//     order is completed automatically;
+//   - delivered orders are completed CompletionDelay after delivery.
+//
+// Every order is handled in its own transaction and every change goes through
+// the order state machine, so runs on several instances do not act on the
+// same order twice. A failing order is logged and retried on the next run.
+func (uc *OrderPolicyUseCase) Run(ctx context.Context) error {
+	now := time.Now()
+	paymentDeadline := now.Add(-uc.config.PaymentWindow)
+	completionDeadline := now.Add(-uc.config.CompletionDelay)
+	db := uc.db.WithContext(ctx)
+
+	reminders, err := uc.orderRepo.FindUnpaidOrders(db, paymentDeadline.Add(uc.config.PaymentReminder), true, orderPolicyBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range reminders {
+		if reminders[i].CreatedAt.Before(paymentDeadline) {
+			// cancelled below, a reminder would be pointless
+			continue
+		}
+		uc.remindPayment(ctx, &reminders[i], now)
+	}
+
+	unpaid, err := uc.orderRepo.FindUnpaidOrders(db, paymentDeadline, false, orderPolicyBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range unpaid {
+		uc.cancelUnpaid(ctx, &unpaid[i])
+	}
+
+	reminders, err = uc.orderRepo.FindDeliveredOrders(db, completionDeadline.Add(uc.config.CompletionReminder), true, orderPolicyBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range reminders {
+		uc.remindCompletion(ctx, &reminders[i], now)
+	}
+
+	delivered, err := uc.orderRepo.FindDeliveredOrders(db, completionDeadline, false, orderPolicyBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range delivered {
+		uc.completeDelivered(ctx, &delivered[i])
+	}
+	return nil
+}
+
+func (uc *OrderPolicyUseCase) remindPayment(ctx context.Context, order *entity.Order, now time.Time) {
+	deadline := order.CreatedAt.Add(uc.config.PaymentWindow)
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		marked, err := uc.orderRepo.MarkPaymentReminderSent(tx, order.ID, now)
+		if err != nil || !marked {
+			return err
+		}
+		return uc.notification.Notify(ctx, tx, entity.Notification{
+			UserID:        order.UserID,
+			Type:          "payment_reminder",
+			Title:         "Complete your payment",
+			Message:       fmt.Sprintf("Order %s will be cancelled if it is not paid by %s.", order.OrderUUID, deadline.Format("2006-01-02 15:04:05")),
+			ReferenceType: "order",
+			ReferenceID:   order.OrderUUID,
+		})
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("order_uuid", order.OrderUUID).Error("Failed to send payment reminder")
+	}
+}
+
+func (uc *OrderPolicyUseCase) cancelUnpaid(ctx context.Context, order *entity.Order) {
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	orderEvent, err := uc.order.CancelPendingOrder(ctx, tx, order, "system", nil,
+		fmt.Sprintf("Not paid within %s", formatPolicyDuration(uc.config.PaymentWindow)))
+	if err != nil {
+		logPolicyError(err, order, "Failed to cancel unpaid order")
+		return
+	}
+	if err := uc.notification.Notify(ctx, tx, entity.Notification{
+		UserID:        order.UserID,
+		Type:          "order_expired",
+		Title:         "Order cancelled",
+		Message:       fmt.Sprintf("Order %s was cancelled because it was not paid in time.", order.OrderUUID),
+		ReferenceType: "order",
+		ReferenceID:   order.OrderUUID,
+	}); err != nil {
+		logPolicyError(err, order, "Failed to cancel unpaid order")
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		logPolicyError(err, order, "Failed to cancel unpaid order")
+		return
+	}
+
+	go uc.orderEvent.CancelOrderEvent(context.Background(), orderEvent)
+}
+
+func (uc *OrderPolicyUseCase) remindCompletion(ctx context.Context, order *entity.Order, now time.Time) {
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		marked, err := uc.orderRepo.MarkCompletionReminderSent(tx, order.ID, now)
+		if err != nil || !marked {
+			return err
+		}
+		return uc.notification.Notify(ctx, tx, entity.Notification{
+			UserID:        order.UserID,
+			Type:          "completion_reminder",
+			Title:         "Order will be completed soon",
+			Message:       fmt.Sprintf("Order %s will be completed automatically within %s. Contact the seller if something is wrong with it.", order.OrderUUID, formatPolicyDuration(uc.config.CompletionReminder)),
+			ReferenceType: "order",
+			ReferenceID:   order.OrderUUID,
+		})
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("order_uuid", order.OrderUUID).Error("Failed to send completion reminder")
+	}
+}
+
+func (uc *OrderPolicyUseCase) completeDelivered(ctx context.Context, order *entity.Order) {
+	err := uc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := uc.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+			OrderID:    order.ID,
+			Machine:    string(statemachine.Order),
+			FromStatus: order.Status,
+			ToStatus:   "completed",
+			ActorType:  "system",
+			Reason:     fmt.Sprintf("Completed automatically %s after delivery", formatPolicyDuration(uc.config.CompletionDelay)),
+		}); err != nil {
+			return err
+		}
+		return uc.notification.Notify(ctx, tx, entity.Notification{
+			UserID:        order.UserID,
+			Type:          "order_completed",
+			Title:         "Order completed",
+			Message:       fmt.Sprintf("Order %s has been completed.", order.OrderUUID),
+			ReferenceType: "order",
+			ReferenceID:   order.OrderUUID,
+		})
+	})
+	if err != nil {
+		logPolicyError(err, order, "Failed to complete delivered order")
+	}
+}
+
+// logPolicyError logs a failed policy step. Orders changed by someone else in
+// the meantime, such as a buyer paying right at the deadline, are expected
+// and only logged at debug level.
+func logPolicyError(err error, order *entity.Order, message string) {
+	entry := logrus.WithError(err).WithField("order_uuid", order.OrderUUID)
+	var apiErr *model.ApiError
+	if errors.Is(err, statemachine.ErrStaleStatus) || errors.Is(err, statemachine.ErrInvalidTransition) ||
+		(errors.As(err, &apiErr) && apiErr.StatusCode == fiber.StatusConflict) {
+		entry.Debug(message)
+		return
+	}
+	entry.Error(message)
+}
+
+// formatPolicyDuration renders a deadline for buyers, e.g. "7 days" or
+// "6 hours".
+func formatPolicyDuration(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d%day == 0 && d >= day:
+		return pluralize(int64(d/day), "day")
+	case d%time.Hour == 0 && d >= time.Hour:
+		return pluralize(int64(d/time.Hour), "hour")
+	case d%time.Minute == 0 && d >= time.Minute:
+		return pluralize(int64(d/time.Minute), "minute")
+	}
+	return d.String()
+}
+
+func pluralize(n int64, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
+	"github.com/gofiber/fiber/v2"
+)
+
+// transitionError maps an error of OrderStatusRepository.Transition or
+// statemachine.Check to an API error.
+func transitionError(err error) error {
+	var transition *statemachine.TransitionError
+	switch {
+	case errors.As(err, &transition):
+		return model.NewApiError(fiber.StatusConflict,
+			fmt.Sprintf("Cannot change %s status from %s to %s", transition.Machine, transition.From, transition.To), nil)
+	case errors.Is(err, statemachine.ErrStaleStatus):
+		return model.NewApiError(fiber.StatusConflict, "Order was changed by another request, please retry", nil)
+	}
+	return model.ErrInternalServer
+}
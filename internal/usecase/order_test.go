@@ -0,0 +1,513 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"github.com/abdisetiakawan/go-ecommerce/internal/repository"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
+	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestCreateOrderConcurrentStock places more orders at once than there are
+// units in stock and checks that exactly the available units are sold and
+// stock never goes below zero. The product repository is the real one, its
+// SQL runs against stockDB.
+func TestCreateOrderConcurrentStock(t *testing.T) {
+	const stock = 5
+	const buyers = 40
+
+	db := newStockDB(&stockRow{
+		id:          1,
+		productUUID: "7d1f6c0e-4d55-4b8a-9a43-0c2b6f3e9d11",
+		storeID:     1,
+		productName: "Keyboard",
+		price:       "1500.00",
+		currency:    "IDR",
+		stock:       stock,
+	})
+	orders := &fakeOrderRepository{}
+	uc := newTestOrderUseCase(t, db, orders, &fakeWarehouseRepository{})
+
+	var wg sync.WaitGroup
+	results := make(chan error, buyers)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(userID uint) {
+			defer wg.Done()
+			_, err := uc.CreateOrder(context.Background(), &model.CreateOrder{
+				UserID: userID,
+				Items:  []model.OrderItemRequest{{ProductUUID: "7d1f6c0e-4d55-4b8a-9a43-0c2b6f3e9d11", Quantity: 1}},
+				ShippingAddress: model.ShippingAddressRequest{
+					Address:    "Jl. Merdeka 1",
+					City:       "Bandung",
+					Province:   "Jawa Barat",
+					PostalCode: "40111",
+				},
+				Payments: model.PaymentRequest{PaymentMethod: "transfer"},
+			})
+			results <- err
+		}(uint(i + 1))
+	}
+	wg.Wait()
+	close(results)
+
+	placed := 0
+	for err := range results {
+		if err == nil {
+			placed++
+			continue
+		}
+		var apiErr *model.ApiError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != fiber.StatusConflict {
+			t.Fatalf("unexpected error: %v, queries: %q", err, db.queries)
+		}
+	}
+
+	if placed != stock {
+		t.Errorf("placed %d orders, want %d", placed, stock)
+	}
+	if len(orders.orders) != stock {
+		t.Errorf("created %d orders, want %d", len(orders.orders), stock)
+	}
+	for _, order := range orders.orders {
+		if order.StoreID != 1 {
+			t.Errorf("order kept store %d, want 1", order.StoreID)
+		}
+	}
+	if left := db.rows[1].stock; left != 0 {
+		t.Errorf("stock left %d, want 0", left)
+	}
+	if db.lowest < 0 {
+		t.Errorf("stock went down to %d", db.lowest)
+	}
+	if !db.sawQuery("FOR UPDATE") || !db.sawQuery("stock >= ?") {
+		t.Errorf("products were not locked and decremented conditionally, queries: %v", db.queries)
+	}
+}
+
+func newTestOrderUseCase(t *testing.T, stock *stockDB, orders *fakeOrderRepository, warehouses *fakeWarehouseRepository) interfaces.OrderUseCase {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(stock),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	products := testProductRepository{repository.NewProductRepository(db), stock}
+	uuid := helper.NewUUIDHelper()
+	inventory := NewInventoryUseCase(db, validator.New(), fakeInventoryRepository{}, products, warehouses, nil, nil, uuid)
+	warehouse := NewWarehouseUseCase(db, validator.New(), warehouses, fakeStoreRepository{}, products, uuid)
+	return NewOrderUseCase(db, validator.New(), orders, products, fakeStoreRepository{}, inventory,
+		warehouse, fakeExchangeRateUseCase{}, fakeOrderStatusRepository{}, nil, nil, nil, nil,
+		fakePromotionUseCase{}, fakeShippingRateUseCase{}, fakeTaxRateUseCase{}, fakeAddressUseCase{},
+		uuid, fakeOrderEventUseCase{})
+}
+
+// stockDB is an in-memory products table behind a database/sql driver. It
+// runs the locking read and the stock updates of the product repository the
+// way MySQL does: FOR UPDATE and UPDATE lock the row until the transaction
+// ends, the WHERE clause of an update is checked against the row as it is
+// then, and a rollback restores the stock. Order events are inserted without
+// being kept, any other query fails.
+type stockDB struct {
+	mu      sync.Mutex
+	rows    map[uint]*stockRow
+	queries []string
+	lowest  int
+	lastID  int64
+}
+
+type stockRow struct {
+	lock        sync.Mutex
+	id          uint
+	productUUID string
+	storeID     uint
+	productName string
+	price       string
+	currency    string
+	stock       int
+}
+
+func newStockDB(rows ...*stockRow) *stockDB {
+	db := &stockDB{rows: make(map[uint]*stockRow, len(rows))}
+	for _, row := range rows {
+		db.rows[row.id] = row
+	}
+	return db
+}
+
+func (db *stockDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &stockConn{db: db}, nil
+}
+
+func (db *stockDB) Driver() driver.Driver {
+	return nil
+}
+
+func (db *stockDB) sawQuery(fragment string) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, query := range db.queries {
+		if strings.Contains(query, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// stockConn is a connection to stockDB with the row locks and the undo log
+// of its open transaction.
+type stockConn struct {
+	db     *stockDB
+	locked []*stockRow
+	undo   []func()
+}
+
+func (c *stockConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("stockdb: prepared statements are not supported")
+}
+
+func (c *stockConn) Close() error {
+	return nil
+}
+
+func (c *stockConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *stockConn) Commit() error {
+	c.end()
+	return nil
+}
+
+func (c *stockConn) Rollback() error {
+	c.db.mu.Lock()
+	for i := len(c.undo) - 1; i >= 0; i-- {
+		c.undo[i]()
+	}
+	c.db.mu.Unlock()
+	c.end()
+	return nil
+}
+
+func (c *stockConn) end() {
+	for _, row := range c.locked {
+		row.lock.Unlock()
+	}
+	c.locked = nil
+	c.undo = nil
+}
+
+// lock takes the row lock unless the transaction already holds it.
+func (c *stockConn) lock(row *stockRow) {
+	for _, locked := range c.locked {
+		if locked == row {
+			return
+		}
+	}
+	row.lock.Lock()
+	c.locked = append(c.locked, row)
+}
+
+func (c *stockConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query)
+	if !strings.HasPrefix(query, "SELECT * FROM `products` WHERE product_uuid IN") {
+		return nil, fmt.Errorf("stockdb: unexpected query %q", query)
+	}
+	uuids := make(map[string]bool, len(args))
+	for _, arg := range args {
+		uuids[arg.Value.(string)] = true
+	}
+	var rows []*stockRow
+	for _, row := range c.db.rows {
+		if uuids[row.productUUID] {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].id < rows[j].id })
+	result := &stockRows{}
+	for _, row := range rows {
+		if strings.HasSuffix(query, "FOR UPDATE") {
+			c.lock(row)
+		}
+		c.db.mu.Lock()
+		result.values = append(result.values, []driver.Value{
+			int64(row.id), row.productUUID, int64(row.storeID), row.productName, []byte(row.price), row.currency, int64(row.stock),
+		})
+		c.db.mu.Unlock()
+	}
+	return result, nil
+}
+
+func (c *stockConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+	if strings.HasPrefix(query, "INSERT INTO `order_events`") {
+		c.db.mu.Lock()
+		defer c.db.mu.Unlock()
+		c.db.lastID++
+		return stockResult(c.db.lastID), nil
+	}
+	if !strings.HasPrefix(query, "UPDATE `products` SET `stock`=stock") {
+		return nil, fmt.Errorf("stockdb: unexpected statement %q", query)
+	}
+	row, ok := c.db.rows[uint(argAt(query, "id = ?", args))]
+	if !ok {
+		return driver.RowsAffected(0), nil
+	}
+	c.lock(row)
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if strings.Contains(query, "stock >= ?") && int64(row.stock) < argAt(query, "stock >= ?", args) {
+		return driver.RowsAffected(0), nil
+	}
+	delta := int(argAt(query, "stock + ?", args))
+	if strings.Contains(query, "stock - ?") {
+		delta = -int(argAt(query, "stock - ?", args))
+	}
+	row.stock += delta
+	if row.stock < c.db.lowest {
+		c.db.lowest = row.stock
+	}
+	c.undo = append(c.undo, func() { row.stock -= delta })
+	return driver.RowsAffected(1), nil
+}
+
+func (db *stockDB) record(query string) {
+	db.mu.Lock()
+	db.queries = append(db.queries, query)
+	db.mu.Unlock()
+}
+
+// argAt returns the integer argument bound to the placeholder that ends
+// fragment in query, 0 if query does not contain fragment.
+func argAt(query, fragment string, args []driver.NamedValue) int64 {
+	i := strings.Index(query, fragment)
+	if i < 0 {
+		return 0
+	}
+	n := strings.Count(query[:i+len(fragment)], "?") - 1
+	switch v := args[n].Value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	}
+	panic(fmt.Sprintf("stockdb: argument %d of %q is %T", n, query, args[n].Value))
+}
+
+// stockResult is the result of an insert, the ID of the inserted row.
+type stockResult int64
+
+func (r stockResult) LastInsertId() (int64, error) {
+	return int64(r), nil
+}
+
+func (r stockResult) RowsAffected() (int64, error) {
+	return 1, nil
+}
+
+type stockRows struct {
+	values [][]driver.Value
+}
+
+func (r *stockRows) Columns() []string {
+	return []string{"id", "product_uuid", "store_id", "product_name", "price", "currency", "stock"}
+}
+
+func (r *stockRows) Close() error {
+	return nil
+}
+
+func (r *stockRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// testProductRepository is the product repository with the reads that only
+// feed the inventory ledger and stock alerts answered from stockDB directly.
+type testProductRepository struct {
+	repo.ProductRepository
+	stock *stockDB
+}
+
+func (r testProductRepository) FindProductWithStore(db *gorm.DB, productID uint) (*entity.Product, error) {
+	r.stock.mu.Lock()
+	defer r.stock.mu.Unlock()
+	row := r.stock.rows[productID]
+	return &entity.Product{Model: gorm.Model{ID: row.id}, StoreID: row.storeID, Stock: row.stock}, nil
+}
+
+type fakeOrderRepository struct {
+	repo.OrderRepository
+	mu     sync.Mutex
+	orders []entity.Order
+}
+
+func (r *fakeOrderRepository) FindStoreByProductUUIDs(productUUIDs []string) (uint, error) {
+	return 1, nil
+}
+
+func (r *fakeOrderRepository) CreateOrder(db *gorm.DB, order *entity.Order) error {
+	r.mu.Lock()
+	r.orders = append(r.orders, *order)
+	r.mu.Unlock()
+	return nil
+}
+
+type fakeStoreRepository struct {
+	repo.StoreRepository
+}
+
+func (fakeStoreRepository) FindStoreByID(db *gorm.DB, storeID uint) (*entity.Store, error) {
+	return &entity.Store{Model: gorm.Model{ID: storeID}, Currency: "IDR", TaxMode: "exclusive", OriginProvince: "Jawa Barat"}, nil
+}
+
+type fakeInventoryRepository struct {
+	repo.InventoryRepository
+}
+
+func (fakeInventoryRepository) CreateMovement(db *gorm.DB, movement *entity.InventoryMovement) error {
+	return nil
+}
+
+type fakeOrderStatusRepository struct {
+	repo.OrderStatusRepository
+}
+
+func (fakeOrderStatusRepository) Transition(db *gorm.DB, change *entity.OrderStatusHistory) error {
+	return nil
+}
+
+type fakeWarehouseRepository struct {
+	repo.WarehouseRepository
+	mu         sync.Mutex
+	warehouses []entity.Warehouse
+	stocks     []entity.WarehouseStock
+}
+
+func (r *fakeWarehouseRepository) FindWarehousesByStore(db *gorm.DB, storeID uint, activeOnly bool) ([]entity.Warehouse, error) {
+	var warehouses []entity.Warehouse
+	for _, warehouse := range r.warehouses {
+		if warehouse.StoreID == storeID && (warehouse.IsActive || !activeOnly) {
+			warehouses = append(warehouses, warehouse)
+		}
+	}
+	return warehouses, nil
+}
+
+func (r *fakeWarehouseRepository) FindStocksForUpdate(db *gorm.DB, warehouseIDs []uint, productIDs []uint) ([]entity.WarehouseStock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stocks []entity.WarehouseStock
+	for _, stock := range r.stocks {
+		if containsID(warehouseIDs, stock.WarehouseID) && containsID(productIDs, stock.ProductID) {
+			stocks = append(stocks, stock)
+		}
+	}
+	return stocks, nil
+}
+
+func (r *fakeWarehouseRepository) ChangeStock(db *gorm.DB, warehouseID uint, productID uint, quantity int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.stocks {
+		stock := &r.stocks[i]
+		if stock.WarehouseID == warehouseID && stock.ProductID == productID {
+			if stock.Stock+quantity < 0 {
+				return model.ErrInsufficientStock
+			}
+			stock.Stock += quantity
+			return nil
+		}
+	}
+	if quantity < 0 {
+		return model.ErrInsufficientStock
+	}
+	r.stocks = append(r.stocks, entity.WarehouseStock{WarehouseID: warehouseID, ProductID: productID, Stock: quantity})
+	return nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeExchangeRateUseCase struct {
+	interfaces.ExchangeRateUseCase
+}
+
+func (fakeExchangeRateUseCase) GetRate(ctx context.Context, db *gorm.DB, from, to string) (money.Rate, error) {
+	return money.RateOne, nil
+}
+
+type fakePromotionUseCase struct {
+	interfaces.PromotionUseCase
+}
+
+func (fakePromotionUseCase) ApplyPromotions(ctx context.Context, tx *gorm.DB, order *entity.Order, products map[uint]entity.Product) error {
+	return nil
+}
+
+type fakeTaxRateUseCase struct {
+	interfaces.TaxRateUseCase
+}
+
+func (fakeTaxRateUseCase) ApplyTaxes(ctx context.Context, tx *gorm.DB, store *entity.Store, order *entity.Order, products map[uint]entity.Product) error {
+	return nil
+}
+
+type fakeShippingRateUseCase struct {
+	interfaces.ShippingRateUseCase
+}
+
+func (fakeShippingRateUseCase) Quote(ctx context.Context, db *gorm.DB, parcel *shipping.Parcel, currency string) ([]shipping.Quote, error) {
+	return []shipping.Quote{{Provider: "table", Service: "regular", Cost: money.Amount(1000000), Currency: currency}}, nil
+}
+
+type fakeAddressUseCase struct {
+	interfaces.AddressUseCase
+}
+
+func (fakeAddressUseCase) ResolveShippingAddress(ctx context.Context, db *gorm.DB, userID uint, addressUUID string, address *model.ShippingAddressRequest) error {
+	return nil
+}
+
+type fakeOrderEventUseCase struct {
+	ordereventUC.OrderEventUseCase
+}
+
+func (fakeOrderEventUseCase) ProcessOrderEvent(ctx context.Context, event *evententity.OrderEvent) error {
+	return nil
+}
@@ -2,14 +2,18 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
 	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
@@ -18,15 +22,21 @@ type ProductUseCase struct {
 	val         *validator.Validate
 	productRepo repo.ProductRepository
 	storeRepo   repo.StoreRepository
+	warehouseRepo repo.WarehouseRepository
+	inventory   interfaces.InventoryUseCase
+	exchangeRate interfaces.ExchangeRateUseCase
 	uuid        *helper.UUIDHelper
 }
 
-func NewProductUseCase(db *gorm.DB, validate *validator.Validate, productRepo repo.ProductRepository, storeRepo repo.StoreRepository, uuid *helper.UUIDHelper) interfaces.ProductUseCase {
+func NewProductUseCase(db *gorm.DB, validate *validator.Validate, productRepo repo.ProductRepository, storeRepo repo.StoreRepository, warehouseRepo repo.WarehouseRepository, inventory interfaces.InventoryUseCase, exchangeRate interfaces.ExchangeRateUseCase, uuid *helper.UUIDHelper) interfaces.ProductUseCase {
 	return &ProductUseCase{
 		db:          db,
 		val:         validate,
 		productRepo: productRepo,
 		storeRepo: storeRepo,
+		warehouseRepo: warehouseRepo,
+		inventory:   inventory,
+		exchangeRate: exchangeRate,
 		uuid:        uuid,
 	}
 }
@@ -37,26 +47,66 @@ func NewProductUseCase(db *gorm.DB, validate *validator.Validate, productRepo re
 // If the user is not a seller, it returns a 403 error.
 // If the request body is invalid, it returns a 400 error.
 // If the product cannot be created, it returns a 500 error.
+// The initial stock is booked as an "import" movement in the inventory ledger.
+// The price is taken to be in the store's base currency unless the request names another one.
+// Products without a tax category are taxed at the "standard" rate.
 func (u *ProductUseCase) CreateProduct(ctx context.Context, request *model.RegisterProduct) (*model.ProductResponse, error) {
+	request.Currency = strings.ToUpper(request.Currency)
 	if err := helper.ValidateStruct(u.val, request); err != nil {
 		return nil, err
 	}
-	storeID, err := u.storeRepo.GetStoreIDByUserID(request.AuthID)
+	store, err := u.storeRepo.FindStoreByUserID(request.AuthID)
 	if err != nil {
 		return nil, err
 	}
+	currency := request.Currency
+	if currency == "" {
+		currency = store.Currency
+	}
+	taxCategory := normalizeTaxCategory(request.TaxCategory)
+	if taxCategory == "" {
+		taxCategory = "standard"
+	}
 	product := &entity.Product{
 		ProductUUID: u.uuid.Generate(),
-		StoreID: storeID,
+		StoreID: store.ID,
 		ProductName: request.ProductName,
+		SKU: request.SKU,
+		ImageURL: request.ImageURL,
+		Attributes: request.Attributes,
 		Description: request.Description,
 		Price: request.Price,
-		Stock: request.Stock,
+		Currency: currency,
 		Category: request.Category,
+		TaxCategory: taxCategory,
+		LowStockThreshold: request.LowStockThreshold,
+		Weight: request.Weight,
+		Length: request.Length,
+		Width: request.Width,
+		Height: request.Height,
 	}
-	if err := u.productRepo.CreateProduct(product); err != nil {
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.productRepo.CreateProduct(tx, product); err != nil {
 		return nil, err
 	}
+	if err := u.inventory.ApplyMovement(ctx, tx, &entity.InventoryMovement{
+		ProductID:     product.ID,
+		Quantity:      request.Stock,
+		Reason:        "import",
+		ReferenceType: "product",
+		ReferenceID:   product.ProductUUID,
+		UserID:        &request.AuthID,
+		Note:          "Initial stock",
+	}); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	product.Stock = request.Stock
 
 	return converter.ProductToResponse(product), nil
 }
@@ -72,21 +122,46 @@ func (u *ProductUseCase) CreateProduct(ctx context.Context, request *model.Regis
 //     category, price range, pagination, etc.
 //
 // Returns:
-//   - A slice of ProductResponse containing the product details.
+//   - A slice of ProductResponse containing the product details. When a display
+//     currency is requested, each product also carries its price converted at
+//     the current exchange rate.
 //   - An int64 representing the total number of products that match the query.
-//   - An error, if any occurs during validation or data retrieval.
+//   - An error, if any occurs during validation or data retrieval, a 400 error
+//     if a price range comes without its currency, or a 422 error if no
+//     exchange rate is known for a requested conversion.
 
 func (u *ProductUseCase) GetProducts(ctx context.Context, request *model.GetProductsRequest) ([]model.ProductResponse, int64, error) {
+	request.Currency = strings.ToUpper(request.Currency)
+	request.PriceCurrency = strings.ToUpper(request.PriceCurrency)
 	if err := helper.ValidateStruct(u.val, request); err != nil {
 		return nil, 0, err
 	}
+	// prices in different currencies cannot be compared with one range
+	if (request.PriceMin > 0 || request.PriceMax > 0) && request.PriceCurrency == "" {
+		return nil, 0, model.NewApiError(fiber.StatusBadRequest, "price_currency is required with price_min or price_max", nil)
+	}
 	products, total, err := u.productRepo.GetProducts(request)
 	if err != nil {
 		return nil, 0, err
 	}
+	rates := make(map[string]money.Rate)
 	responses := make([]model.ProductResponse, len(products))
 	for i, product := range products {
 		responses[i] = *converter.ProductsToResponse(&product)
+		if request.Currency == "" {
+			continue
+		}
+		rate, ok := rates[product.Currency]
+		if !ok {
+			rate, err = u.exchangeRate.GetRate(ctx, u.db, product.Currency, request.Currency)
+			if err != nil {
+				return nil, 0, err
+			}
+			rates[product.Currency] = rate
+		}
+		displayPrice := product.Price.Convert(rate)
+		responses[i].DisplayPrice = &displayPrice
+		responses[i].DisplayCurrency = request.Currency
 	}
 	return responses, total, nil
 }
@@ -120,7 +195,12 @@ func (u *ProductUseCase) GetProductById(ctx context.Context, request *model.GetP
 // It first checks if the product exists and if the user is the owner of the product.
 // If the product does not exist or the user is not the owner, it returns an error.
 // If the product exists and the user is the owner, it updates the product and returns the updated product.
+// A new stock value is not written directly; the difference is booked as an
+// "adjust" movement in the inventory ledger. The stock cannot be set below
+// the units held in the store's warehouses, which are only changed through
+// their own stock adjustments; a lower value returns a 409 error.
 func (u *ProductUseCase) UpdateProduct(ctx context.Context, request *model.UpdateProduct) (*model.ProductResponse, error) {
+	request.Currency = strings.ToUpper(request.Currency)
 	if err := helper.ValidateStruct(u.val, request); err != nil {
 		return nil, err
 	}
@@ -134,21 +214,111 @@ func (u *ProductUseCase) UpdateProduct(ctx context.Context, request *model.Updat
 	if request.Description != "" {
 		product.Description = request.Description
 	}
+	if request.SKU != nil {
+		product.SKU = *request.SKU
+	}
+	if request.ImageURL != nil {
+		product.ImageURL = *request.ImageURL
+	}
+	if request.Attributes != nil {
+		product.Attributes = request.Attributes
+	}
 	if request.Price != 0 {
 		product.Price = request.Price
 	}
-	if request.Stock != 0 {
-		product.Stock = request.Stock
+	if request.Currency != "" {
+		product.Currency = request.Currency
 	}
 	if request.Category != "" {
 		product.Category = request.Category
 	}
-	if err := u.productRepo.UpdateProduct(product); err != nil {
+	if taxCategory := normalizeTaxCategory(request.TaxCategory); taxCategory != "" {
+		product.TaxCategory = taxCategory
+	}
+	if request.LowStockThreshold != nil {
+		product.LowStockThreshold = *request.LowStockThreshold
+	}
+	if request.Weight != nil {
+		product.Weight = *request.Weight
+	}
+	if request.Length != nil {
+		product.Length = *request.Length
+	}
+	if request.Width != nil {
+		product.Width = *request.Width
+	}
+	if request.Height != nil {
+		product.Height = *request.Height
+	}
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if request.Stock != 0 {
+		// lock the row so the difference is computed against the live stock
+		locked, err := u.productRepo.FindProductsByUUIDsForUpdate(tx, []string{product.ProductUUID})
+		if err != nil || len(locked) == 0 {
+			return nil, model.ErrInternalServer
+		}
+		product.Stock = locked[0].Stock
+		allocated, err := u.allocatedStock(tx, product)
+		if err != nil {
+			return nil, model.ErrInternalServer
+		}
+		if request.Stock < allocated {
+			return nil, model.NewApiError(fiber.StatusConflict,
+				fmt.Sprintf("Stock cannot be set below the %d units held in warehouses, adjust the warehouse stock instead", allocated), nil)
+		}
+		movement := &entity.InventoryMovement{
+			ProductID:     product.ID,
+			Quantity:      request.Stock - product.Stock,
+			Reason:        "adjust",
+			ReferenceType: "product",
+			ReferenceID:   product.ProductUUID,
+			UserID:        &request.UserID,
+			Note:          "Stock set by product update",
+		}
+		if err := u.inventory.ApplyMovement(ctx, tx, movement); err != nil {
+			if err == model.ErrInsufficientStock {
+				return nil, err
+			}
+			return nil, model.ErrInternalServer
+		}
+		if movement.Quantity != 0 {
+			product.Stock = movement.StockAfter
+		}
+	}
+	if err := u.productRepo.UpdateProduct(tx, product); err != nil {
 		return nil, err
 	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
 	return converter.ProductToResponse(product), nil
 }
 
+// allocatedStock returns the units of the product held in the warehouses of
+// its store, active or not, and locks their stock rows.
+func (u *ProductUseCase) allocatedStock(tx *gorm.DB, product *entity.Product) (int, error) {
+	warehouses, err := u.warehouseRepo.FindWarehousesByStore(tx, product.StoreID, false)
+	if err != nil || len(warehouses) == 0 {
+		return 0, err
+	}
+	warehouseIDs := make([]uint, len(warehouses))
+	for i, warehouse := range warehouses {
+		warehouseIDs[i] = warehouse.ID
+	}
+	stocks, err := u.warehouseRepo.FindStocksForUpdate(tx, warehouseIDs, []uint{product.ID})
+	if err != nil {
+		return 0, err
+	}
+	allocated := 0
+	for _, stock := range stocks {
+		allocated += stock.Stock
+	}
+	return allocated, nil
+}
+
 // DeleteProduct deletes a product by its UUID and the user ID of the owner of the product.
 // It first checks if the product exists and if the user is the owner of the product.
 // If the product does not exist or the user is not the owner, it returns an error.
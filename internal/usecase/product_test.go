@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestUpdateProductStock sets the stock of a product of 10 units, 6 of them
+// held in warehouses.
+func TestUpdateProductStock(t *testing.T) {
+	const productUUID = "7d1f6c0e-4d55-4b8a-9a43-0c2b6f3e9d11"
+	tests := []struct {
+		name         string
+		noWarehouses bool
+		stock        int
+		wantStatus   int
+		wantQuantity int
+	}{
+		{name: "above the warehouse stock", stock: 8, wantQuantity: -2},
+		{name: "down to the warehouse stock", stock: 6, wantQuantity: -4},
+		{name: "raised", stock: 15, wantQuantity: 5},
+		{name: "below the warehouse stock", stock: 5, wantStatus: fiber.StatusConflict},
+		{name: "store without warehouses", noWarehouses: true, stock: 3, wantQuantity: -7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := &fakeProductUpdateRepository{product: entity.Product{
+				Model:       gorm.Model{ID: 1},
+				ProductUUID: productUUID,
+				StoreID:     1,
+				Stock:       10,
+			}}
+			warehouses := &fakeWarehouseRepository{}
+			if !tt.noWarehouses {
+				warehouses.warehouses = []entity.Warehouse{
+					{Model: gorm.Model{ID: 1}, StoreID: 1, IsActive: true},
+					{Model: gorm.Model{ID: 2}, StoreID: 1},
+				}
+				warehouses.stocks = []entity.WarehouseStock{
+					{WarehouseID: 1, ProductID: 1, Stock: 4},
+					{WarehouseID: 2, ProductID: 1, Stock: 2},
+					{WarehouseID: 1, ProductID: 2, Stock: 50},
+				}
+			}
+			inventory := &fakeItemInventoryUseCase{}
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sql.OpenDB(newStockDB()),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{Logger: logger.Discard})
+			if err != nil {
+				t.Fatal(err)
+			}
+			uc := NewProductUseCase(db, validator.New(), products, fakeStoreRepository{}, warehouses, inventory, fakeExchangeRateUseCase{}, nil)
+
+			_, err = uc.UpdateProduct(context.Background(), &model.UpdateProduct{UserID: 1, ProductUUID: productUUID, Stock: tt.stock})
+			if tt.wantStatus != 0 {
+				var apiErr *model.ApiError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+				}
+				if len(inventory.movements) != 0 || products.updated {
+					t.Errorf("refused stock was booked: movements %v, product updated %v", inventory.movements, products.updated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(inventory.movements) != 1 || inventory.movements[0].Quantity != tt.wantQuantity || inventory.movements[0].WarehouseID != nil {
+				t.Errorf("movements %v, want one adjustment of %d off the unallocated stock", inventory.movements, tt.wantQuantity)
+			}
+		})
+	}
+}
+
+type fakeProductUpdateRepository struct {
+	repo.ProductRepository
+	product entity.Product
+	updated bool
+}
+
+func (r *fakeProductUpdateRepository) GetProductById(userID uint, productUUID string) (*entity.Product, error) {
+	product := r.product
+	return &product, nil
+}
+
+func (r *fakeProductUpdateRepository) FindProductsByUUIDsForUpdate(db *gorm.DB, productUUIDs []string) ([]entity.Product, error) {
+	return []entity.Product{r.product}, nil
+}
+
+func (r *fakeProductUpdateRepository) UpdateProduct(db *gorm.DB, product *entity.Product) error {
+	r.updated = true
+	return nil
+}
@@ -0,0 +1,470 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type PromotionUseCase struct {
+	db            *gorm.DB
+	val           *validator.Validate
+	promotionRepo repo.PromotionRepository
+	storeRepo     repo.StoreRepository
+	productRepo   repo.ProductRepository
+	exchangeRate  interfaces.ExchangeRateUseCase
+	uuid          *helper.UUIDHelper
+}
+
+func NewPromotionUseCase(db *gorm.DB, validate *validator.Validate, promotionRepo repo.PromotionRepository, storeRepo repo.StoreRepository, productRepo repo.ProductRepository, exchangeRate interfaces.ExchangeRateUseCase, uuid *helper.UUIDHelper) interfaces.PromotionUseCase {
+	return &PromotionUseCase{
+		db:            db,
+		val:           validate,
+		promotionRepo: promotionRepo,
+		storeRepo:     storeRepo,
+		productRepo:   productRepo,
+		exchangeRate:  exchangeRate,
+		uuid:          uuid,
+	}
+}
+
+// CreatePromotion creates an automatic promotion of the seller's store.
+// Percentage promotions need percent, buy X get Y promotions buy_quantity and
+// get_quantity, tiered promotions at least one tier, and bundle promotions
+// percent and at least two products, without categories; fields of the other
+// types are ignored.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid or a product does not belong to the store.
+//   - 404 Not Found: if the seller has no store.
+func (uc *PromotionUseCase) CreatePromotion(ctx context.Context, request *model.CreatePromotionRequest) (*model.PromotionResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	promotion := &entity.Promotion{
+		PromotionUUID: uc.uuid.Generate(),
+		Name:          request.Name,
+		Type:          request.Type,
+		Categories:    strings.Join(request.Categories, ","),
+		StartsAt:      request.StartsAt,
+		EndsAt:        request.EndsAt,
+		IsActive:      true,
+	}
+	switch request.Type {
+	case "percentage":
+		if request.Percent == 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Percent is required for percentage promotions", nil)
+		}
+		promotion.Percent = request.Percent
+	case "buy_x_get_y":
+		if request.BuyQuantity == 0 || request.GetQuantity == 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Buy quantity and get quantity are required for buy X get Y promotions", nil)
+		}
+		promotion.BuyQuantity = request.BuyQuantity
+		promotion.GetQuantity = request.GetQuantity
+	case "tiered":
+		if len(request.Tiers) == 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Tiers are required for tiered promotions", nil)
+		}
+		seen := make(map[int]bool, len(request.Tiers))
+		for _, tier := range request.Tiers {
+			if seen[tier.MinQuantity] {
+				return nil, model.NewApiError(fiber.StatusBadRequest, "Tiers must have different minimum quantities", nil)
+			}
+			seen[tier.MinQuantity] = true
+			promotion.Tiers = append(promotion.Tiers, entity.PromotionTier{
+				MinQuantity: tier.MinQuantity,
+				Percent:     tier.Percent,
+			})
+		}
+		sort.Slice(promotion.Tiers, func(i, j int) bool { return promotion.Tiers[i].MinQuantity < promotion.Tiers[j].MinQuantity })
+	case "bundle":
+		if request.Percent == 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Percent is required for bundle promotions", nil)
+		}
+		if len(request.Categories) > 0 || len(request.ProductUUIDs) < 2 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Bundle promotions need at least two products and no categories", nil)
+		}
+		promotion.Percent = request.Percent
+	}
+	if err := validateVoucherPeriod(request.StartsAt, request.EndsAt); err != nil {
+		return nil, err
+	}
+
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	promotion.StoreID = store.ID
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if len(request.ProductUUIDs) > 0 {
+		products, err := uc.productRepo.FindProductsByUUIDsForUpdate(tx, request.ProductUUIDs)
+		if err != nil {
+			return nil, model.ErrInternalServer
+		}
+		found := make(map[string]bool, len(products))
+		for _, product := range products {
+			if product.StoreID != store.ID {
+				return nil, model.NewApiError(fiber.StatusBadRequest, "Promotions can only be scoped to products of your store", nil)
+			}
+			found[product.ProductUUID] = true
+		}
+		for _, productUUID := range request.ProductUUIDs {
+			if !found[productUUID] {
+				return nil, model.NewApiError(fiber.StatusBadRequest, "One or more products not found", nil)
+			}
+		}
+		promotion.Products = products
+	}
+	if promotion.Type == "bundle" && len(promotion.Products) < 2 {
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Bundle promotions need at least two products and no categories", nil)
+	}
+
+	if err := uc.promotionRepo.CreatePromotion(tx, promotion); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.PromotionToResponse(promotion), nil
+}
+
+// GetPromotions lists the promotions of the seller's store, newest first.
+func (uc *PromotionUseCase) GetPromotions(ctx context.Context, request *model.SearchPromotionRequest) ([]model.PromotionResponse, int64, error) {
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	promotions, total, err := uc.promotionRepo.GetPromotions(store.ID, request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.PromotionResponse, len(promotions))
+	for i := range promotions {
+		responses[i] = *converter.PromotionToResponse(&promotions[i])
+	}
+	return responses, total, nil
+}
+
+// GetPromotion returns a promotion of the seller's store.
+func (uc *PromotionUseCase) GetPromotion(ctx context.Context, request *model.GetPromotionRequest) (*model.PromotionResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	promotion, err := uc.promotionRepo.FindPromotionByUUID(store.ID, request.PromotionUUID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.PromotionToResponse(promotion), nil
+}
+
+// UpdatePromotion renames a promotion of the seller's store or changes when
+// it runs. The discount and scope of a promotion cannot change, as they are
+// recorded on the orders it was applied to; create a new promotion instead.
+func (uc *PromotionUseCase) UpdatePromotion(ctx context.Context, request *model.UpdatePromotionRequest) (*model.PromotionResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	promotion, err := uc.promotionRepo.FindPromotionByUUID(store.ID, request.PromotionUUID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Name != "" {
+		promotion.Name = request.Name
+	}
+	if request.StartsAt != nil {
+		promotion.StartsAt = request.StartsAt
+	}
+	if request.EndsAt != nil {
+		promotion.EndsAt = request.EndsAt
+	}
+	if request.IsActive != nil {
+		promotion.IsActive = *request.IsActive
+	}
+	if err := validateVoucherPeriod(promotion.StartsAt, promotion.EndsAt); err != nil {
+		return nil, err
+	}
+	if err := uc.promotionRepo.UpdatePromotion(promotion); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.PromotionToResponse(promotion), nil
+}
+
+// PreviewOrder prices a basket the way orders are placed, without placing
+// them: per store, in the store's currency, after the promotions running now.
+// Stock is not checked and vouchers are not applied.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid.
+//   - 404 Not Found: if a product does not exist.
+func (uc *PromotionUseCase) PreviewOrder(ctx context.Context, request *model.PreviewOrderRequest) (*model.PreviewOrderResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	db := uc.db.WithContext(ctx)
+
+	productUUIDs := make([]string, len(request.Items))
+	for i, item := range request.Items {
+		productUUIDs[i] = item.ProductUUID
+	}
+	products, err := uc.productRepo.FindProductsByUUIDs(db, productUUIDs)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	productByUUID := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		productByUUID[product.ProductUUID] = product
+	}
+
+	var storeIDs []uint
+	itemsByStore := make(map[uint][]model.OrderItemRequest)
+	for _, item := range request.Items {
+		product, ok := productByUUID[item.ProductUUID]
+		if !ok {
+			return nil, model.NewApiError(fiber.StatusNotFound, "One or more products not found", nil)
+		}
+		if _, ok := itemsByStore[product.StoreID]; !ok {
+			storeIDs = append(storeIDs, product.StoreID)
+		}
+		itemsByStore[product.StoreID] = append(itemsByStore[product.StoreID], item)
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+
+	promotions, err := uc.ActivePromotions(ctx, db, storeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.PreviewOrderResponse{Stores: make([]model.PreviewStoreResponse, 0, len(storeIDs))}
+	for _, storeID := range storeIDs {
+		items := itemsByStore[storeID]
+		store := productByUUID[items[0].ProductUUID].Store
+		storeResponse := model.PreviewStoreResponse{
+			StoreName: store.StoreName,
+			Currency:  store.Currency,
+			Items:     make([]model.PreviewItemResponse, 0, len(items)),
+		}
+		basket := newPromotionBasket()
+		for _, item := range items {
+			basket.add(productByUUID[item.ProductUUID].ID, item.Quantity)
+		}
+		rates := make(map[string]money.Rate)
+		for _, item := range items {
+			product := productByUUID[item.ProductUUID]
+			rate, ok := rates[product.Currency]
+			if !ok {
+				rate, err = uc.exchangeRate.GetRate(ctx, db, product.Currency, store.Currency)
+				if err != nil {
+					return nil, err
+				}
+				rates[product.Currency] = rate
+			}
+			unitPrice := product.Price.Convert(rate)
+			subtotal := unitPrice.Mul(item.Quantity)
+			promotion, discount := bestPromotion(promotions[storeID], &product, item.Quantity, subtotal, basket)
+			storeResponse.Items = append(storeResponse.Items, model.PreviewItemResponse{
+				ProductUUID: product.ProductUUID,
+				ProductName: product.ProductName,
+				Quantity:    item.Quantity,
+				UnitPrice:   unitPrice,
+				Subtotal:    subtotal,
+				Discount:    discount,
+				Total:       subtotal.Sub(discount),
+				Promotion:   converter.AppliedPromotionToResponse(promotion),
+			})
+			storeResponse.Subtotal = storeResponse.Subtotal.Add(subtotal)
+			storeResponse.Discount = storeResponse.Discount.Add(discount)
+		}
+		storeResponse.Total = storeResponse.Subtotal.Sub(storeResponse.Discount)
+		response.Stores = append(response.Stores, storeResponse)
+	}
+	return response, nil
+}
+
+// ActivePromotions returns the promotions running now, per store.
+func (uc *PromotionUseCase) ActivePromotions(ctx context.Context, db *gorm.DB, storeIDs []uint) (map[uint][]entity.Promotion, error) {
+	byStore := make(map[uint][]entity.Promotion)
+	if len(storeIDs) == 0 {
+		return byStore, nil
+	}
+	promotions, err := uc.promotionRepo.FindActivePromotions(db, storeIDs, time.Now())
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	for _, promotion := range promotions {
+		byStore[promotion.StoreID] = append(byStore[promotion.StoreID], promotion)
+	}
+	return byStore, nil
+}
+
+// ApplyPromotions takes the best running promotion of the store off every
+// item of an order that is about to be created, see bestPromotion. Item and
+// order totals are reduced by the discounts, which are added to the discount
+// of the order and recorded as discount lines of the items.
+func (uc *PromotionUseCase) ApplyPromotions(ctx context.Context, tx *gorm.DB, order *entity.Order, products map[uint]entity.Product) error {
+	if len(order.Items) == 0 {
+		return nil
+	}
+	storeID := products[order.Items[0].ProductID].StoreID
+	promotions, err := uc.ActivePromotions(ctx, tx, []uint{storeID})
+	if err != nil {
+		return err
+	}
+	if len(promotions[storeID]) == 0 {
+		return nil
+	}
+	basket := newPromotionBasket()
+	for _, item := range order.Items {
+		basket.add(item.ProductID, item.Quantity)
+	}
+	for i := range order.Items {
+		item := &order.Items[i]
+		product := products[item.ProductID]
+		promotion, discount := bestPromotion(promotions[storeID], &product, item.Quantity, item.TotalPrice, basket)
+		if promotion == nil {
+			continue
+		}
+		item.DiscountAmount = item.DiscountAmount.Add(discount)
+		item.TotalPrice = item.TotalPrice.Sub(discount)
+		item.Discounts = append(item.Discounts, entity.OrderItemDiscount{
+			PromotionID: &promotion.ID,
+			Label:       promotion.Name,
+			Amount:      discount,
+		})
+		order.DiscountAmount = order.DiscountAmount.Add(discount)
+		order.TotalPrice = order.TotalPrice.Sub(discount)
+	}
+	return nil
+}
+
+// promotionBasket holds what bundle promotions need to price the lines of a
+// store: the units of every product bought and, per bundle promotion and
+// product, the units already discounted on earlier lines. A product spread
+// over several lines, e.g. one per variant, is bundled at most as many times
+// as there are complete sets.
+type promotionBasket struct {
+	quantities map[uint]int
+	bundled    map[bundleUnit]int
+}
+
+type bundleUnit struct {
+	promotionID uint
+	productID   uint
+}
+
+func newPromotionBasket() *promotionBasket {
+	return &promotionBasket{quantities: make(map[uint]int), bundled: make(map[bundleUnit]int)}
+}
+
+// add counts quantity units of a product.
+func (b *promotionBasket) add(productID uint, quantity int) {
+	b.quantities[productID] += quantity
+}
+
+// bundleUnits returns how many of quantity units of a product a bundle
+// promotion can still discount.
+func (b *promotionBasket) bundleUnits(promotion *entity.Promotion, productID uint, quantity int) int {
+	units := bundleCount(promotion, b.quantities) - b.bundled[bundleUnit{promotion.ID, productID}]
+	if units > quantity {
+		units = quantity
+	}
+	if units < 0 {
+		return 0
+	}
+	return units
+}
+
+// bestPromotion returns the promotion that takes the most off an order line
+// and its discount. Lines must be priced in order with the same basket, which
+// remembers the units a chosen bundle promotion discounted. Promotions do not
+// stack: a line gets at most one, the oldest among equal discounts. It returns
+// nil when no promotion takes anything off the line.
+func bestPromotion(promotions []entity.Promotion, product *entity.Product, quantity int, lineTotal money.Amount, basket *promotionBasket) (*entity.Promotion, money.Amount) {
+	var best *entity.Promotion
+	var bestDiscount money.Amount
+	for i := range promotions {
+		promotion := &promotions[i]
+		if !scopeCovers(promotion.Products, promotion.Categories, product) {
+			continue
+		}
+		if discount := promotionDiscount(promotion, product.ID, quantity, lineTotal, basket); discount > bestDiscount {
+			best, bestDiscount = promotion, discount
+		}
+	}
+	if best != nil && best.Type == "bundle" {
+		basket.bundled[bundleUnit{best.ID, product.ID}] += basket.bundleUnits(best, product.ID, quantity)
+	}
+	return best, bestDiscount
+}
+
+// promotionDiscount returns the discount of a promotion on an order line of
+// quantity units of a product costing lineTotal.
+func promotionDiscount(promotion *entity.Promotion, productID uint, quantity int, lineTotal money.Amount, basket *promotionBasket) money.Amount {
+	switch promotion.Type {
+	case "percentage":
+		return lineTotal.MulRatio(int64(promotion.Percent), 100)
+	case "buy_x_get_y":
+		group := promotion.BuyQuantity + promotion.GetQuantity
+		if group <= 0 || quantity <= 0 {
+			return 0
+		}
+		free := quantity / group * promotion.GetQuantity
+		return lineTotal.MulRatio(int64(free), int64(quantity))
+	case "tiered":
+		percent := 0
+		for _, tier := range promotion.Tiers {
+			if quantity >= tier.MinQuantity {
+				percent = tier.Percent
+			}
+		}
+		return lineTotal.MulRatio(int64(percent), 100)
+	case "bundle":
+		// only the units that complete a set are discounted
+		bundled := basket.bundleUnits(promotion, productID, quantity)
+		if bundled == 0 {
+			return 0
+		}
+		return lineTotal.MulRatio(int64(bundled*promotion.Percent), int64(quantity*100))
+	}
+	return 0
+}
+
+// bundleCount returns the number of complete sets of a bundle promotion's
+// products among quantities.
+func bundleCount(promotion *entity.Promotion, quantities map[uint]int) int {
+	if len(promotion.Products) == 0 {
+		return 0
+	}
+	count := -1
+	for _, product := range promotion.Products {
+		if quantity := quantities[product.ID]; count < 0 || quantity < count {
+			count = quantity
+		}
+	}
+	return count
+}
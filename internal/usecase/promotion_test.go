@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"gorm.io/gorm"
+)
+
+func TestPromotionDiscount(t *testing.T) {
+	tiered := entity.Promotion{Type: "tiered", Tiers: []entity.PromotionTier{
+		{MinQuantity: 2, Percent: 5},
+		{MinQuantity: 5, Percent: 10},
+		{MinQuantity: 10, Percent: 20},
+	}}
+	buyTwoGetOne := entity.Promotion{Type: "buy_x_get_y", BuyQuantity: 2, GetQuantity: 1}
+	tests := []struct {
+		name      string
+		promotion entity.Promotion
+		quantity  int
+		want      money.Amount
+	}{
+		{"percentage", entity.Promotion{Type: "percentage", Percent: 15}, 1, 1500},
+		{"buy 2 get 1, one free unit", buyTwoGetOne, 3, 3333},
+		{"buy 2 get 1, two free units", buyTwoGetOne, 7, 2857},
+		{"buy 2 get 1, no free unit", buyTwoGetOne, 2, 0},
+		{"buy x get y without quantities", entity.Promotion{Type: "buy_x_get_y"}, 3, 0},
+		{"below the first tier", tiered, 1, 0},
+		{"first tier", tiered, 2, 500},
+		{"between tiers", tiered, 9, 1000},
+		{"highest tier", tiered, 10, 2000},
+		{"unknown type", entity.Promotion{Type: "cashback", Percent: 15}, 1, 0},
+	}
+	for _, tt := range tests {
+		got := promotionDiscount(&tt.promotion, 1, tt.quantity, 10000, newPromotionBasket())
+		if got != tt.want {
+			t.Errorf("%s: discount of %d units = %s, want %s", tt.name, tt.quantity, got, tt.want)
+		}
+	}
+}
+
+// TestBestPromotion prices the lines of an order one after another with the
+// same basket, the way ApplyPromotions does.
+func TestBestPromotion(t *testing.T) {
+	shirt := entity.Product{Model: gorm.Model{ID: 1}, Category: "clothes"}
+	hat := entity.Product{Model: gorm.Model{ID: 2}, Category: "accessories"}
+	phone := entity.Product{Model: gorm.Model{ID: 3}, Category: "electronics"}
+
+	type line struct {
+		product  entity.Product
+		quantity int
+		total    money.Amount
+	}
+	tests := []struct {
+		name       string
+		promotions []entity.Promotion
+		lines      []line
+		// wantIDs and wantDiscounts are per line, promotion 0 for none
+		wantIDs       []uint
+		wantDiscounts []money.Amount
+	}{
+		{
+			name: "largest discount wins",
+			promotions: []entity.Promotion{
+				{Model: gorm.Model{ID: 1}, Type: "percentage", Percent: 10},
+				{Model: gorm.Model{ID: 2}, Type: "tiered", Tiers: []entity.PromotionTier{{MinQuantity: 3, Percent: 20}}},
+			},
+			lines:         []line{{shirt, 3, 9000}, {hat, 1, 2000}},
+			wantIDs:       []uint{2, 1},
+			wantDiscounts: []money.Amount{1800, 200},
+		},
+		{
+			name: "oldest among equal discounts",
+			promotions: []entity.Promotion{
+				{Model: gorm.Model{ID: 1}, Type: "percentage", Percent: 10},
+				{Model: gorm.Model{ID: 2}, Type: "percentage", Percent: 10},
+			},
+			lines:         []line{{shirt, 1, 5000}},
+			wantIDs:       []uint{1},
+			wantDiscounts: []money.Amount{500},
+		},
+		{
+			name: "scoped to a category",
+			promotions: []entity.Promotion{
+				{Model: gorm.Model{ID: 1}, Type: "percentage", Percent: 10, Categories: "electronics"},
+			},
+			lines:         []line{{shirt, 1, 5000}, {phone, 1, 30000}},
+			wantIDs:       []uint{0, 1},
+			wantDiscounts: []money.Amount{0, 3000},
+		},
+		{
+			name: "bundle of complete sets only",
+			promotions: []entity.Promotion{
+				{Model: gorm.Model{ID: 1}, Type: "bundle", Percent: 50, Products: []entity.Product{shirt, hat}},
+			},
+			lines:         []line{{shirt, 3, 6000}, {hat, 2, 2000}},
+			wantIDs:       []uint{1, 1},
+			wantDiscounts: []money.Amount{2000, 1000},
+		},
+		{
+			name: "bundle over several lines of a product",
+			promotions: []entity.Promotion{
+				{Model: gorm.Model{ID: 1}, Type: "bundle", Percent: 50, Products: []entity.Product{shirt, hat}},
+			},
+			lines:         []line{{shirt, 1, 2000}, {shirt, 1, 2000}, {hat, 1, 1000}},
+			wantIDs:       []uint{1, 0, 1},
+			wantDiscounts: []money.Amount{1000, 0, 500},
+		},
+		{
+			name: "bundle without a complete set",
+			promotions: []entity.Promotion{
+				{Model: gorm.Model{ID: 1}, Type: "bundle", Percent: 50, Products: []entity.Product{shirt, phone}},
+			},
+			lines:         []line{{shirt, 2, 4000}, {hat, 1, 1000}},
+			wantIDs:       []uint{0, 0},
+			wantDiscounts: []money.Amount{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			basket := newPromotionBasket()
+			for _, l := range tt.lines {
+				basket.add(l.product.ID, l.quantity)
+			}
+			for i, l := range tt.lines {
+				promotion, discount := bestPromotion(tt.promotions, &l.product, l.quantity, l.total, basket)
+				var id uint
+				if promotion != nil {
+					id = promotion.ID
+				}
+				if id != tt.wantIDs[i] || discount != tt.wantDiscounts[i] {
+					t.Errorf("line %d: promotion %d, discount %s, want %d, %s", i, id, discount, tt.wantIDs[i], tt.wantDiscounts[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	eventmodel "github.com/abdisetiakawan/go-ecommerce/internal/model/event_model"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type RefundUseCase struct {
+	refundRepo repo.RefundRepository
+	uuid       *helper.UUIDHelper
+}
+
+func NewRefundUseCase(refundRepo repo.RefundRepository, uuid *helper.UUIDHelper) interfaces.RefundUseCase {
+	return &RefundUseCase{
+		refundRepo: refundRepo,
+		uuid:       uuid,
+	}
+}
+
+// IssueRefund records a refund on the payment of the order inside the
+// caller's transaction, together with a "refund_issued" order event. The
+// caller must hold the order lock (OrderRepository.LockOrder) so concurrent
+// refunds cannot exceed the payment, commit the transaction and then hand the
+// event to OrderEventUseCase.RefundPaymentEvent. The payment consumer books
+// the refund on the payment, which becomes "partially_refunded" or
+// "refunded".
+//
+// Errors:
+//
+//   - 400 Bad Request: if the amount is not positive.
+//   - 409 Conflict: if the order has not been paid or the amount exceeds what is left to refund.
+func (uc *RefundUseCase) IssueRefund(ctx context.Context, tx *gorm.DB, order *entity.Order, request *model.IssueRefund) (*entity.Refund, *evententity.OrderEvent, error) {
+	payment := order.Payment
+	if payment == nil || (payment.Status != "paid" && payment.Status != "partially_refunded") {
+		return nil, nil, model.NewApiError(fiber.StatusConflict, "Order has not been paid", nil)
+	}
+	if request.Amount <= 0 {
+		return nil, nil, model.NewApiError(fiber.StatusBadRequest, "Refund amount must be greater than zero", nil)
+	}
+
+	refunded, err := uc.refundRepo.SumRefundsByOrderID(tx, order.ID)
+	if err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	if remaining := payment.Amount.Sub(refunded); request.Amount > remaining {
+		return nil, nil, model.NewApiError(fiber.StatusConflict,
+			fmt.Sprintf("Refund amount exceeds the refundable amount of %s %s", remaining, payment.Currency), nil)
+	}
+
+	refund := &entity.Refund{
+		RefundUUID:    uc.uuid.Generate(),
+		OrderID:       order.ID,
+		Amount:        request.Amount,
+		Currency:      payment.Currency,
+		Reason:        request.Reason,
+		ReferenceType: request.ReferenceType,
+		ReferenceID:   request.ReferenceID,
+		Status:        "pending",
+	}
+	if err := uc.refundRepo.CreateRefund(tx, refund); err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+
+	paymentData, err := json.Marshal(eventmodel.PaymentMessage{
+		PaymentUUID: payment.PaymentUUID,
+		OrderID:     order.ID,
+		Amount:      refund.Amount,
+		Currency:    refund.Currency,
+		RefundUUID:  refund.RefundUUID,
+	})
+	if err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	orderEvent := &evententity.OrderEvent{
+		EventUUID:   uc.uuid.Generate(),
+		OrderID:     order.ID,
+		EventType:   "refund_issued",
+		Status:      "pending",
+		PaymentData: paymentData,
+	}
+	if err := tx.Create(orderEvent).Error; err != nil {
+		return nil, nil, model.ErrInternalServer
+	}
+	return refund, orderEvent, nil
+}
@@ -0,0 +1,480 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
+	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// DefaultReturnWindow is how long after delivery a buyer can request a
+// return when no window is configured.
+const DefaultReturnWindow = 7 * 24 * time.Hour
+
+type ReturnUseCase struct {
+	db           *gorm.DB
+	val          *validator.Validate
+	returnRepo   repo.ReturnRepository
+	orderRepo    repo.OrderRepository
+	storeRepo    repo.StoreRepository
+	statusRepo   repo.OrderStatusRepository
+	inventory    interfaces.InventoryUseCase
+	refund       interfaces.RefundUseCase
+	notification interfaces.NotificationUseCase
+	orderEvent   ordereventUC.OrderEventUseCase
+	uuid         *helper.UUIDHelper
+	window       time.Duration
+}
+
+func NewReturnUseCase(db *gorm.DB, validate *validator.Validate, returnRepo repo.ReturnRepository, orderRepo repo.OrderRepository, storeRepo repo.StoreRepository, statusRepo repo.OrderStatusRepository, inventory interfaces.InventoryUseCase, refund interfaces.RefundUseCase, notification interfaces.NotificationUseCase, orderEvent ordereventUC.OrderEventUseCase, uuid *helper.UUIDHelper, window time.Duration) interfaces.ReturnUseCase {
+	if window <= 0 {
+		window = DefaultReturnWindow
+	}
+	return &ReturnUseCase{
+		db:           db,
+		val:          validate,
+		returnRepo:   returnRepo,
+		orderRepo:    orderRepo,
+		storeRepo:    storeRepo,
+		statusRepo:   statusRepo,
+		inventory:    inventory,
+		refund:       refund,
+		notification: notification,
+		orderEvent:   orderEvent,
+		uuid:         uuid,
+		window:       window,
+	}
+}
+
+// RequestReturn opens a return for items of a delivered, disputed or
+// completed order. The return window starts when the order was delivered.
+// Each order item can be returned up to its ordered quantity across all
+// returns that were not rejected. The seller is notified.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid or lists an order item twice.
+//   - 404 Not Found: if the order or one of the order items is not found.
+//   - 409 Conflict: if the order has not been delivered or paid, the return window has closed, an item was cancelled, or more than the remaining quantity of an item is returned.
+func (uc *ReturnUseCase) RequestReturn(ctx context.Context, request *model.CreateReturnRequest) (*model.ReturnResponse, error) {
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	request.Reason = strings.TrimSpace(request.Reason)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	order, err := uc.orderRepo.GetOrderByIdByBuyer(&model.GetOrderDetails{
+		OrderUUID: request.OrderUUID,
+		UserID:    request.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch order.Status {
+	case "delivered", "disputed", "completed":
+	default:
+		return nil, model.NewApiError(fiber.StatusConflict,
+			fmt.Sprintf("Cannot return items of a %s order", order.Status), nil)
+	}
+	if order.Payment == nil || (order.Payment.Status != "paid" && order.Payment.Status != "partially_refunded") {
+		return nil, model.NewApiError(fiber.StatusConflict, "Order has no refundable payment", nil)
+	}
+
+	deliveredAt, err := uc.deliveredAt(tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(deliveredAt.Add(uc.window)) {
+		return nil, model.NewApiError(fiber.StatusConflict,
+			fmt.Sprintf("The return window of %s after delivery has closed", formatPolicyDuration(uc.window)), nil)
+	}
+
+	if err := uc.orderRepo.LockOrder(tx, order.ID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	returned, err := uc.returnRepo.ReturnedItems(tx, order.ID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	itemsByUUID := make(map[string]entity.OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		itemsByUUID[item.OrderItemUUID] = item
+	}
+
+	returnRequest := &entity.ReturnRequest{
+		ReturnUUID: uc.uuid.Generate(),
+		OrderID:    order.ID,
+		UserID:     request.UserID,
+		StoreID:    order.StoreID,
+		Status:     statemachine.Initial(statemachine.Return),
+		Reason:     request.Reason,
+		Currency:   order.Currency,
+	}
+	orderItems := make([]entity.OrderItem, 0, len(request.Items))
+	seen := make(map[uint]bool, len(request.Items))
+	for _, requested := range request.Items {
+		item, ok := itemsByUUID[requested.OrderItemUUID]
+		if !ok {
+			return nil, model.NewApiError(fiber.StatusNotFound,
+				fmt.Sprintf("Order item %s not found", requested.OrderItemUUID), nil)
+		}
+		if item.Status == "cancelled" {
+			return nil, model.NewApiError(fiber.StatusConflict,
+				fmt.Sprintf("Order item %s was cancelled", requested.OrderItemUUID), nil)
+		}
+		if seen[item.ID] {
+			return nil, model.NewApiError(fiber.StatusBadRequest,
+				fmt.Sprintf("Order item %s is listed more than once", requested.OrderItemUUID), nil)
+		}
+		if left := item.Quantity - returned[item.ID].Quantity; requested.Quantity > left {
+			return nil, model.NewApiError(fiber.StatusConflict,
+				fmt.Sprintf("Only %d of order item %s can still be returned", left, requested.OrderItemUUID), nil)
+		}
+		seen[item.ID] = true
+		orderItems = append(orderItems, item)
+		returnRequest.Items = append(returnRequest.Items, entity.ReturnItem{
+			OrderItemID: item.ID,
+			Quantity:    requested.Quantity,
+			Amount:      returnAmount(item, returned[item.ID], requested.Quantity),
+		})
+	}
+	for _, url := range request.Photos {
+		returnRequest.Photos = append(returnRequest.Photos, entity.ReturnPhoto{URL: url})
+	}
+
+	if err := uc.returnRepo.CreateReturn(tx, returnRequest); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	returnRequest.Order = *order
+	for i := range returnRequest.Items {
+		returnRequest.Items[i].OrderItem = orderItems[i]
+	}
+
+	if err := uc.notifyReturn(ctx, tx, returnRequest, "seller", entity.Notification{
+		Type:    "return_requested",
+		Title:   "Return requested",
+		Message: fmt.Sprintf("The buyer requested a return for order %s: %s", order.OrderUUID, request.Reason),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.ReturnToResponse(returnRequest), nil
+}
+
+// GetReturnsByBuyer lists the returns of the buyer, newest first.
+func (uc *ReturnUseCase) GetReturnsByBuyer(ctx context.Context, request *model.SearchReturnRequest) ([]model.ReturnResponse, int64, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, 0, err
+	}
+	request.StoreID = 0
+	return uc.getReturns(request)
+}
+
+// GetReturnByBuyer returns one return of the buyer.
+func (uc *ReturnUseCase) GetReturnByBuyer(ctx context.Context, request *model.GetReturnRequest) (*model.ReturnResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	returnRequest, err := uc.returnRepo.FindReturnByBuyer(request.UserID, request.ReturnUUID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.ReturnToResponse(returnRequest), nil
+}
+
+// GetReturnsBySeller lists the returns of the seller's store, newest first.
+func (uc *ReturnUseCase) GetReturnsBySeller(ctx context.Context, request *model.SearchReturnRequest) ([]model.ReturnResponse, int64, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, 0, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	request.StoreID = store.ID
+	return uc.getReturns(request)
+}
+
+// GetReturnBySeller returns one return of the seller's store.
+func (uc *ReturnUseCase) GetReturnBySeller(ctx context.Context, request *model.GetReturnRequest) (*model.ReturnResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	returnRequest, err := uc.returnRepo.FindReturnBySeller(store.ID, request.ReturnUUID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.ReturnToResponse(returnRequest), nil
+}
+
+// ApproveReturn accepts a requested return; the buyer is notified to send the
+// items back.
+//
+// Errors:
+//
+//   - 404 Not Found: if the return is not found in the seller's store.
+//   - 409 Conflict: if the return is not "requested".
+func (uc *ReturnUseCase) ApproveReturn(ctx context.Context, request *model.ApproveReturnRequest) (*model.ReturnResponse, error) {
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	returnRequest, err := uc.findSellerReturn(tx, request.UserID, request.ReturnUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := statemachine.Check(statemachine.Return, returnRequest.Status, "approved"); err != nil {
+		return nil, transitionError(err)
+	}
+	returnRequest.Status = "approved"
+	if note := strings.TrimSpace(request.Note); note != "" {
+		returnRequest.SellerNote = note
+	}
+	if err := uc.returnRepo.UpdateReturn(tx, returnRequest); err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	if err := uc.notifyReturn(ctx, tx, returnRequest, "buyer", entity.Notification{
+		Type:    "return_updated",
+		Title:   "Return approved",
+		Message: fmt.Sprintf("Your return for order %s has been approved. Please send the items back to the seller.", returnRequest.Order.OrderUUID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.ReturnToResponse(returnRequest), nil
+}
+
+// RejectReturn declines a requested return, or an approved one whose items
+// did not pass inspection. The note tells the buyer why.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the note is missing.
+//   - 404 Not Found: if the return is not found in the seller's store.
+//   - 409 Conflict: if the return is neither "requested" nor "approved".
+func (uc *ReturnUseCase) RejectReturn(ctx context.Context, request *model.RejectReturnRequest) (*model.ReturnResponse, error) {
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	request.Note = strings.TrimSpace(request.Note)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	returnRequest, err := uc.findSellerReturn(tx, request.UserID, request.ReturnUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := statemachine.Check(statemachine.Return, returnRequest.Status, "rejected"); err != nil {
+		return nil, transitionError(err)
+	}
+	returnRequest.Status = "rejected"
+	returnRequest.SellerNote = request.Note
+	if err := uc.returnRepo.UpdateReturn(tx, returnRequest); err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	if err := uc.notifyReturn(ctx, tx, returnRequest, "buyer", entity.Notification{
+		Type:    "return_updated",
+		Title:   "Return rejected",
+		Message: fmt.Sprintf("Your return for order %s has been rejected: %s", returnRequest.Order.OrderUUID, request.Note),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.ReturnToResponse(returnRequest), nil
+}
+
+// InspectReturn records that the seller received and inspected the items of
+// an approved return. The items are restocked through the inventory ledger
+// and a refund is issued, see RefundUseCase.IssueRefund: the full value of the
+// returned items, or a lower RefundAmount for a partial refund. The return
+// becomes "refunded" once the payment consumer has booked the refund.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the refund amount is not positive or exceeds the value of the returned items.
+//   - 404 Not Found: if the return is not found in the seller's store.
+//   - 409 Conflict: if the return is not "approved" or the payment cannot be refunded.
+func (uc *ReturnUseCase) InspectReturn(ctx context.Context, request *model.InspectReturnRequest) (*model.ReturnResponse, error) {
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	returnRequest, err := uc.findSellerReturn(tx, request.UserID, request.ReturnUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := statemachine.Check(statemachine.Return, returnRequest.Status, "inspected"); err != nil {
+		return nil, transitionError(err)
+	}
+
+	var itemsAmount money.Amount
+	for _, item := range returnRequest.Items {
+		itemsAmount = itemsAmount.Add(item.Amount)
+	}
+	amount := itemsAmount
+	if request.RefundAmount != nil {
+		amount = *request.RefundAmount
+	}
+	if amount <= 0 || amount > itemsAmount {
+		return nil, model.NewApiError(fiber.StatusBadRequest,
+			fmt.Sprintf("Refund amount must be greater than zero and at most %s %s", itemsAmount, returnRequest.Currency), nil)
+	}
+
+	if err := uc.orderRepo.LockOrder(tx, returnRequest.OrderID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	for _, item := range returnRequest.Items {
+		if err := uc.inventory.ApplyMovement(ctx, tx, &entity.InventoryMovement{
+			ProductID:     item.OrderItem.ProductID,
+			WarehouseID:   returnRequest.Order.WarehouseID,
+			Quantity:      item.Quantity,
+			Reason:        "return",
+			ReferenceType: "return",
+			ReferenceID:   returnRequest.ReturnUUID,
+			UserID:        &request.UserID,
+		}); err != nil {
+			return nil, model.ErrInternalServer
+		}
+	}
+
+	_, refundEvent, err := uc.refund.IssueRefund(ctx, tx, &returnRequest.Order, &model.IssueRefund{
+		Amount:        amount,
+		Reason:        fmt.Sprintf("Refund for return %s", returnRequest.ReturnUUID),
+		ReferenceType: "return",
+		ReferenceID:   returnRequest.ReturnUUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	returnRequest.Status = "inspected"
+	returnRequest.RefundAmount = amount
+	if note := strings.TrimSpace(request.Note); note != "" {
+		returnRequest.SellerNote = note
+	}
+	if err := uc.returnRepo.UpdateReturn(tx, returnRequest); err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	if err := uc.notifyReturn(ctx, tx, returnRequest, "buyer", entity.Notification{
+		Type:    "return_updated",
+		Title:   "Return inspected",
+		Message: fmt.Sprintf("The seller received your return for order %s. A refund of %s %s is on its way.", returnRequest.Order.OrderUUID, amount, returnRequest.Currency),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	go uc.orderEvent.RefundPaymentEvent(ctx, refundEvent)
+	return converter.ReturnToResponse(returnRequest), nil
+}
+
+func (uc *ReturnUseCase) getReturns(request *model.SearchReturnRequest) ([]model.ReturnResponse, int64, error) {
+	returnRequests, total, err := uc.returnRepo.GetReturns(request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.ReturnResponse, len(returnRequests))
+	for i, returnRequest := range returnRequests {
+		responses[i] = *converter.ReturnToResponse(&returnRequest)
+	}
+	return responses, total, nil
+}
+
+// findSellerReturn loads and locks a return of the seller's store.
+func (uc *ReturnUseCase) findSellerReturn(tx *gorm.DB, userID uint, returnUUID string) (*entity.ReturnRequest, error) {
+	store, err := uc.storeRepo.FindStoreByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.returnRepo.FindReturnBySellerForUpdate(tx, store.ID, returnUUID)
+}
+
+// deliveredAt returns when the order was last delivered according to its
+// status history, which starts the return window. Orders completed before
+// orders had a delivered status only have a delivered shipping record.
+func (uc *ReturnUseCase) deliveredAt(db *gorm.DB, orderID uint) (time.Time, error) {
+	history, err := uc.statusRepo.GetHistoryByOrderID(db, orderID)
+	if err != nil {
+		return time.Time{}, model.ErrInternalServer
+	}
+	var deliveredAt time.Time
+	for _, change := range history {
+		if change.ToStatus == "delivered" && change.Machine != string(statemachine.Payment) && change.CreatedAt.After(deliveredAt) {
+			deliveredAt = change.CreatedAt
+		}
+	}
+	if deliveredAt.IsZero() {
+		return time.Time{}, model.NewApiError(fiber.StatusConflict, "Order has no delivery record", nil)
+	}
+	return deliveredAt, nil
+}
+
+// returnAmount is the refund for returning quantity more units of item, on
+// top of what earlier returns already cover. The share is rounded on the
+// running quantity, so the refunds of all partial returns of an item add up
+// to its total price and never exceed it.
+func returnAmount(item entity.OrderItem, returned entity.ReturnItem, quantity int) money.Amount {
+	left := item.TotalPrice.Sub(returned.Amount)
+	amount := item.TotalPrice.MulRatio(int64(returned.Quantity+quantity), int64(item.Quantity)).Sub(returned.Amount)
+	if amount > left {
+		amount = left
+	}
+	if amount < 0 {
+		amount = 0
+	}
+	return amount
+}
+
+// notifyReturn notifies the buyer or the seller of a return.
+func (uc *ReturnUseCase) notifyReturn(ctx context.Context, tx *gorm.DB, returnRequest *entity.ReturnRequest, recipient string, notification entity.Notification) error {
+	notification.UserID = returnRequest.UserID
+	if recipient == "seller" {
+		store, err := uc.storeRepo.FindStoreByID(tx, returnRequest.StoreID)
+		if err != nil {
+			return err
+		}
+		notification.UserID = store.UserID
+	}
+	notification.ReferenceType = "return"
+	notification.ReferenceID = returnRequest.ReturnUUID
+	return uc.notification.Notify(ctx, tx, notification)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+)
+
+func TestReturnAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      money.Amount
+		quantity   int
+		returns    []int
+		wantShares []money.Amount
+	}{
+		{"whole item at once", 1000, 3, []int{3}, []money.Amount{1000}},
+		{"one unit at a time", 1000, 3, []int{1, 1, 1}, []money.Amount{333, 334, 333}},
+		{"one unit, then the rest", 1000, 3, []int{1, 2}, []money.Amount{333, 667}},
+		{"half a cent rounds away from zero", 1001, 2, []int{1, 1}, []money.Amount{501, 500}},
+		{"more than bought", 1000, 2, []int{1, 2}, []money.Amount{500, 500}},
+		{"free item", 0, 2, []int{1, 1}, []money.Amount{0, 0}},
+	}
+	for _, tt := range tests {
+		item := entity.OrderItem{Quantity: tt.quantity, TotalPrice: tt.total}
+		var returned entity.ReturnItem
+		for i, quantity := range tt.returns {
+			got := returnAmount(item, returned, quantity)
+			if got != tt.wantShares[i] {
+				t.Errorf("%s: return %d of %d units = %s, want %s", tt.name, i+1, len(tt.returns), got, tt.wantShares[i])
+			}
+			returned.Quantity += quantity
+			returned.Amount = returned.Amount.Add(got)
+		}
+	}
+}
+
+// TestReturnAmountRefunded covers earlier returns refunded by more than the
+// running share, which the refund of the next return must not exceed.
+func TestReturnAmountRefunded(t *testing.T) {
+	item := entity.OrderItem{Quantity: 2, TotalPrice: 1000}
+	tests := []struct {
+		name     string
+		returned entity.ReturnItem
+		quantity int
+		want     money.Amount
+	}{
+		{"capped by what is left", entity.ReturnItem{Quantity: 1, Amount: 600}, 1, 400},
+		{"nothing left", entity.ReturnItem{Quantity: 1, Amount: 1000}, 1, 0},
+		{"more than the total refunded", entity.ReturnItem{Quantity: 1, Amount: 1100}, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := returnAmount(item, tt.returned, tt.quantity); got != tt.want {
+			t.Errorf("%s: returnAmount = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
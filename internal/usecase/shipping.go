@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	evententity "github.com/abdisetiakawan/go-ecommerce/internal/entity/event_entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
 	eventmodel "github.com/abdisetiakawan/go-ecommerce/internal/model/event_model"
 	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/statemachine"
 	ordereventUC "github.com/abdisetiakawan/go-ecommerce/internal/usecase/event_uc/interfaces"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/go-playground/validator/v10"
@@ -23,6 +25,7 @@ type ShippingUseCase struct {
 	shippingRepo repo.ShippingRepository
 	storeRepo    repo.StoreRepository
 	orderRepo    repo.OrderRepository
+	statusRepo   repo.OrderStatusRepository
 	uuid         *helper.UUIDHelper
 	orderEvent   ordereventUC.OrderEventUseCase
 }
@@ -35,38 +38,35 @@ type ShippingUseCase struct {
 //   - shippingRepo: repo.ShippingRepository - Repository for accessing shipping data.
 //   - storeRepo: repo.StoreRepository - Repository for accessing store data.
 //   - orderRepo: repo.OrderRepository - Repository for accessing order data.
+//   - statusRepo: repo.OrderStatusRepository - Repository for status transitions and their history.
 //   - uuid: helper.UUIDHelper - Helper for generating UUIDs.
 //   - orderEvent: ordereventUC.OrderEventUseCase - Use case for handling order events.
 //
 // Returns:
 //   - interfaces.ShippingUseCase: A new ShippingUseCase instance with all necessary dependencies.
 
-func NewShippingUseCase(db *gorm.DB, validate *validator.Validate, shippingRepo repo.ShippingRepository, storeRepo repo.StoreRepository, orderRepo repo.OrderRepository, uuid *helper.UUIDHelper, orderEvent ordereventUC.OrderEventUseCase) interfaces.ShippingUseCase {
+func NewShippingUseCase(db *gorm.DB, validate *validator.Validate, shippingRepo repo.ShippingRepository, storeRepo repo.StoreRepository, orderRepo repo.OrderRepository, statusRepo repo.OrderStatusRepository, uuid *helper.UUIDHelper, orderEvent ordereventUC.OrderEventUseCase) interfaces.ShippingUseCase {
 	return &ShippingUseCase{
 		db:           db,
 		shippingRepo: shippingRepo,
 		storeRepo: storeRepo,
 		orderRepo: orderRepo,
+		statusRepo: statusRepo,
 		uuid:         uuid,
 		orderEvent:   orderEvent,
 	}
 }
 
-// UpdateShippingStatus updates the shipping status of an order.
-//
-// This function will start a transaction to update the order shipping status. If the order status is not "pending", it will return a 409 error.
-//
-// If the order status is "pending", it will check if the payment status is "paid". If the payment status is not "paid", it will return a 409 error.
-//
-// If the payment status is "paid", it will check if the shipping status is "pending". If the shipping status is not "pending", it will return a 409 error.
-//
-// If the shipping status is "pending", it will check if the request status is "shipped" or "delivered". If the request status is not "shipped" or "delivered", it will return a 400 error.
-//
-// If the request status is "shipped", it will update the shipping status to "shipped" and the order status to "shipped". If the request status is "delivered", it will update the shipping status to "delivered" and the order status to "completed".
-//
-// After updating the order, it will publish an event to kafka topic to update the order status.
-//
-// If there is an error when committing the transaction or publishing the event, it will rollback the transaction and return an error.
+// UpdateShippingStatus moves the shipping of an order of the seller's store
+// to "shipped" or "delivered", and the order with it. The order needs its
+// payment and shipping, which the Kafka consumers create after the order, and
+// a "paid" or "partially_refunded" payment; a partial refund comes from a
+// cancelled item and the rest of the order still ships. Both changes are
+// checked against the shipping and order state machines and recorded in the
+// order status history with the seller as actor. "delivered" is only a
+// delivery claim: the order is completed when the buyer confirms receipt, or
+// later by OrderPolicyUseCase. A "shipping_processed" or "order_delivered"
+// event is stored in the same transaction and published once it commits.
 //
 // Returns:
 //
@@ -75,7 +75,7 @@ func NewShippingUseCase(db *gorm.DB, validate *validator.Validate, shippingRepo
 // Errors:
 //
 //	* 400 Bad Request: if the request status is not "shipped" or "delivered".
-//	* 409 Conflict: if the order status is not "pending" or the payment status is not "paid" or the shipping status is not "pending".
+//	* 409 Conflict: if the payment or shipping of the order is not created yet, the payment is not "paid" or "partially_refunded", or the shipping or order cannot move to the requested status.
 //	* Propagates error from use case layer if update fails.
 func (c *ShippingUseCase) UpdateShippingStatus(ctx context.Context, request *model.UpdateShippingStatusRequest) (*model.OrderResponse, error) {
 	tx := c.db.WithContext(ctx).Begin()
@@ -91,47 +91,68 @@ func (c *ShippingUseCase) UpdateShippingStatus(ctx context.Context, request *mod
 		return nil, err
 	}
 
-	switch {
-	case order.Payment.Status != "paid":
-		return nil, model.NewApiError(fiber.StatusConflict,
-			fmt.Sprintf("Cannot update shipping. Payment status is %s", order.Payment.Status), nil)
-
-	case order.Shipping.Status == "delivered":
-		return nil, model.NewApiError(fiber.StatusConflict, fmt.Sprintf("Cannot update shipping. Order is already %s", order.Status), nil)
-
-	case request.Status == "shipped" && order.Shipping.Status != "pending":
-		return nil, model.NewApiError(fiber.StatusConflict,
-			fmt.Sprintf("Cannot ship order. Current shipping status is %s", order.Shipping.Status), nil)
-
-	case request.Status == "delivered" && order.Shipping.Status == "pending":
-		return nil, model.NewApiError(fiber.StatusConflict,
-			fmt.Sprintf("Cannot deliver order. Current shipping status is %s", order.Shipping.Status), nil)
+	// payment and shipping are created by the Kafka consumers after the order
+	if order.Payment == nil || order.Shipping == nil {
+		return nil, model.NewApiError(fiber.StatusConflict, "Order payment and shipping are still being created, please retry", nil)
+	}
 
-	case request.Status == order.Shipping.Status:
+	// a partial refund comes from a cancelled item, the rest is still paid
+	if order.Payment.Status != "paid" && order.Payment.Status != "partially_refunded" {
 		return nil, model.NewApiError(fiber.StatusConflict,
-			fmt.Sprintf("Cannot update shipping. Current shipping status is %s", order.Shipping.Status), nil)
+			fmt.Sprintf("Cannot update shipping. Payment status is %s", order.Payment.Status), nil)
 	}
 
-	order.Shipping.Status = request.Status
-	var eventType string
-	if request.Status == "shipped" {
+	var eventType, orderStatus, reason string
+	switch request.Status {
+	case "shipped":
 		eventType = "shipping_processed"
-		order.Status = "shipped"
-	} else if request.Status == "delivered" {
+		orderStatus = "shipped"
+		reason = "Shipped by seller"
+	case "delivered":
 		eventType = "order_delivered"
-		order.Status = "completed"
-	} else {
+		orderStatus = "delivered"
+		reason = "Delivery claimed by seller"
+	default:
 		return nil, model.NewApiError(fiber.StatusBadRequest,
 			fmt.Sprintf("Invalid status: %s", request.Status), nil)
 	}
-	
-	if err := c.shippingRepo.UpdateShipping(order.Shipping); err != nil {
-		return nil, err
+
+	// shipping and order move together, both transitions are checked
+	// against the state machine before anything is written
+	if err := statemachine.Check(statemachine.Order, order.Status, orderStatus); err != nil {
+		return nil, transitionError(err)
+	}
+	if err := c.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		Machine:    string(statemachine.Shipping),
+		FromStatus: order.Shipping.Status,
+		ToStatus:   request.Status,
+		ActorType:  "seller",
+		ActorID:    &request.UserID,
+		Reason:     reason,
+	}); err != nil {
+		return nil, transitionError(err)
 	}
+	if err := c.statusRepo.Transition(tx, &entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		Machine:    string(statemachine.Order),
+		FromStatus: order.Status,
+		ToStatus:   orderStatus,
+		ActorType:  "seller",
+		ActorID:    &request.UserID,
+		Reason:     reason,
+	}); err != nil {
+		return nil, transitionError(err)
+	}
+	order.Shipping.Status = request.Status
+	order.Status = orderStatus
 	
-	orderStatus, err := json.Marshal(eventmodel.OrderMessage{
-		OrderID: order.ID,
-		Status:  order.Status,
+	orderData, err := json.Marshal(eventmodel.OrderMessage{
+		OrderID:   order.ID,
+		Status:    order.Status,
+		ActorType: "seller",
+		ActorID:   &request.UserID,
+		Reason:    reason,
 	})
 	if err != nil {
 		return nil, err
@@ -141,17 +162,17 @@ func (c *ShippingUseCase) UpdateShippingStatus(ctx context.Context, request *mod
 		OrderID:    order.ID,
 		Status:     "pending",
 		EventType:  eventType,
-		OrderData: orderStatus,
+		OrderData: orderData,
 	}
 	if err := tx.Create(orderEvent).Error; err != nil {
 		return nil, err
 	}
 
-	go c.orderEvent.ChangeOrderStatusUC(ctx, orderEvent)
-
 	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
+	go c.orderEvent.ChangeOrderStatusUC(ctx, orderEvent)
+
 	return converter.OrderToResponse(order), nil
 }
\ No newline at end of file
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ShippingRateUseCase struct {
+	db               *gorm.DB
+	val              *validator.Validate
+	shippingRateRepo repo.ShippingRateRepository
+	exchangeRate     interfaces.ExchangeRateUseCase
+	uuid             *helper.UUIDHelper
+	providers        []shipping.Provider
+}
+
+// NewShippingRateUseCase creates the use case that maintains the shipping rate
+// table and prices parcels with the given providers.
+func NewShippingRateUseCase(db *gorm.DB, validate *validator.Validate, shippingRateRepo repo.ShippingRateRepository, exchangeRate interfaces.ExchangeRateUseCase, uuid *helper.UUIDHelper, providers []shipping.Provider) interfaces.ShippingRateUseCase {
+	return &ShippingRateUseCase{
+		db:               db,
+		val:              validate,
+		shippingRateRepo: shippingRateRepo,
+		exchangeRate:     exchangeRate,
+		uuid:             uuid,
+		providers:        providers,
+	}
+}
+
+// CreateRates adds a batch of rows to the shipping rate table in one
+// transaction.
+func (uc *ShippingRateUseCase) CreateRates(ctx context.Context, request *model.CreateShippingRatesRequest) ([]model.ShippingRateResponse, error) {
+	for i := range request.Rates {
+		normalizeShippingRate(&request.Rates[i])
+	}
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	rates := make([]entity.ShippingRate, len(request.Rates))
+	for i := range request.Rates {
+		rates[i].RateUUID = uc.uuid.Generate()
+		applyShippingRate(&rates[i], &request.Rates[i])
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.shippingRateRepo.CreateRates(tx, rates); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+
+	responses := make([]model.ShippingRateResponse, len(rates))
+	for i := range rates {
+		responses[i] = *converter.ShippingRateToResponse(&rates[i])
+	}
+	return responses, nil
+}
+
+// GetRates lists the shipping rate table, optionally limited to a service or
+// a route.
+func (uc *ShippingRateUseCase) GetRates(ctx context.Context, request *model.SearchShippingRateRequest) ([]model.ShippingRateResponse, int64, error) {
+	rates, total, err := uc.shippingRateRepo.GetRates(request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.ShippingRateResponse, len(rates))
+	for i := range rates {
+		responses[i] = *converter.ShippingRateToResponse(&rates[i])
+	}
+	return responses, total, nil
+}
+
+// UpdateRate replaces a row of the shipping rate table. Orders already placed
+// keep the shipping cost they were quoted.
+func (uc *ShippingRateUseCase) UpdateRate(ctx context.Context, request *model.UpdateShippingRateRequest) (*model.ShippingRateResponse, error) {
+	normalizeShippingRate(&request.ShippingRateRequest)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	rate, err := uc.shippingRateRepo.FindRateByUUID(request.RateUUID)
+	if err != nil {
+		return nil, err
+	}
+	applyShippingRate(rate, &request.ShippingRateRequest)
+	if err := uc.shippingRateRepo.UpdateRate(rate); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.ShippingRateToResponse(rate), nil
+}
+
+// DeleteRate removes a row of the shipping rate table.
+func (uc *ShippingRateUseCase) DeleteRate(ctx context.Context, request *model.DeleteShippingRateRequest) error {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return err
+	}
+	rate, err := uc.shippingRateRepo.FindRateByUUID(request.RateUUID)
+	if err != nil {
+		return err
+	}
+	if err := uc.shippingRateRepo.DeleteRate(rate); err != nil {
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// Quote asks every provider for the shipping options of a parcel and returns
+// them converted to currency, cheapest first. A provider that fails is logged
+// and skipped, so a courier being unavailable does not block orders. When no
+// provider ships the parcel, a 422 error is returned.
+func (uc *ShippingRateUseCase) Quote(ctx context.Context, db *gorm.DB, parcel *shipping.Parcel, currency string) ([]shipping.Quote, error) {
+	var quotes []shipping.Quote
+	rates := make(map[string]money.Rate)
+	for _, provider := range uc.providers {
+		providerQuotes, err := provider.Quote(ctx, parcel)
+		if err != nil {
+			logrus.WithError(err).WithField("provider", provider.Name()).Error("Failed to quote shipping")
+			continue
+		}
+		for _, quote := range providerQuotes {
+			rate, ok := rates[quote.Currency]
+			if !ok {
+				rate, err = uc.exchangeRate.GetRate(ctx, db, quote.Currency, currency)
+				if err != nil {
+					return nil, err
+				}
+				rates[quote.Currency] = rate
+			}
+			quote.Cost = quote.Cost.Convert(rate)
+			quote.Currency = currency
+			quotes = append(quotes, quote)
+		}
+	}
+	if len(quotes) == 0 {
+		return nil, model.NewApiError(fiber.StatusUnprocessableEntity,
+			fmt.Sprintf("No shipping option is available from %s to %s", parcel.OriginProvince, parcel.DestinationProvince), nil)
+	}
+	sort.SliceStable(quotes, func(i, j int) bool { return quotes[i].Cost < quotes[j].Cost })
+	return quotes, nil
+}
+
+// chooseShippingOption returns the quote of the option the buyer chose, or
+// the cheapest quote when no option was chosen. quotes are sorted cheapest
+// first, as returned by ShippingRateUseCase.Quote.
+func chooseShippingOption(quotes []shipping.Quote, option string) (*shipping.Quote, error) {
+	if option == "" {
+		return &quotes[0], nil
+	}
+	for i := range quotes {
+		if quotes[i].Option() == option {
+			return &quotes[i], nil
+		}
+	}
+	return nil, model.NewApiError(fiber.StatusBadRequest, fmt.Sprintf("Shipping option %s is not available for this order", option), nil)
+}
+
+func normalizeShippingRate(request *model.ShippingRateRequest) {
+	request.Service = strings.ToLower(strings.TrimSpace(request.Service))
+	request.OriginProvince = strings.TrimSpace(request.OriginProvince)
+	request.DestinationProvince = strings.TrimSpace(request.DestinationProvince)
+	request.Currency = strings.ToUpper(request.Currency)
+}
+
+func applyShippingRate(rate *entity.ShippingRate, request *model.ShippingRateRequest) {
+	rate.Service = request.Service
+	rate.OriginProvince = request.OriginProvince
+	rate.DestinationProvince = request.DestinationProvince
+	rate.MinWeight = request.MinWeight
+	rate.MaxWeight = request.MaxWeight
+	rate.Cost = request.Cost
+	rate.Currency = request.Currency
+	if rate.Currency == "" {
+		rate.Currency = money.DefaultCurrency
+	}
+	rate.EstimatedDays = request.EstimatedDays
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	"github.com/abdisetiakawan/go-ecommerce/internal/shipping"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func TestShippingQuote(t *testing.T) {
+	// the provider that is down is logged
+	logrus.SetOutput(io.Discard)
+	defer logrus.SetOutput(os.Stderr)
+
+	table := fakeProvider{name: "table", quotes: []shipping.Quote{
+		{Provider: "table", Service: "regular", Cost: 2000000, Currency: "IDR"},
+		{Provider: "table", Service: "express", Cost: 100, Currency: "USD"},
+	}}
+	courier := fakeProvider{name: "courier", quotes: []shipping.Quote{
+		{Provider: "courier", Service: "economy", Cost: 1500000, Currency: "IDR"},
+	}}
+	down := fakeProvider{name: "down", err: errors.New("courier unavailable")}
+	rates := fakeRates{rates: map[string]money.Rate{"USD:IDR": 16000 * money.RateOne, "IDR:IDR": money.RateOne}}
+
+	uc := &ShippingRateUseCase{exchangeRate: rates, providers: []shipping.Provider{table, down, courier}}
+	quotes, err := uc.Quote(context.Background(), nil, &shipping.Parcel{}, "IDR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"courier:economy 15000.00 IDR", "table:express 16000.00 IDR", "table:regular 20000.00 IDR"}
+	if len(quotes) != len(want) {
+		t.Fatalf("got %d quotes, want %d", len(quotes), len(want))
+	}
+	for i, quote := range quotes {
+		if got := quote.Option() + " " + quote.Cost.String() + " " + quote.Currency; got != want[i] {
+			t.Errorf("quote %d = %s, want %s", i, got, want[i])
+		}
+	}
+
+	chosen, err := chooseShippingOption(quotes, "")
+	if err != nil || chosen.Option() != "courier:economy" {
+		t.Errorf("default option = %v, %v, want the cheapest", chosen, err)
+	}
+	chosen, err = chooseShippingOption(quotes, "table:regular")
+	if err != nil || chosen.Option() != "table:regular" {
+		t.Errorf("chosen option = %v, %v, want table:regular", chosen, err)
+	}
+	var apiErr *model.ApiError
+	if _, err := chooseShippingOption(quotes, "table:overnight"); !errors.As(err, &apiErr) || apiErr.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("unknown option error = %v, want status 400", err)
+	}
+
+	uc.providers = []shipping.Provider{down}
+	if _, err := uc.Quote(context.Background(), nil, &shipping.Parcel{}, "IDR"); !errors.As(err, &apiErr) || apiErr.StatusCode != fiber.StatusUnprocessableEntity {
+		t.Errorf("error without options = %v, want status 422", err)
+	}
+}
+
+type fakeProvider struct {
+	name   string
+	quotes []shipping.Quote
+	err    error
+}
+
+func (p fakeProvider) Name() string {
+	return p.name
+}
+
+func (p fakeProvider) Quote(ctx context.Context, parcel *shipping.Parcel) ([]shipping.Quote, error) {
+	return p.quotes, p.err
+}
+
+// fakeRates answers exchange rates from a map keyed by "FROM:TO".
+type fakeRates struct {
+	interfaces.ExchangeRateUseCase
+	rates map[string]money.Rate
+}
+
+func (r fakeRates) GetRate(ctx context.Context, db *gorm.DB, from, to string) (money.Rate, error) {
+	return r.rates[from+":"+to], nil
+}
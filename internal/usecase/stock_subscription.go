@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type StockSubscriptionUseCase struct {
+	val              *validator.Validate
+	subscriptionRepo repo.StockSubscriptionRepository
+	productRepo      repo.ProductRepository
+}
+
+func NewStockSubscriptionUseCase(validate *validator.Validate, subscriptionRepo repo.StockSubscriptionRepository, productRepo repo.ProductRepository) interfaces.StockSubscriptionUseCase {
+	return &StockSubscriptionUseCase{
+		val:              validate,
+		subscriptionRepo: subscriptionRepo,
+		productRepo:      productRepo,
+	}
+}
+
+// Subscribe registers the buyer for a back-in-stock notification.
+//
+// Only products that are currently out of stock can be subscribed to; a
+// product that still has stock returns a 409 error. Subscribing again after a
+// notification was sent re-arms the subscription for the next restock.
+func (uc *StockSubscriptionUseCase) Subscribe(ctx context.Context, request *model.StockSubscriptionRequest) error {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return err
+	}
+	product, err := uc.productRepo.FindProductByUUID(request.ProductUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.ErrNotFound
+		}
+		return model.ErrInternalServer
+	}
+	if product.Stock > 0 {
+		return model.NewApiError(fiber.StatusConflict, "Product is in stock", nil)
+	}
+	if err := uc.subscriptionRepo.UpsertSubscription(&entity.StockSubscription{
+		UserID:    request.UserID,
+		ProductID: product.ID,
+	}); err != nil {
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// Unsubscribe removes the buyer's back-in-stock subscription for the product.
+// It returns a 404 error if the buyer was not subscribed.
+func (uc *StockSubscriptionUseCase) Unsubscribe(ctx context.Context, request *model.StockSubscriptionRequest) error {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return err
+	}
+	product, err := uc.productRepo.FindProductByUUID(request.ProductUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.ErrNotFound
+		}
+		return model.ErrInternalServer
+	}
+	deleted, err := uc.subscriptionRepo.DeleteSubscription(request.UserID, product.ID)
+	if err != nil {
+		return model.ErrInternalServer
+	}
+	if !deleted {
+		return model.ErrNotFound
+	}
+	return nil
+}
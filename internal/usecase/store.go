@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"strings"
 
 	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
 	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model"
 	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
 	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/go-playground/validator/v10"
@@ -30,11 +32,14 @@ func NewStoreUseCase(db *gorm.DB, validate *validator.Validate, storeRepo repo.S
 }
 
 // RegisterStore registers a new store and returns the newly created store in the response.
+// The store's base currency defaults to money.DefaultCurrency when none is given,
+// and its prices exclude tax unless the tax mode says otherwise.
 // It first validates the request body and checks if the user is a seller.
 // If the user is not a seller, it returns a 403 error.
 // If the request body is invalid, it returns a 400 error.
 // If the store cannot be created, it returns a 500 error.
 func (uc *StoreUseCase) RegisterStore(ctx context.Context, request *model.RegisterStore) (*model.StoreResponse, error) {
+	request.Currency = strings.ToUpper(request.Currency)
 	if err := helper.ValidateStruct(uc.val, request); err != nil {
 		return nil, err
 	}
@@ -50,6 +55,17 @@ func (uc *StoreUseCase) RegisterStore(ctx context.Context, request *model.Regist
 		UserID: request.ID,
 		StoreName: request.StoreName,
 		Description: request.Description,
+		Currency: request.Currency,
+		TaxMode: request.TaxMode,
+		OriginCity: request.OriginCity,
+		OriginProvince: request.OriginProvince,
+		OriginPostalCode: request.OriginPostalCode,
+	}
+	if store.Currency == "" {
+		store.Currency = money.DefaultCurrency
+	}
+	if store.TaxMode == "" {
+		store.TaxMode = "exclusive"
 	}
 	
 	if err := uc.storeRepo.CreateStore(store); err != nil {
@@ -79,7 +95,8 @@ func (uc *StoreUseCase) GetStore(ctx context.Context, id uint) (*model.StoreResp
 
 // UpdateStore updates the information of a store associated with the given user ID.
 // It first validates the request structure. If validation fails, it returns an error.
-// It retrieves the store by user ID, and updates the store's name and description based on the request.
+// It retrieves the store by user ID, and updates the store's name, description, base currency, tax mode and origin address based on the request.
+// Changing the base currency does not touch existing products, which keep the currency they are priced in.
 // If the store does not exist, or if any error occurs during the update process, it returns an error.
 // 
 // Parameters:
@@ -91,6 +108,7 @@ func (uc *StoreUseCase) GetStore(ctx context.Context, id uint) (*model.StoreResp
 //   * error: If an error occurs during validation, retrieval, or update.
 
 func (uc *StoreUseCase) UpdateStore(ctx context.Context, request *model.UpdateStore) (*model.StoreResponse, error) {
+	request.Currency = strings.ToUpper(request.Currency)
 	if err := helper.ValidateStruct(uc.val, request); err != nil {
 		return nil, err
 	}
@@ -104,6 +122,21 @@ func (uc *StoreUseCase) UpdateStore(ctx context.Context, request *model.UpdateSt
 	if request.Description != "" {
 		store.Description = request.Description
 	}
+	if request.Currency != "" {
+		store.Currency = request.Currency
+	}
+	if request.TaxMode != "" {
+		store.TaxMode = request.TaxMode
+	}
+	if request.OriginCity != "" {
+		store.OriginCity = request.OriginCity
+	}
+	if request.OriginProvince != "" {
+		store.OriginProvince = request.OriginProvince
+	}
+	if request.OriginPostalCode != "" {
+		store.OriginPostalCode = request.OriginPostalCode
+	}
 	if err := uc.storeRepo.UpdateStore(&store); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// basisPointsUnit is the number of basis points in 100%.
+const basisPointsUnit = 10000
+
+type TaxRateUseCase struct {
+	db          *gorm.DB
+	val         *validator.Validate
+	taxRateRepo repo.TaxRateRepository
+	uuid        *helper.UUIDHelper
+}
+
+// NewTaxRateUseCase creates the use case that maintains the tax rates and
+// charges them on orders.
+func NewTaxRateUseCase(db *gorm.DB, validate *validator.Validate, taxRateRepo repo.TaxRateRepository, uuid *helper.UUIDHelper) interfaces.TaxRateUseCase {
+	return &TaxRateUseCase{
+		db:          db,
+		val:         validate,
+		taxRateRepo: taxRateRepo,
+		uuid:        uuid,
+	}
+}
+
+// CreateRate adds a tax rate for a category. A rate that overlaps an earlier
+// one of the category supersedes it from its effective_from on.
+func (uc *TaxRateUseCase) CreateRate(ctx context.Context, request *model.CreateTaxRateRequest) (*model.TaxRateResponse, error) {
+	request.Category = normalizeTaxCategory(request.Category)
+	request.Name = strings.TrimSpace(request.Name)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+
+	rate := &entity.TaxRate{
+		TaxRateUUID:   uc.uuid.Generate(),
+		Category:      request.Category,
+		Name:          request.Name,
+		BasisPoints:   request.BasisPoints,
+		EffectiveFrom: time.Now(),
+		EffectiveTo:   request.EffectiveTo,
+	}
+	if request.EffectiveFrom != nil {
+		rate.EffectiveFrom = *request.EffectiveFrom
+	}
+	if err := validateTaxRatePeriod(rate); err != nil {
+		return nil, err
+	}
+	if err := uc.taxRateRepo.CreateRate(rate); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.TaxRateToResponse(rate), nil
+}
+
+// GetRates lists the tax rates, optionally of a single category.
+func (uc *TaxRateUseCase) GetRates(ctx context.Context, request *model.SearchTaxRateRequest) ([]model.TaxRateResponse, int64, error) {
+	request.Category = normalizeTaxCategory(request.Category)
+	rates, total, err := uc.taxRateRepo.GetRates(request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.TaxRateResponse, len(rates))
+	for i := range rates {
+		responses[i] = *converter.TaxRateToResponse(&rates[i])
+	}
+	return responses, total, nil
+}
+
+// UpdateRate changes a tax rate. Orders record the tax they were charged, so
+// a rate in effect keeps its basis points and effective_from; it can only be
+// renamed or ended, and a new rate created to replace it.
+func (uc *TaxRateUseCase) UpdateRate(ctx context.Context, request *model.UpdateTaxRateRequest) (*model.TaxRateResponse, error) {
+	if request.Name != nil {
+		name := strings.TrimSpace(*request.Name)
+		request.Name = &name
+	}
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	rate, err := uc.taxRateRepo.FindRateByUUID(request.TaxRateUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	inEffect := !time.Now().Before(rate.EffectiveFrom)
+	if inEffect && (request.BasisPoints != nil || request.EffectiveFrom != nil) {
+		return nil, model.NewApiError(fiber.StatusConflict, "Tax rate is already in effect, end it and create a new rate instead", nil)
+	}
+	if request.Name != nil {
+		rate.Name = *request.Name
+	}
+	if request.BasisPoints != nil {
+		rate.BasisPoints = *request.BasisPoints
+	}
+	if request.EffectiveFrom != nil {
+		rate.EffectiveFrom = *request.EffectiveFrom
+	}
+	if request.EffectiveTo != nil {
+		rate.EffectiveTo = request.EffectiveTo
+	}
+	if err := validateTaxRatePeriod(rate); err != nil {
+		return nil, err
+	}
+	if err := uc.taxRateRepo.UpdateRate(rate); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.TaxRateToResponse(rate), nil
+}
+
+// ApplyTaxes charges the rate in effect for the tax category of every item of
+// an order that is about to be created, on the item total after discounts.
+// When the store's prices exclude tax, the tax is added to the item and order
+// totals; when they include it, the tax is the part of the item total it
+// accounts for and the totals are left as they are. Items of a category
+// without a rate in effect are not taxed.
+func (uc *TaxRateUseCase) ApplyTaxes(ctx context.Context, tx *gorm.DB, store *entity.Store, order *entity.Order, products map[uint]entity.Product) error {
+	order.TaxInclusive = store.TaxMode == "inclusive"
+	if len(order.Items) == 0 {
+		return nil
+	}
+
+	var categories []string
+	seen := make(map[string]bool)
+	for _, item := range order.Items {
+		category := products[item.ProductID].TaxCategory
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	rates, err := uc.taxRateRepo.FindEffectiveRates(tx, categories, time.Now())
+	if err != nil {
+		return model.ErrInternalServer
+	}
+	// rates come latest first, so the first rate of a category is the one
+	// in effect
+	basisPoints := make(map[string]int, len(categories))
+	for _, rate := range rates {
+		if _, ok := basisPoints[rate.Category]; !ok {
+			basisPoints[rate.Category] = rate.BasisPoints
+		}
+	}
+
+	for i := range order.Items {
+		item := &order.Items[i]
+		bps := basisPoints[products[item.ProductID].TaxCategory]
+		if bps == 0 {
+			continue
+		}
+		item.TaxBasisPoints = bps
+		item.TaxAmount = itemTax(item.TotalPrice, bps, order.TaxInclusive)
+		if !order.TaxInclusive {
+			item.TotalPrice = item.TotalPrice.Add(item.TaxAmount)
+			order.TotalPrice = order.TotalPrice.Add(item.TaxAmount)
+		}
+		order.TaxAmount = order.TaxAmount.Add(item.TaxAmount)
+	}
+	return nil
+}
+
+// itemTax is the tax at bps on an item total after discounts: the part of the
+// total it accounts for when prices include tax, or the tax added on top.
+func itemTax(total money.Amount, bps int, inclusive bool) money.Amount {
+	if inclusive {
+		return total.MulRatio(int64(bps), int64(basisPointsUnit+bps))
+	}
+	return total.MulRatio(int64(bps), basisPointsUnit)
+}
+
+func validateTaxRatePeriod(rate *entity.TaxRate) error {
+	if rate.EffectiveTo != nil && !rate.EffectiveTo.After(rate.EffectiveFrom) {
+		return model.NewApiError(fiber.StatusBadRequest, "Effective to must be after effective from", nil)
+	}
+	return nil
+}
+
+// normalizeTaxCategory makes tax categories case-insensitive.
+func normalizeTaxCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
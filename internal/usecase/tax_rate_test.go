@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+func TestItemTax(t *testing.T) {
+	tests := []struct {
+		total     money.Amount
+		bps       int
+		inclusive bool
+		want      money.Amount
+	}{
+		{10000, 1100, false, 1100},
+		{10000, 1100, true, 991},
+		{11100, 1100, true, 1100},
+		{999, 1000, false, 100},
+		{1099, 1000, true, 100},
+		{10000, 0, false, 0},
+		{0, 1100, true, 0},
+	}
+	for _, tt := range tests {
+		if got := itemTax(tt.total, tt.bps, tt.inclusive); got != tt.want {
+			t.Errorf("itemTax(%s, %d, %v) = %s, want %s", tt.total, tt.bps, tt.inclusive, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTaxes(t *testing.T) {
+	products := map[uint]entity.Product{
+		1: {Model: gorm.Model{ID: 1}, TaxCategory: "standard"},
+		2: {Model: gorm.Model{ID: 2}, TaxCategory: "exempt"},
+		3: {Model: gorm.Model{ID: 3}, TaxCategory: "luxury"},
+	}
+	// luxury had 20% before the current 25%; rates come latest first
+	rates := []entity.TaxRate{
+		{Category: "standard", BasisPoints: 1100},
+		{Category: "luxury", BasisPoints: 2500},
+		{Category: "luxury", BasisPoints: 2000},
+	}
+	tests := []struct {
+		name       string
+		taxMode    string
+		wantTotals []money.Amount
+		wantTaxes  []money.Amount
+		wantTotal  money.Amount
+		wantTax    money.Amount
+	}{
+		{
+			name:       "tax added to prices",
+			taxMode:    "exclusive",
+			wantTotals: []money.Amount{11100, 5000, 25000},
+			wantTaxes:  []money.Amount{1100, 0, 5000},
+			wantTotal:  41100,
+			wantTax:    6100,
+		},
+		{
+			name:       "tax included in prices",
+			taxMode:    "inclusive",
+			wantTotals: []money.Amount{10000, 5000, 20000},
+			wantTaxes:  []money.Amount{991, 0, 4000},
+			wantTotal:  35000,
+			wantTax:    4991,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &entity.Order{
+				TotalPrice: 35000,
+				Items: []entity.OrderItem{
+					{ProductID: 1, TotalPrice: 10000},
+					{ProductID: 2, TotalPrice: 5000},
+					{ProductID: 3, TotalPrice: 20000},
+				},
+			}
+			uc := &TaxRateUseCase{taxRateRepo: fakeTaxRateRepository{rates: rates}}
+			if err := uc.ApplyTaxes(context.Background(), nil, &entity.Store{TaxMode: tt.taxMode}, order, products); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order.TaxInclusive != (tt.taxMode == "inclusive") {
+				t.Errorf("order tax inclusive = %v for a %s store", order.TaxInclusive, tt.taxMode)
+			}
+			for i, item := range order.Items {
+				if item.TotalPrice != tt.wantTotals[i] || item.TaxAmount != tt.wantTaxes[i] {
+					t.Errorf("item %d: total %s, tax %s, want %s, %s", i, item.TotalPrice, item.TaxAmount, tt.wantTotals[i], tt.wantTaxes[i])
+				}
+			}
+			if order.TotalPrice != tt.wantTotal || order.TaxAmount != tt.wantTax {
+				t.Errorf("order total %s, tax %s, want %s, %s", order.TotalPrice, order.TaxAmount, tt.wantTotal, tt.wantTax)
+			}
+		})
+	}
+}
+
+type fakeTaxRateRepository struct {
+	repo.TaxRateRepository
+	rates []entity.TaxRate
+}
+
+func (r fakeTaxRateRepository) FindEffectiveRates(db *gorm.DB, categories []string, at time.Time) ([]entity.TaxRate, error) {
+	return r.rates, nil
+}
@@ -10,6 +10,7 @@ import (
 	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
 	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
 	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -20,15 +21,17 @@ type UserUseCase struct {
 	userRepo repo.UserRepository
 	uuid 	*helper.UUIDHelper
 	jwt 	*helper.JwtHelper
+	cart    interfaces.CartUseCase
 }
 
-func NewUserUseCase(db *gorm.DB, validate *validator.Validate, userRepo repo.UserRepository, uuid *helper.UUIDHelper, jwt *helper.JwtHelper) interfaces.UserUseCase {
+func NewUserUseCase(db *gorm.DB, validate *validator.Validate, userRepo repo.UserRepository, uuid *helper.UUIDHelper, jwt *helper.JwtHelper, cart interfaces.CartUseCase) interfaces.UserUseCase {
 	return &UserUseCase{
 		db:       db,
 		val:      validate,
 		userRepo: userRepo,
 		uuid: uuid,
 		jwt: jwt,
+		cart: cart,
 	}
 }
 
@@ -115,6 +118,8 @@ func (uc *UserUseCase) Register(ctx context.Context, request *model.RegisterUser
 // It compares the hashed password stored for the user with the provided password.
 // If the password is incorrect, it returns an error.
 // If the password is correct, it generates a new access token and refresh token for the user.
+// A buyer who shopped as a guest gets the guest cart merged into their own cart; a failed merge
+// is logged and does not fail the login.
 // 
 // Parameters:
 // 
@@ -160,6 +165,12 @@ func (uc *UserUseCase) Login(ctx context.Context, request *model.LoginUser) (*mo
 	user.AccessToken = accessToken
 	user.RefreshToken = refreshToken
 
+	if user.Role == "buyer" && request.CartToken != "" {
+		if err := uc.cart.MergeGuestCart(ctx, user.ID, request.CartToken); err != nil {
+			logrus.WithError(err).Error("Failed to merge guest cart")
+		}
+	}
+
 	return converter.AuthToResponse(user), nil
 }
 
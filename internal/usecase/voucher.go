@@ -0,0 +1,521 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type VoucherUseCase struct {
+	db           *gorm.DB
+	val          *validator.Validate
+	voucherRepo  repo.VoucherRepository
+	storeRepo    repo.StoreRepository
+	productRepo  repo.ProductRepository
+	exchangeRate interfaces.ExchangeRateUseCase
+	uuid         *helper.UUIDHelper
+}
+
+func NewVoucherUseCase(db *gorm.DB, validate *validator.Validate, voucherRepo repo.VoucherRepository, storeRepo repo.StoreRepository, productRepo repo.ProductRepository, exchangeRate interfaces.ExchangeRateUseCase, uuid *helper.UUIDHelper) interfaces.VoucherUseCase {
+	return &VoucherUseCase{
+		db:           db,
+		val:          validate,
+		voucherRepo:  voucherRepo,
+		storeRepo:    storeRepo,
+		productRepo:  productRepo,
+		exchangeRate: exchangeRate,
+		uuid:         uuid,
+	}
+}
+
+// CreateVoucher creates a voucher. Admins create platform vouchers, sellers
+// vouchers of their store, which can only be scoped to the store's products.
+// Codes are case-insensitive and stored in upper case. Amounts are in the
+// given currency, by default the store currency or money.DefaultCurrency for
+// platform vouchers.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid or a product does not belong to the store.
+//   - 404 Not Found: if the seller has no store.
+//   - 409 Conflict: if the code is already taken.
+func (uc *VoucherUseCase) CreateVoucher(ctx context.Context, request *model.CreateVoucherRequest) (*model.VoucherResponse, error) {
+	request.Code = strings.ToUpper(request.Code)
+	request.Currency = strings.ToUpper(request.Currency)
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	if request.Type == "percentage" {
+		if request.Percent == 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Percent is required for percentage vouchers", nil)
+		}
+		if request.MaxDiscount != nil && *request.MaxDiscount <= 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Max discount must be greater than zero", nil)
+		}
+	} else if request.Amount <= 0 {
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Amount must be greater than zero for fixed vouchers", nil)
+	}
+	if request.MinSpend < 0 {
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Min spend must not be negative", nil)
+	}
+	if err := validateVoucherPeriod(request.StartsAt, request.EndsAt); err != nil {
+		return nil, err
+	}
+
+	storeID, currency, err := uc.owner(request.UserID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+	if request.Currency != "" {
+		currency = request.Currency
+	}
+	exists, err := uc.voucherRepo.CodeExists(request.Code)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if exists {
+		return nil, model.NewApiError(fiber.StatusConflict, "Voucher code already exists", nil)
+	}
+
+	tx := uc.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var products []entity.Product
+	if len(request.ProductUUIDs) > 0 {
+		products, err = uc.productRepo.FindProductsByUUIDsForUpdate(tx, request.ProductUUIDs)
+		if err != nil {
+			return nil, model.ErrInternalServer
+		}
+		found := make(map[string]bool, len(products))
+		for _, product := range products {
+			if storeID != nil && product.StoreID != *storeID {
+				return nil, model.NewApiError(fiber.StatusBadRequest, "Vouchers can only be scoped to products of your store", nil)
+			}
+			found[product.ProductUUID] = true
+		}
+		for _, productUUID := range request.ProductUUIDs {
+			if !found[productUUID] {
+				return nil, model.NewApiError(fiber.StatusBadRequest, "One or more products not found", nil)
+			}
+		}
+	}
+
+	voucher := &entity.Voucher{
+		VoucherUUID:  uc.uuid.Generate(),
+		Code:         request.Code,
+		StoreID:      storeID,
+		Type:         request.Type,
+		MinSpend:     request.MinSpend,
+		Currency:     currency,
+		Categories:   strings.Join(request.Categories, ","),
+		UsageLimit:   request.UsageLimit,
+		PerUserLimit: request.PerUserLimit,
+		StartsAt:     request.StartsAt,
+		EndsAt:       request.EndsAt,
+		IsActive:     true,
+		Products:     products,
+	}
+	if request.Type == "percentage" {
+		voucher.Percent = request.Percent
+		voucher.MaxDiscount = request.MaxDiscount
+	} else {
+		voucher.Amount = request.Amount
+	}
+	if err := uc.voucherRepo.CreateVoucher(tx, voucher); err != nil {
+		if err == model.ErrConflict {
+			return nil, model.NewApiError(fiber.StatusConflict, "Voucher code already exists", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.VoucherToResponse(voucher), nil
+}
+
+// GetVouchers lists the platform vouchers for admins and the store's vouchers
+// for sellers, newest first.
+func (uc *VoucherUseCase) GetVouchers(ctx context.Context, request *model.SearchVoucherRequest) ([]model.VoucherResponse, int64, error) {
+	storeID, _, err := uc.owner(request.UserID, request.Role)
+	if err != nil {
+		return nil, 0, err
+	}
+	vouchers, total, err := uc.voucherRepo.GetVouchers(storeID, request)
+	if err != nil {
+		return nil, 0, model.ErrInternalServer
+	}
+	responses := make([]model.VoucherResponse, len(vouchers))
+	for i := range vouchers {
+		responses[i] = *converter.VoucherToResponse(&vouchers[i])
+	}
+	return responses, total, nil
+}
+
+// GetVoucher returns a platform voucher for admins or a voucher of the store
+// for sellers.
+func (uc *VoucherUseCase) GetVoucher(ctx context.Context, request *model.GetVoucherRequest) (*model.VoucherResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	storeID, _, err := uc.owner(request.UserID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := uc.voucherRepo.FindVoucherByUUID(storeID, request.VoucherUUID)
+	if err != nil {
+		return nil, err
+	}
+	return converter.VoucherToResponse(voucher), nil
+}
+
+// UpdateVoucher changes the minimum spend, the limits, the validity window or
+// the active flag of a voucher. The code, discount and scope of a voucher
+// cannot change once it may have been used; create a new voucher instead.
+func (uc *VoucherUseCase) UpdateVoucher(ctx context.Context, request *model.UpdateVoucherRequest) (*model.VoucherResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	storeID, _, err := uc.owner(request.UserID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := uc.voucherRepo.FindVoucherByUUID(storeID, request.VoucherUUID)
+	if err != nil {
+		return nil, err
+	}
+	if request.MinSpend != nil {
+		if *request.MinSpend < 0 {
+			return nil, model.NewApiError(fiber.StatusBadRequest, "Min spend must not be negative", nil)
+		}
+		voucher.MinSpend = *request.MinSpend
+	}
+	if request.UsageLimit != nil {
+		voucher.UsageLimit = *request.UsageLimit
+	}
+	if request.PerUserLimit != nil {
+		voucher.PerUserLimit = *request.PerUserLimit
+	}
+	if request.StartsAt != nil {
+		voucher.StartsAt = request.StartsAt
+	}
+	if request.EndsAt != nil {
+		voucher.EndsAt = request.EndsAt
+	}
+	if request.IsActive != nil {
+		voucher.IsActive = *request.IsActive
+	}
+	if err := validateVoucherPeriod(voucher.StartsAt, voucher.EndsAt); err != nil {
+		return nil, err
+	}
+	if err := uc.voucherRepo.UpdateVoucher(voucher); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.VoucherToResponse(voucher), nil
+}
+
+// FindVoucher loads the voucher of a code inside the caller's transaction and
+// checks that it can be used now. The voucher stays locked until the
+// transaction ends, so its limits are checked and counted atomically by
+// ApplyVoucher and RedeemVoucher.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the voucher is inactive, not valid yet or expired.
+//   - 404 Not Found: if no voucher has the code.
+func (uc *VoucherUseCase) FindVoucher(ctx context.Context, tx *gorm.DB, code string) (*entity.Voucher, error) {
+	voucher, err := uc.voucherRepo.FindVoucherByCodeForUpdate(tx, strings.ToUpper(code))
+	if err != nil {
+		if err == model.ErrNotFound {
+			return nil, model.NewApiError(fiber.StatusNotFound, "Voucher not found", nil)
+		}
+		return nil, model.ErrInternalServer
+	}
+	now := time.Now()
+	switch {
+	case !voucher.IsActive:
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Voucher is no longer active", nil)
+	case voucher.StartsAt != nil && now.Before(*voucher.StartsAt):
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Voucher is not valid yet", nil)
+	case voucher.EndsAt != nil && !now.Before(*voucher.EndsAt):
+		return nil, model.NewApiError(fiber.StatusBadRequest, "Voucher has expired", nil)
+	}
+	return voucher, nil
+}
+
+// ApplyVoucher checks a voucher found by FindVoucher against an order that
+// is about to be created and takes its discount off the order. The discount
+// is computed on the items the voucher covers, at their price after
+// promotions, converted to the order currency, and spread over those items in
+// proportion to their price by largest remainder, so no item's share is
+// negative or more than its price. Item and order totals are reduced by the
+// discount, which is added to the discount of the order and recorded as a
+// discount line of each item.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the voucher is for another store, covers no item or the minimum spend is not reached.
+//   - 409 Conflict: if the voucher or the buyer's share of it is used up.
+func (uc *VoucherUseCase) ApplyVoucher(ctx context.Context, tx *gorm.DB, voucher *entity.Voucher, order *entity.Order, products map[uint]entity.Product) error {
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return model.NewApiError(fiber.StatusConflict, "Voucher has been fully used", nil)
+	}
+	if voucher.PerUserLimit > 0 {
+		used, err := uc.voucherRepo.CountRedemptions(tx, voucher.ID, order.UserID)
+		if err != nil {
+			return model.ErrInternalServer
+		}
+		if used >= int64(voucher.PerUserLimit) {
+			return model.NewApiError(fiber.StatusConflict, "You have already used this voucher", nil)
+		}
+	}
+
+	var eligible money.Amount
+	var covered []int
+	var prices []money.Amount
+	for i, item := range order.Items {
+		product := products[item.ProductID]
+		if voucher.StoreID != nil && *voucher.StoreID != product.StoreID {
+			return model.NewApiError(fiber.StatusBadRequest, "Voucher is not valid for this store", nil)
+		}
+		if voucherCovers(voucher, &product) {
+			eligible = eligible.Add(item.TotalPrice)
+			covered = append(covered, i)
+			prices = append(prices, item.TotalPrice)
+		}
+	}
+	if len(covered) == 0 || eligible.IsZero() {
+		return model.NewApiError(fiber.StatusBadRequest, "Voucher does not apply to these products", nil)
+	}
+
+	rate, err := uc.exchangeRate.GetRate(ctx, tx, voucher.Currency, order.Currency)
+	if err != nil {
+		return err
+	}
+	if minSpend := voucher.MinSpend.Convert(rate); eligible < minSpend {
+		return model.NewApiError(fiber.StatusBadRequest, fmt.Sprintf("Voucher requires a minimum spend of %s %s", minSpend, order.Currency), nil)
+	}
+
+	var discount money.Amount
+	if voucher.Type == "percentage" {
+		discount = eligible.MulRatio(int64(voucher.Percent), 100)
+		if voucher.MaxDiscount != nil {
+			if maxDiscount := voucher.MaxDiscount.Convert(rate); discount > maxDiscount {
+				discount = maxDiscount
+			}
+		}
+	} else {
+		discount = voucher.Amount.Convert(rate)
+	}
+	if discount > eligible {
+		discount = eligible
+	}
+
+	shares := discount.Allocate(prices)
+	for k, i := range covered {
+		item := &order.Items[i]
+		share := shares[k]
+		if share.IsZero() {
+			continue
+		}
+		item.DiscountAmount = item.DiscountAmount.Add(share)
+		item.TotalPrice = item.TotalPrice.Sub(share)
+		item.Discounts = append(item.Discounts, entity.OrderItemDiscount{
+			VoucherID: &voucher.ID,
+			Label:     "Voucher " + voucher.Code,
+			Amount:    share,
+		})
+	}
+	order.DiscountAmount = order.DiscountAmount.Add(discount)
+	order.TotalPrice = order.TotalPrice.Sub(discount)
+	order.VoucherCode = voucher.Code
+	return nil
+}
+
+// RedeemVoucher records the use of a voucher by an order created after
+// ApplyVoucher, in the same transaction, with the voucher's discount lines.
+func (uc *VoucherUseCase) RedeemVoucher(ctx context.Context, tx *gorm.DB, voucher *entity.Voucher, order *entity.Order) error {
+	var discount money.Amount
+	for _, item := range order.Items {
+		for _, line := range item.Discounts {
+			if line.VoucherID != nil && *line.VoucherID == voucher.ID {
+				discount = discount.Add(line.Amount)
+			}
+		}
+	}
+	if err := uc.voucherRepo.Redeem(tx, &entity.VoucherRedemption{
+		VoucherID: voucher.ID,
+		UserID:    order.UserID,
+		OrderID:   order.ID,
+		Discount:  discount,
+	}); err != nil {
+		if err == model.ErrConflict {
+			return model.NewApiError(fiber.StatusConflict, "Voucher has been fully used", nil)
+		}
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// ReleaseVoucher gives the voucher use of a cancelled order back, so the
+// buyer can use the voucher again. Orders without a voucher are left alone.
+func (uc *VoucherUseCase) ReleaseVoucher(ctx context.Context, tx *gorm.DB, order *entity.Order) error {
+	if order.VoucherCode == "" {
+		return nil
+	}
+	if err := uc.voucherRepo.Release(tx, order.ID); err != nil {
+		return model.ErrInternalServer
+	}
+	return nil
+}
+
+// RecheckVoucher checks the voucher of an order again once items of it were
+// cancelled. While the active items it covers, those with a discount line of
+// it, still reach its minimum spend the order keeps the discount. Otherwise
+// the voucher no longer applies: its discount lines are taken off the active
+// items, whose tax and totals are worked out again without it, the order
+// totals follow and the voucher use is given back. It returns the repriced
+// items, the caller stores them.
+func (uc *VoucherUseCase) RecheckVoucher(ctx context.Context, tx *gorm.DB, order *entity.Order) ([]entity.OrderItem, error) {
+	if order.VoucherCode == "" {
+		return nil, nil
+	}
+	voucher, err := uc.voucherRepo.FindVoucherByCodeForUpdate(tx, order.VoucherCode)
+	if err != nil {
+		if err == model.ErrNotFound {
+			// deleted vouchers are not checked again
+			return nil, nil
+		}
+		return nil, model.ErrInternalServer
+	}
+
+	// the minimum spend is checked on the price before the voucher and tax,
+	// as ApplyVoucher does
+	var eligible money.Amount
+	for _, item := range order.Items {
+		share := voucherShare(&item, voucher.ID)
+		if item.Status == "cancelled" || share.IsZero() {
+			continue
+		}
+		eligible = eligible.Add(itemPriceBeforeTax(&item, order.TaxInclusive)).Add(share)
+	}
+	rate, err := uc.exchangeRate.GetRate(ctx, tx, voucher.Currency, order.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if eligible >= voucher.MinSpend.Convert(rate) {
+		return nil, nil
+	}
+
+	var repriced []entity.OrderItem
+	for i := range order.Items {
+		item := &order.Items[i]
+		share := voucherShare(item, voucher.ID)
+		if item.Status == "cancelled" || share.IsZero() {
+			continue
+		}
+		total := itemPriceBeforeTax(item, order.TaxInclusive).Add(share)
+		tax := itemTax(total, item.TaxBasisPoints, order.TaxInclusive)
+		if !order.TaxInclusive {
+			total = total.Add(tax)
+		}
+		order.TotalPrice = order.TotalPrice.Add(total.Sub(item.TotalPrice))
+		order.TaxAmount = order.TaxAmount.Add(tax.Sub(item.TaxAmount))
+		order.DiscountAmount = order.DiscountAmount.Sub(share)
+
+		item.TotalPrice = total
+		item.TaxAmount = tax
+		item.DiscountAmount = item.DiscountAmount.Sub(share)
+		discounts := item.Discounts[:0]
+		for _, line := range item.Discounts {
+			if line.VoucherID == nil || *line.VoucherID != voucher.ID {
+				discounts = append(discounts, line)
+			}
+		}
+		item.Discounts = discounts
+		repriced = append(repriced, *item)
+	}
+	order.VoucherCode = ""
+	if err := uc.voucherRepo.Release(tx, order.ID); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return repriced, nil
+}
+
+// voucherShare is the discount of the voucher on an order item.
+func voucherShare(item *entity.OrderItem, voucherID uint) money.Amount {
+	var share money.Amount
+	for _, line := range item.Discounts {
+		if line.VoucherID != nil && *line.VoucherID == voucherID {
+			share = share.Add(line.Amount)
+		}
+	}
+	return share
+}
+
+// itemPriceBeforeTax is the item total after discounts without any tax added
+// on top of it.
+func itemPriceBeforeTax(item *entity.OrderItem, taxInclusive bool) money.Amount {
+	if taxInclusive {
+		return item.TotalPrice
+	}
+	return item.TotalPrice.Sub(item.TaxAmount)
+}
+
+// owner returns the store whose vouchers the user manages, nil for the
+// platform vouchers of admins, and the default currency of its vouchers.
+func (uc *VoucherUseCase) owner(userID uint, role string) (*uint, string, error) {
+	if role == "admin" {
+		return nil, money.DefaultCurrency, nil
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	return &store.ID, store.Currency, nil
+}
+
+func validateVoucherPeriod(startsAt, endsAt *time.Time) error {
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return model.NewApiError(fiber.StatusBadRequest, "Ends at must be after starts at", nil)
+	}
+	return nil
+}
+
+// voucherCovers reports whether the voucher applies to a product, see
+// scopeCovers.
+func voucherCovers(voucher *entity.Voucher, product *entity.Product) bool {
+	return scopeCovers(voucher.Products, voucher.Categories, product)
+}
+
+// scopeCovers reports whether a product scope of vouchers and promotions
+// includes a product: every product when the scope lists no product and no
+// category, otherwise the listed products and the products of the listed
+// categories.
+func scopeCovers(products []entity.Product, categories string, product *entity.Product) bool {
+	if len(products) == 0 && categories == "" {
+		return true
+	}
+	for _, scoped := range products {
+		if scoped.ID == product.ID {
+			return true
+		}
+	}
+	for _, category := range strings.Split(categories, ",") {
+		if category == product.Category {
+			return true
+		}
+	}
+	return false
+}
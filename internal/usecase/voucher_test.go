@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/money"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func TestApplyVoucher(t *testing.T) {
+	storeOne, storeTwo := uint(1), uint(2)
+	maxDiscount := money.Amount(2000)
+	products := map[uint]entity.Product{
+		1: {Model: gorm.Model{ID: 1}, StoreID: 1, Category: "clothes"},
+		2: {Model: gorm.Model{ID: 2}, StoreID: 1, Category: "electronics"},
+		3: {Model: gorm.Model{ID: 3}, StoreID: 1, Category: "clothes"},
+	}
+	tests := []struct {
+		name        string
+		voucher     entity.Voucher
+		redemptions int64
+		items       []money.Amount
+		wantStatus  int
+		wantShares  []money.Amount
+	}{
+		{
+			name:       "percentage of every item",
+			voucher:    entity.Voucher{Type: "percentage", Percent: 10},
+			items:      []money.Amount{10000, 5000},
+			wantShares: []money.Amount{1000, 500},
+		},
+		{
+			name:       "percentage capped by the maximum discount",
+			voucher:    entity.Voucher{Type: "percentage", Percent: 50, MaxDiscount: &maxDiscount},
+			items:      []money.Amount{10000, 5000},
+			wantShares: []money.Amount{1333, 667},
+		},
+		{
+			name:       "fixed amount split by largest remainder",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 100},
+			items:      []money.Amount{1000, 1000, 1000},
+			wantShares: []money.Amount{34, 33, 33},
+		},
+		{
+			name:       "fixed amount above the covered items",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 20000},
+			items:      []money.Amount{10000, 5000},
+			wantShares: []money.Amount{10000, 5000},
+		},
+		{
+			name:       "category scope",
+			voucher:    entity.Voucher{Type: "percentage", Percent: 10, Categories: "electronics"},
+			items:      []money.Amount{10000, 5000},
+			wantShares: []money.Amount{0, 500},
+		},
+		{
+			name:       "store voucher of the order store",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 1500, StoreID: &storeOne},
+			items:      []money.Amount{10000, 5000},
+			wantShares: []money.Amount{1000, 500},
+		},
+		{
+			name:       "minimum spend counts covered items only",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 1000, MinSpend: 10000, Categories: "electronics"},
+			items:      []money.Amount{10000, 5000},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "store voucher of another store",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 1000, StoreID: &storeTwo},
+			items:      []money.Amount{10000, 5000},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "no covered item",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 1000, Categories: "accessories"},
+			items:      []money.Amount{10000, 5000},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:       "fully used",
+			voucher:    entity.Voucher{Type: "fixed", Amount: 1000, UsageLimit: 5, UsedCount: 5},
+			items:      []money.Amount{10000, 5000},
+			wantStatus: fiber.StatusConflict,
+		},
+		{
+			name:        "used up by the buyer",
+			voucher:     entity.Voucher{Type: "fixed", Amount: 1000, PerUserLimit: 1},
+			redemptions: 1,
+			items:       []money.Amount{10000, 5000},
+			wantStatus:  fiber.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			voucher := tt.voucher
+			voucher.ID = 7
+			voucher.Code = "SAVE"
+			voucher.Currency = "IDR"
+			order := &entity.Order{UserID: 1, Currency: "IDR"}
+			for i, price := range tt.items {
+				order.Items = append(order.Items, entity.OrderItem{ProductID: uint(i + 1), TotalPrice: price})
+				order.TotalPrice = order.TotalPrice.Add(price)
+			}
+			total := order.TotalPrice
+			uc := &VoucherUseCase{
+				voucherRepo:  &fakeVoucherRepository{redemptions: tt.redemptions},
+				exchangeRate: fakeExchangeRateUseCase{},
+			}
+
+			err := uc.ApplyVoucher(context.Background(), nil, &voucher, order, products)
+			if tt.wantStatus != 0 {
+				var apiErr *model.ApiError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+				}
+				if order.TotalPrice != total || order.VoucherCode != "" {
+					t.Errorf("order changed by a refused voucher: total %s, voucher %q", order.TotalPrice, order.VoucherCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var discount money.Amount
+			shares := make([]money.Amount, len(order.Items))
+			for i, item := range order.Items {
+				shares[i] = voucherShare(&item, voucher.ID)
+				discount = discount.Add(shares[i])
+				if item.DiscountAmount != shares[i] || item.TotalPrice != tt.items[i].Sub(shares[i]) {
+					t.Errorf("item %d: discount %s, total %s, want %s off %s", i, item.DiscountAmount, item.TotalPrice, shares[i], tt.items[i])
+				}
+				if shares[i].IsZero() && len(item.Discounts) != 0 {
+					t.Errorf("item %d has discount lines without a share: %v", i, item.Discounts)
+				}
+			}
+			if !reflect.DeepEqual(shares, tt.wantShares) {
+				t.Errorf("shares = %v, want %v", shares, tt.wantShares)
+			}
+			if order.DiscountAmount != discount || order.TotalPrice != total.Sub(discount) {
+				t.Errorf("order discount %s, total %s, want %s off %s", order.DiscountAmount, order.TotalPrice, discount, total)
+			}
+			if order.VoucherCode != "SAVE" {
+				t.Errorf("order voucher = %q, want SAVE", order.VoucherCode)
+			}
+		})
+	}
+}
+
+// TestRecheckVoucher cancels the second of two items that share a voucher
+// and checks the voucher against the item left.
+func TestRecheckVoucher(t *testing.T) {
+	voucherID, promotionID := uint(7), uint(3)
+	tests := []struct {
+		name         string
+		taxInclusive bool
+		minSpend     money.Amount
+		deleted      bool
+		wantKept     bool
+		wantTotal    money.Amount
+		wantTax      money.Amount
+	}{
+		{name: "minimum spend still reached", minSpend: 9500, wantKept: true},
+		{name: "voucher deleted", minSpend: 12000, deleted: true, wantKept: true},
+		{name: "below minimum spend, tax added", minSpend: 12000, wantTotal: 10450, wantTax: 950},
+		{name: "below minimum spend, tax included", taxInclusive: true, minSpend: 12000, wantTotal: 9500, wantTax: 864},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the active item costs 10000, 500 off by a promotion and 1000
+			// off by the voucher, and is taxed at 10%
+			item := entity.OrderItem{
+				Model:          gorm.Model{ID: 1},
+				Status:         "active",
+				TaxBasisPoints: 1000,
+				DiscountAmount: 1500,
+				Discounts: []entity.OrderItemDiscount{
+					{PromotionID: &promotionID, Label: "Sale", Amount: 500},
+					{VoucherID: &voucherID, Label: "Voucher SAVE", Amount: 1000},
+				},
+			}
+			if tt.taxInclusive {
+				item.TotalPrice, item.TaxAmount = 8500, 773
+			} else {
+				item.TotalPrice, item.TaxAmount = 9350, 850
+			}
+			cancelled := entity.OrderItem{
+				Model:      gorm.Model{ID: 2},
+				Status:     "cancelled",
+				Discounts:  []entity.OrderItemDiscount{{VoucherID: &voucherID, Label: "Voucher SAVE", Amount: 500}},
+				TotalPrice: 4500,
+			}
+			order := &entity.Order{
+				Model:          gorm.Model{ID: 9},
+				Currency:       "IDR",
+				TaxInclusive:   tt.taxInclusive,
+				VoucherCode:    "SAVE",
+				TotalPrice:     item.TotalPrice,
+				TaxAmount:      item.TaxAmount,
+				DiscountAmount: item.DiscountAmount,
+				Items:          []entity.OrderItem{item, cancelled},
+			}
+			vouchers := &fakeVoucherRepository{}
+			if !tt.deleted {
+				vouchers.voucher = &entity.Voucher{Model: gorm.Model{ID: voucherID}, Code: "SAVE", MinSpend: tt.minSpend, Currency: "IDR"}
+			}
+			uc := &VoucherUseCase{voucherRepo: vouchers, exchangeRate: fakeExchangeRateUseCase{}}
+
+			repriced, err := uc.RecheckVoucher(context.Background(), nil, order)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantKept {
+				if repriced != nil || order.VoucherCode != "SAVE" || order.TotalPrice != item.TotalPrice || vouchers.released != 0 {
+					t.Errorf("voucher not kept: repriced %v, voucher %q, total %s, released order %d", repriced, order.VoucherCode, order.TotalPrice, vouchers.released)
+				}
+				return
+			}
+
+			if len(repriced) != 1 || repriced[0].ID != 1 {
+				t.Fatalf("repriced %v, want the active item", repriced)
+			}
+			got := order.Items[0]
+			if got.TotalPrice != tt.wantTotal || got.TaxAmount != tt.wantTax || got.DiscountAmount != 500 {
+				t.Errorf("item total %s, tax %s, discount %s, want %s, %s, 500.00", got.TotalPrice, got.TaxAmount, got.DiscountAmount, tt.wantTotal, tt.wantTax)
+			}
+			if len(got.Discounts) != 1 || got.Discounts[0].PromotionID == nil {
+				t.Errorf("discount lines %v, want the promotion line only", got.Discounts)
+			}
+			if order.TotalPrice != tt.wantTotal || order.TaxAmount != tt.wantTax || order.DiscountAmount != 500 {
+				t.Errorf("order total %s, tax %s, discount %s, want %s, %s, 500.00", order.TotalPrice, order.TaxAmount, order.DiscountAmount, tt.wantTotal, tt.wantTax)
+			}
+			if order.VoucherCode != "" || vouchers.released != order.ID {
+				t.Errorf("voucher %q, released order %d, want the use of order %d given back", order.VoucherCode, vouchers.released, order.ID)
+			}
+			if order.Items[1].TotalPrice != 4500 || len(order.Items[1].Discounts) != 1 {
+				t.Errorf("cancelled item was repriced: %+v", order.Items[1])
+			}
+		})
+	}
+}
+
+type fakeVoucherRepository struct {
+	repo.VoucherRepository
+	voucher     *entity.Voucher
+	redemptions int64
+	released    uint
+}
+
+func (r *fakeVoucherRepository) FindVoucherByCodeForUpdate(db *gorm.DB, code string) (*entity.Voucher, error) {
+	if r.voucher == nil || r.voucher.Code != code {
+		return nil, model.ErrNotFound
+	}
+	return r.voucher, nil
+}
+
+func (r *fakeVoucherRepository) CountRedemptions(db *gorm.DB, voucherID uint, userID uint) (int64, error) {
+	return r.redemptions, nil
+}
+
+func (r *fakeVoucherRepository) Release(db *gorm.DB, orderID uint) error {
+	r.released = orderID
+	return nil
+}
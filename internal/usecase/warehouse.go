@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/helper"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model/converter"
+	repo "github.com/abdisetiakawan/go-ecommerce/internal/repository/interfaces"
+	"github.com/abdisetiakawan/go-ecommerce/internal/usecase/interfaces"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type WarehouseUseCase struct {
+	db            *gorm.DB
+	val           *validator.Validate
+	warehouseRepo repo.WarehouseRepository
+	storeRepo     repo.StoreRepository
+	productRepo   repo.ProductRepository
+	uuid          *helper.UUIDHelper
+}
+
+func NewWarehouseUseCase(db *gorm.DB, validate *validator.Validate, warehouseRepo repo.WarehouseRepository, storeRepo repo.StoreRepository, productRepo repo.ProductRepository, uuid *helper.UUIDHelper) interfaces.WarehouseUseCase {
+	return &WarehouseUseCase{
+		db:            db,
+		val:           validate,
+		warehouseRepo: warehouseRepo,
+		storeRepo:     storeRepo,
+		productRepo:   productRepo,
+		uuid:          uuid,
+	}
+}
+
+// CreateWarehouse registers a new warehouse for the seller's store.
+//
+// Errors:
+//
+//   - 400 Bad Request: if the request is invalid.
+//   - 404 Not Found: if the seller has no store.
+func (uc *WarehouseUseCase) CreateWarehouse(ctx context.Context, request *model.RegisterWarehouse) (*model.WarehouseResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	warehouse := &entity.Warehouse{
+		WarehouseUUID: uc.uuid.Generate(),
+		StoreID:       store.ID,
+		Name:          request.Name,
+		Address:       request.Address,
+		City:          request.City,
+		Province:      request.Province,
+		PostalCode:    request.PostalCode,
+		Priority:      request.Priority,
+		IsActive:      true,
+	}
+	if err := uc.warehouseRepo.CreateWarehouse(warehouse); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.WarehouseToResponse(warehouse), nil
+}
+
+// GetWarehouses lists every warehouse of the seller's store, highest priority first.
+func (uc *WarehouseUseCase) GetWarehouses(ctx context.Context, userID uint) ([]model.WarehouseResponse, error) {
+	store, err := uc.storeRepo.FindStoreByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := uc.warehouseRepo.FindWarehousesByStore(uc.db, store.ID, false)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	responses := make([]model.WarehouseResponse, len(warehouses))
+	for i, warehouse := range warehouses {
+		responses[i] = *converter.WarehouseToResponse(&warehouse)
+	}
+	return responses, nil
+}
+
+// UpdateWarehouse updates the details of a warehouse of the seller's store.
+// Deactivated warehouses keep their stock but are skipped by order allocation.
+func (uc *WarehouseUseCase) UpdateWarehouse(ctx context.Context, request *model.UpdateWarehouse) (*model.WarehouseResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	store, err := uc.storeRepo.FindStoreByUserID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	warehouse, err := uc.warehouseRepo.FindWarehouseByUUID(store.ID, request.WarehouseUUID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Name != "" {
+		warehouse.Name = request.Name
+	}
+	if request.Address != "" {
+		warehouse.Address = request.Address
+	}
+	if request.City != "" {
+		warehouse.City = request.City
+	}
+	if request.Province != "" {
+		warehouse.Province = request.Province
+	}
+	if request.PostalCode != "" {
+		warehouse.PostalCode = request.PostalCode
+	}
+	if request.Priority != nil {
+		warehouse.Priority = *request.Priority
+	}
+	if request.IsActive != nil {
+		warehouse.IsActive = *request.IsActive
+	}
+	if err := uc.warehouseRepo.UpdateWarehouse(warehouse); err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.WarehouseToResponse(warehouse), nil
+}
+
+// GetProductStock returns the stock of a seller's product broken down per
+// warehouse. Unallocated is the part of the total stock that is not assigned
+// to any warehouse.
+func (uc *WarehouseUseCase) GetProductStock(ctx context.Context, request *model.GetProductRequest) (*model.ProductStockResponse, error) {
+	if err := helper.ValidateStruct(uc.val, request); err != nil {
+		return nil, err
+	}
+	product, err := uc.productRepo.GetProductById(request.UserID, request.ProductUUID)
+	if err != nil {
+		return nil, err
+	}
+	stocks, err := uc.warehouseRepo.GetStocksByProduct(product.ID)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	return converter.ProductStockToResponse(product, stocks), nil
+}
+
+// Allocate chooses the warehouse that fulfils an order of the given store.
+//
+// quantities maps product IDs to the ordered quantity and products maps them
+// to the product rows the caller loaded, locked when the allocation is used
+// for an order. Only active warehouses
+// that hold enough stock for every item are considered. A warehouse in the
+// same province as the shipping address wins, otherwise the warehouse with the
+// highest priority is used. The stock rows are locked, so the choice stays
+// valid until the transaction ends.
+//
+// It returns nil without an error when the store has no active warehouse, or
+// when no warehouse can fulfil the order but the stock not assigned to any
+// warehouse can, such as stock from before the store's first warehouse. The
+// order is then fulfilled from the product stock alone. It returns a 409
+// error when neither a single warehouse nor the unallocated stock can fulfil
+// the whole order.
+func (uc *WarehouseUseCase) Allocate(ctx context.Context, tx *gorm.DB, storeID uint, province string, quantities map[uint]int, products map[uint]entity.Product) (*entity.Warehouse, error) {
+	warehouses, err := uc.warehouseRepo.FindWarehousesByStore(tx, storeID, true)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	if len(warehouses) == 0 {
+		return nil, nil
+	}
+
+	warehouseIDs := make([]uint, len(warehouses))
+	for i, warehouse := range warehouses {
+		warehouseIDs[i] = warehouse.ID
+	}
+	productIDs := make([]uint, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	stocks, err := uc.warehouseRepo.FindStocksForUpdate(tx, warehouseIDs, productIDs)
+	if err != nil {
+		return nil, model.ErrInternalServer
+	}
+	available := make(map[uint]map[uint]int, len(warehouses))
+	for _, stock := range stocks {
+		if available[stock.WarehouseID] == nil {
+			available[stock.WarehouseID] = make(map[uint]int)
+		}
+		available[stock.WarehouseID][stock.ProductID] = stock.Stock
+	}
+
+	var chosen *entity.Warehouse
+	for i := range warehouses {
+		warehouse := &warehouses[i]
+		if !canFulfil(available[warehouse.ID], quantities) {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(warehouse.Province), strings.TrimSpace(province)) {
+			return warehouse, nil
+		}
+		if chosen == nil {
+			chosen = warehouse
+		}
+	}
+	if chosen == nil {
+		unallocated, err := uc.unallocatedStock(tx, storeID, productIDs, products)
+		if err != nil {
+			return nil, model.ErrInternalServer
+		}
+		if canFulfil(unallocated, quantities) {
+			return nil, nil
+		}
+		return nil, model.NewApiError(fiber.StatusConflict, "No warehouse has enough stock to fulfil this order", nil)
+	}
+	return chosen, nil
+}
+
+// unallocatedStock returns the part of the stock of each product that is not
+// assigned to any warehouse of the store, active or not. The product stock is
+// taken from products, whose rows are expected to be locked by the caller;
+// the warehouse stock rows are locked here.
+func (uc *WarehouseUseCase) unallocatedStock(tx *gorm.DB, storeID uint, productIDs []uint, products map[uint]entity.Product) (map[uint]int, error) {
+	warehouses, err := uc.warehouseRepo.FindWarehousesByStore(tx, storeID, false)
+	if err != nil {
+		return nil, err
+	}
+	warehouseIDs := make([]uint, len(warehouses))
+	for i, warehouse := range warehouses {
+		warehouseIDs[i] = warehouse.ID
+	}
+	stocks, err := uc.warehouseRepo.FindStocksForUpdate(tx, warehouseIDs, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	unallocated := make(map[uint]int, len(productIDs))
+	for _, productID := range productIDs {
+		unallocated[productID] = products[productID].Stock
+	}
+	for _, stock := range stocks {
+		unallocated[stock.ProductID] -= stock.Stock
+	}
+	return unallocated, nil
+}
+
+func canFulfil(stock map[uint]int, quantities map[uint]int) bool {
+	for productID, quantity := range quantities {
+		if stock[productID] < quantity {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abdisetiakawan/go-ecommerce/internal/entity"
+	"github.com/abdisetiakawan/go-ecommerce/internal/model"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// TestCreateOrderAfterFirstWarehouse orders from a store that has just
+// created its first warehouse. Stock the warehouse does not hold yet is still
+// sold from the product stock, and an order is only refused when neither the
+// warehouse nor the unallocated stock can fulfil it.
+func TestCreateOrderAfterFirstWarehouse(t *testing.T) {
+	tests := []struct {
+		name           string
+		warehouseStock int
+		quantity       int
+		wantStatus     int
+		wantWarehouse  bool
+		wantStock      int
+		wantInStock    int
+	}{
+		{name: "warehouse holds nothing", warehouseStock: 0, quantity: 2, wantStock: 3, wantInStock: 0},
+		{name: "warehouse can fulfil", warehouseStock: 4, quantity: 3, wantWarehouse: true, wantStock: 2, wantInStock: 1},
+		{name: "neither can fulfil", warehouseStock: 4, quantity: 5, wantStatus: fiber.StatusConflict, wantStock: 5, wantInStock: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newStockDB(&stockRow{
+				id:          1,
+				productUUID: "7d1f6c0e-4d55-4b8a-9a43-0c2b6f3e9d11",
+				storeID:     1,
+				productName: "Keyboard",
+				price:       "1500.00",
+				currency:    "IDR",
+				stock:       5,
+			})
+			warehouses := &fakeWarehouseRepository{
+				warehouses: []entity.Warehouse{{Model: gorm.Model{ID: 1}, StoreID: 1, Province: "Jawa Timur", IsActive: true}},
+			}
+			if tt.warehouseStock > 0 {
+				warehouses.stocks = []entity.WarehouseStock{{WarehouseID: 1, ProductID: 1, Stock: tt.warehouseStock}}
+			}
+			orders := &fakeOrderRepository{}
+			uc := newTestOrderUseCase(t, db, orders, warehouses)
+
+			_, err := uc.CreateOrder(context.Background(), &model.CreateOrder{
+				UserID: 1,
+				Items:  []model.OrderItemRequest{{ProductUUID: "7d1f6c0e-4d55-4b8a-9a43-0c2b6f3e9d11", Quantity: tt.quantity}},
+				ShippingAddress: model.ShippingAddressRequest{
+					Address:    "Jl. Merdeka 1",
+					City:       "Bandung",
+					Province:   "Jawa Barat",
+					PostalCode: "40111",
+				},
+				Payments: model.PaymentRequest{PaymentMethod: "transfer"},
+			})
+			if tt.wantStatus != 0 {
+				var apiErr *model.ApiError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+					t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(orders.orders) != 1 {
+					t.Fatalf("created %d orders, want 1", len(orders.orders))
+				}
+				if got := orders.orders[0].WarehouseID != nil; got != tt.wantWarehouse {
+					t.Errorf("order from a warehouse = %v, want %v", got, tt.wantWarehouse)
+				}
+			}
+
+			if left := db.rows[1].stock; left != tt.wantStock {
+				t.Errorf("product stock %d, want %d", left, tt.wantStock)
+			}
+			inStock := 0
+			for _, stock := range warehouses.stocks {
+				inStock += stock.Stock
+			}
+			if inStock != tt.wantInStock {
+				t.Errorf("warehouse stock %d, want %d", inStock, tt.wantInStock)
+			}
+		})
+	}
+}
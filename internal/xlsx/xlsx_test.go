@@ -0,0 +1,146 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{1, "B"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, tt := range tests {
+		if got := columnName(tt.index); got != tt.want {
+			t.Errorf("columnName(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{`<b>"A&B"</b>`, "&lt;b&gt;&#34;A&amp;B&#34;&lt;/b&gt;"},
+		{"bell\x07 and nul\x00", "bell and nul"},
+		{"tab\tkept", "tab&#x9;kept"},
+	}
+	for _, tt := range tests {
+		if got := escape(tt.in); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+type sheetXML struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Style  string `xml:"s,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, `Orders & "returns"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]Cell{
+		{{Value: "Order", Bold: true}, {Value: "Total", Bold: true}},
+		{String("<ORD-1>"), Number("1250.50")},
+		{String("ORD-2"), Number("")},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	parts := map[string][]byte{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[file.Name] = content
+
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err != nil {
+				if err != io.EOF {
+					t.Errorf("%s is not well-formed: %v", file.Name, err)
+				}
+				break
+			}
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("workbook has no %s", name)
+		}
+	}
+	if !strings.Contains(string(parts["xl/workbook.xml"]), `name="Orders &amp; &#34;returns&#34;"`) {
+		t.Errorf("sheet name not escaped in workbook.xml: %s", parts["xl/workbook.xml"])
+	}
+
+	var sheet sheetXML
+	if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatal(err)
+	}
+	type cell struct{ ref, typ, style, value string }
+	var got [][]cell
+	for _, row := range sheet.Rows {
+		var cells []cell
+		for _, c := range row.Cells {
+			value := c.Value
+			if c.Type == "inlineStr" {
+				value = c.Inline
+			}
+			cells = append(cells, cell{c.Ref, c.Type, c.Style, value})
+		}
+		got = append(got, cells)
+	}
+	want := [][]cell{
+		{{"A1", "inlineStr", "1", "Order"}, {"B1", "inlineStr", "1", "Total"}},
+		{{"A2", "inlineStr", "", "<ORD-1>"}, {"B2", "", "", "1250.50"}},
+		// an empty number is written as an empty text cell
+		{{"A3", "inlineStr", "", "ORD-2"}, {"B3", "inlineStr", "", ""}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sheet cells = %v, want %v", got, want)
+	}
+}